@@ -13,9 +13,15 @@ import (
 	"github.com/mathvn/backend/config"
 	"github.com/mathvn/backend/internal/delivery/http/handler"
 	"github.com/mathvn/backend/internal/delivery/http/router"
+	"github.com/mathvn/backend/internal/repository"
+	"github.com/mathvn/backend/internal/repository/memory"
 	"github.com/mathvn/backend/internal/repository/postgres"
 	"github.com/mathvn/backend/internal/usecase"
 	"github.com/mathvn/backend/pkg/database"
+	"github.com/mathvn/backend/pkg/jwtkeys"
+	"github.com/mathvn/backend/pkg/mailer"
+	"github.com/mathvn/backend/pkg/oauth"
+	"github.com/mathvn/backend/pkg/sms"
 )
 
 func main() {
@@ -49,15 +55,123 @@ func main() {
 	progressRepo := postgres.NewProgressRepository(db)
 	consultationRepo := postgres.NewConsultationRepository(db)
 	statsRepo := postgres.NewStatsRepository(db)
+	verificationTokenRepo := postgres.NewEmailVerificationTokenRepository(db)
+	passwordResetRepo := postgres.NewPasswordResetTokenRepository(db)
+	securityEventRepo := postgres.NewSecurityEventRepository(db)
+	mfaRepo := postgres.NewMFARepository(db)
+	identityRepo := postgres.NewUserIdentityRepository(db)
+	loginOTPRepo := postgres.NewLoginOTPRepository(db)
+	auditLogRepo := postgres.NewAuditLogRepository(db)
+	rolePermissionRepo := postgres.NewRolePermissionRepository(db)
+	courseStaffRepo := postgres.NewCourseStaffRepository(db)
+	parentLinkRepo := postgres.NewParentLinkRepository(db)
+	activationCodeBatchRepo := postgres.NewActivationCodeBatchRepository(db)
+	courseBundleRepo := postgres.NewCourseBundleRepository(db)
+	codeRedemptionRepo := postgres.NewCodeRedemptionRepository(db)
+	deletionCodeRepo := postgres.NewAccountDeletionCodeRepository(db)
+	unitOfWork := postgres.NewUnitOfWork(db)
+
+	// Failed login counters can stay in memory for a single instance
+	var loginAttemptRepo repository.LoginAttemptRepository
+	if cfg.LoginLimit.Store == "memory" {
+		loginAttemptRepo = memory.NewLoginAttemptRepository()
+	} else {
+		loginAttemptRepo = postgres.NewLoginAttemptRepository(db)
+	}
+
+	// Initialize mailer
+	mail, err := mailer.New(&cfg.Mail)
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
+
+	// Initialize SMS sender
+	smsSender, err := sms.New(&cfg.SMS)
+	if err != nil {
+		log.Fatalf("Failed to initialize SMS sender: %v", err)
+	}
+
+	// Initialize access token signing keys
+	accessTokenKeys, err := jwtkeys.Load(&cfg.JWT)
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
+	}
+	if cfg.Server.Mode == "production" {
+		if accessTokenKeys.IsSymmetric() {
+			log.Fatal("JWT_KEYS_DIR or JWT_KEYS must be set in production, access tokens must be signed with RS256/EdDSA keys")
+		}
+		if cfg.JWT.UsesDefaultSecret() {
+			log.Fatal("JWT_ACCESS_TOKEN_SECRET and JWT_REFRESH_TOKEN_SECRET must be changed from their defaults in production")
+		}
+	}
+	if accessTokenKeys.IsSymmetric() {
+		log.Println("⚠️  Access tokens are signed with the shared HS256 secret, configure JWT_KEYS_DIR or JWT_KEYS to use RS256/EdDSA")
+	} else {
+		log.Printf("Access tokens are signed with key %s", accessTokenKeys.ActiveKeyID())
+	}
+
+	// Initialize social login providers
+	oauthProviders := oauth.New(&cfg.OAuth)
+	if cfg.OAuth.MockEnabled {
+		if cfg.Server.Mode == "production" {
+			log.Fatal("OAUTH_MOCK_ENABLED must not be set in production")
+		}
+		log.Println("⚠️  The mock social login provider is enabled, anyone can sign in as any email")
+	}
 
 	// Initialize use cases
-	authUseCase := usecase.NewAuthUseCase(userRepo, refreshTokenRepo, cfg.JWT, cfg.Bcrypt.Cost)
-	courseUseCase := usecase.NewCourseUseCase(courseRepo, userRepo)
-	enrollmentUseCase := usecase.NewEnrollmentUseCase(enrollmentRepo, activationCodeRepo, courseRepo, userRepo)
+	permissionUseCase := usecase.NewPermissionUseCase(rolePermissionRepo, cfg.RBAC.PermissionCacheTTL)
+	tokenVersions := usecase.NewTokenVersionCache(userRepo, cfg.JWT.TokenVersionCacheTTL)
+	authUseCase := usecase.NewAuthUseCase(
+		userRepo,
+		refreshTokenRepo,
+		verificationTokenRepo,
+		passwordResetRepo,
+		securityEventRepo,
+		loginAttemptRepo,
+		mfaRepo,
+		identityRepo,
+		loginOTPRepo,
+		mail,
+		smsSender,
+		oauthProviders,
+		permissionUseCase,
+		tokenVersions,
+		cfg.JWT,
+		accessTokenKeys,
+		cfg.Verification,
+		cfg.PasswordReset,
+		cfg.Session,
+		cfg.LoginLimit,
+		cfg.MFA,
+		cfg.OAuth,
+		cfg.OTPLogin,
+		cfg.Bcrypt.Cost,
+	)
+	courseUseCase := usecase.NewCourseUseCase(courseRepo, userRepo, enrollmentRepo, progressRepo, courseStaffRepo, unitOfWork, permissionUseCase, auditLogRepo)
+	enrollmentUseCase := usecase.NewEnrollmentUseCase(enrollmentRepo, activationCodeRepo, activationCodeBatchRepo, courseBundleRepo, codeRedemptionRepo, courseRepo, userRepo, unitOfWork, loginAttemptRepo, securityEventRepo, cfg.Verification.RequireVerifiedToActivate, cfg.Activation, auditLogRepo)
 	progressUseCase := usecase.NewProgressUseCase(progressRepo, enrollmentRepo)
-	consultationUseCase := usecase.NewConsultationUseCase(consultationRepo)
+	consultationUseCase := usecase.NewConsultationUseCase(consultationRepo, auditLogRepo)
 	statsUseCase := usecase.NewStatsUseCase(statsRepo)
-	adminUserUseCase := usecase.NewAdminUserUseCase(userRepo)
+	adminUserUseCase := usecase.NewAdminUserUseCase(userRepo, refreshTokenRepo, loginAttemptRepo, securityEventRepo, mfaRepo, courseRepo, enrollmentRepo, tokenVersions, auditLogRepo, cfg.Bcrypt.Cost)
+	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepo)
+	accountUseCase := usecase.NewAccountUseCase(
+		userRepo,
+		enrollmentRepo,
+		progressRepo,
+		refreshTokenRepo,
+		securityEventRepo,
+		mfaRepo,
+		identityRepo,
+		consultationRepo,
+		parentLinkRepo,
+		codeRedemptionRepo,
+		deletionCodeRepo,
+		authUseCase,
+		mail,
+		cfg.Account,
+	)
+	parentUseCase := usecase.NewParentUseCase(parentLinkRepo, userRepo, enrollmentRepo, progressRepo, progressUseCase, mail)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authUseCase)
@@ -67,14 +181,81 @@ func main() {
 	consultationHandler := handler.NewConsultationHandler(consultationUseCase)
 	statsHandler := handler.NewStatsHandler(statsUseCase)
 	adminUserHandler := handler.NewAdminUserHandler(adminUserUseCase)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogUseCase)
+	accountHandler := handler.NewAccountHandler(accountUseCase)
+	parentHandler := handler.NewParentHandler(parentUseCase)
 
 	// Setup router
 	engine := gin.New()
 	engine.Use(gin.Logger())
 
-	r := router.NewRouter(authHandler, courseHandler, enrollmentHandler, progressHandler, consultationHandler, statsHandler, adminUserHandler, authUseCase)
+	r := router.NewRouter(authHandler, courseHandler, enrollmentHandler, progressHandler, consultationHandler, statsHandler, adminUserHandler, auditLogHandler, accountHandler, parentHandler, authUseCase, permissionUseCase)
 	r.Setup(engine)
 
+	// Forget stale failed login and activation counters, they share one store
+	staleAttemptWindow := cfg.LoginLimit.AttemptWindow
+	if cfg.Activation.AttemptWindow > staleAttemptWindow {
+		staleAttemptWindow = cfg.Activation.AttemptWindow
+	}
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := loginAttemptRepo.DeleteStale(context.Background(), time.Now().Add(-staleAttemptWindow)); err != nil {
+				log.Printf("Failed to clean up login attempts: %v", err)
+			}
+		}
+	}()
+
+	// Delete login codes once they no longer count towards the hourly limit
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := loginOTPRepo.DeleteCreatedBefore(context.Background(), time.Now().Add(-time.Hour)); err != nil {
+				log.Printf("Failed to clean up login codes: %v", err)
+			}
+		}
+	}()
+
+	// Delete email verification links once they expire
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := verificationTokenRepo.DeleteExpired(context.Background()); err != nil {
+				log.Printf("Failed to clean up verification tokens: %v", err)
+			}
+		}
+	}()
+
+	// Delete password reset links and codes once they expire
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := passwordResetRepo.DeleteExpired(context.Background()); err != nil {
+				log.Printf("Failed to clean up password reset tokens: %v", err)
+			}
+		}
+	}()
+
+	// Anonymize accounts whose deletion grace period has ended
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			purged, err := accountUseCase.PurgeDeletedAccounts(context.Background())
+			if err != nil {
+				log.Printf("Failed to purge deleted accounts: %v", err)
+				continue
+			}
+			if purged > 0 {
+				log.Printf("Purged %d deleted accounts", purged)
+			}
+		}
+	}()
+
 	// Create HTTP server
 	srv := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
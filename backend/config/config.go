@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -10,10 +11,22 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Bcrypt   BcryptConfig
+	Server        ServerConfig
+	Database      DatabaseConfig
+	JWT           JWTConfig
+	Bcrypt        BcryptConfig
+	Mail          MailConfig
+	SMS           SMSConfig
+	Verification  VerificationConfig
+	PasswordReset PasswordResetConfig
+	Session       SessionConfig
+	LoginLimit    LoginLimitConfig
+	MFA           MFAConfig
+	OAuth         OAuthConfig
+	OTPLogin      OTPLoginConfig
+	RBAC          RBACConfig
+	Account       AccountConfig
+	Activation    ActivationLimitConfig
 }
 
 type ServerConfig struct {
@@ -36,19 +49,141 @@ type JWTConfig struct {
 	AccessTokenExpiryTime  time.Duration
 	RefreshTokenSecret     string
 	RefreshTokenExpiryTime time.Duration
+	TokenVersionCacheTTL   time.Duration // How long a token version lookup is reused, bounds how late revocation applies
+	KeysDir                string        // Directory of <kid>.pem signing keys for RS256/EdDSA access tokens
+	Keys                   string        // Comma separated kid=base64(PEM) signing keys
+	ActiveKeyID            string        // kid that signs new access tokens, others only verify
+	ImpersonationExpiry    time.Duration // Lifetime of access tokens an admin gets to act as another user
+}
+
+// Development fallbacks of the JWT secrets and the placeholders of env.example,
+// none of them may be used in production
+var defaultJWTSecrets = map[string]bool{
+	"default-access-secret-key":                                true,
+	"default-refresh-secret-key":                               true,
+	"your-super-secret-access-token-key-change-in-production":  true,
+	"your-super-secret-refresh-token-key-change-in-production": true,
+}
+
+// UsesDefaultSecret reports whether the access or refresh token secret is unset or a known default
+func (c *JWTConfig) UsesDefaultSecret() bool {
+	return c.AccessTokenSecret == "" || defaultJWTSecrets[c.AccessTokenSecret] ||
+		c.RefreshTokenSecret == "" || defaultJWTSecrets[c.RefreshTokenSecret]
 }
 
 type BcryptConfig struct {
 	Cost int
 }
 
+type MailConfig struct {
+	Driver       string // "smtp" or "log"
+	From         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	LogDir       string // Directory for the log driver to write .eml files, empty to only log
+}
+
+type SMSConfig struct {
+	Driver     string // "http" or "log"
+	GatewayURL string
+	APIKey     string
+	LogDir     string // Directory for the log driver to append sms.log, empty to only log
+}
+
+type VerificationConfig struct {
+	EmailTokenExpiryTime      time.Duration
+	EmailResendInterval       time.Duration
+	VerifyEmailURL            string // Frontend page that receives the token as ?token=
+	RequireVerifiedToActivate bool   // Block course activation for unverified accounts
+}
+
+type PasswordResetConfig struct {
+	LinkExpiryTime   time.Duration // Reset links sent by email
+	OTPExpiryTime    time.Duration // Numeric codes sent by SMS
+	MaxAttempts      int           // Wrong codes allowed before the code is voided
+	RequestInterval  time.Duration // Minimum interval between reset requests
+	ResetPasswordURL string        // Frontend page that receives the token as ?token=
+}
+
+// Session limit policies applied when a login would exceed the device limit
+const (
+	SessionLimitPolicyReject      = "reject"       // Refuse the new login
+	SessionLimitPolicyEvictOldest = "evict_oldest" // Log out the least recently used device
+)
+
+type SessionConfig struct {
+	MaxStudentSessions int // Active devices allowed per account by role, 0 for unlimited
+	MaxTeacherSessions int // Also applies to sales staff
+	MaxAdminSessions   int
+	LimitPolicy        string // SessionLimitPolicyReject or SessionLimitPolicyEvictOldest
+}
+
+type LoginLimitConfig struct {
+	Store               string        // "postgres" or "memory" for failed login counters
+	MaxAccountAttempts  int           // Failed logins per email/phone before lockout
+	MaxIPAttempts       int           // Failed logins per client IP before lockout
+	AttemptWindow       time.Duration // Failures older than this are forgotten
+	BaseLockoutDuration time.Duration // First lockout, doubled for each further failure
+	MaxLockoutDuration  time.Duration
+}
+
+type MFAConfig struct {
+	Issuer              string        // Account issuer shown in authenticator apps
+	RequiredRoles       []string      // Roles that must use two-factor authentication
+	ChallengeExpiryTime time.Duration // Lifetime of the token between password and code steps
+	RecoveryCodeCount   int
+}
+
+type OAuthConfig struct {
+	StateExpiryTime time.Duration // Lifetime of the state between starting a social login and the callback
+	Google          OAuthProviderConfig
+	Facebook        OAuthProviderConfig
+	MockEnabled     bool   // Local provider that accepts any email, never enable in production
+	MockRedirectURL string // Frontend callback the mock provider redirects to
+}
+
+type OTPLoginConfig struct {
+	CodeExpiryTime     time.Duration // Lifetime of a login code sent by SMS
+	MaxAttempts        int           // Wrong codes allowed before the code is voided
+	RequestInterval    time.Duration // Minimum interval between codes for one phone number
+	MaxRequestsPerHour int           // Codes one phone number can request per hour
+}
+
+type RBACConfig struct {
+	PermissionCacheTTL time.Duration // How long a role's permissions are reused, bounds how late a role_permissions change applies
+}
+
+type AccountConfig struct {
+	DeletionGracePeriod     time.Duration // Time before a deleted account is anonymized, logging in again cancels the deletion
+	DeletionCodeExpiryTime  time.Duration // Lifetime of the emailed code confirming a deletion
+	DeletionCodeMaxAttempts int           // Wrong codes allowed before the code is invalidated
+	DeletionCodeInterval    time.Duration // Minimum time between two codes for the same user
+}
+
+type ActivationLimitConfig struct {
+	MaxUserAttempts     int           // Failed code redemptions per user before lockout
+	MaxIPAttempts       int           // Failed code redemptions per client IP before lockout
+	AttemptWindow       time.Duration // Failures older than this are forgotten
+	BaseLockoutDuration time.Duration // First lockout, doubled for each further failure
+	MaxLockoutDuration  time.Duration
+}
+
+// OAuthProviderConfig holds the client credentials of a social login provider,
+// the provider is disabled while ClientID is empty
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // Frontend callback registered with the provider
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	// Load .env file if exists
 	if os.Getenv("SERVER_MODE") != "production" {
-    _ = godotenv.Load(".env.dev")
+		_ = godotenv.Load(".env.dev")
 	}
-	
 
 	// Access token expiry (default: 15 minutes)
 	accessTokenExpiryMinutes, err := strconv.Atoi(getEnv("JWT_ACCESS_TOKEN_EXPIRY_MINUTES", "15"))
@@ -62,11 +197,199 @@ func Load() (*Config, error) {
 		refreshTokenExpiryDays = 7
 	}
 
+	// Token version cache TTL (default: 30 seconds)
+	tokenVersionCacheSeconds, err := strconv.Atoi(getEnv("JWT_TOKEN_VERSION_CACHE_SECONDS", "30"))
+	if err != nil {
+		tokenVersionCacheSeconds = 30
+	}
+
+	// Impersonation token expiry (default: 15 minutes)
+	impersonationExpiryMinutes, err := strconv.Atoi(getEnv("JWT_IMPERSONATION_EXPIRY_MINUTES", "15"))
+	if err != nil {
+		impersonationExpiryMinutes = 15
+	}
+
 	bcryptCost, err := strconv.Atoi(getEnv("BCRYPT_COST", "10"))
 	if err != nil {
 		bcryptCost = 10
 	}
 
+	// Email verification token expiry (default: 24 hours)
+	emailTokenExpiryHours, err := strconv.Atoi(getEnv("EMAIL_VERIFICATION_TOKEN_EXPIRY_HOURS", "24"))
+	if err != nil {
+		emailTokenExpiryHours = 24
+	}
+
+	// Minimum interval between verification emails (default: 60 seconds)
+	emailResendIntervalSeconds, err := strconv.Atoi(getEnv("EMAIL_VERIFICATION_RESEND_INTERVAL_SECONDS", "60"))
+	if err != nil {
+		emailResendIntervalSeconds = 60
+	}
+
+	requireVerifiedToActivate, err := strconv.ParseBool(getEnv("REQUIRE_VERIFIED_EMAIL_TO_ACTIVATE", "false"))
+	if err != nil {
+		requireVerifiedToActivate = false
+	}
+
+	// Password reset link expiry (default: 60 minutes)
+	resetLinkExpiryMinutes, err := strconv.Atoi(getEnv("PASSWORD_RESET_LINK_EXPIRY_MINUTES", "60"))
+	if err != nil {
+		resetLinkExpiryMinutes = 60
+	}
+
+	// Password reset OTP expiry (default: 10 minutes)
+	resetOTPExpiryMinutes, err := strconv.Atoi(getEnv("PASSWORD_RESET_OTP_EXPIRY_MINUTES", "10"))
+	if err != nil {
+		resetOTPExpiryMinutes = 10
+	}
+
+	resetMaxAttempts, err := strconv.Atoi(getEnv("PASSWORD_RESET_MAX_ATTEMPTS", "5"))
+	if err != nil {
+		resetMaxAttempts = 5
+	}
+
+	resetRequestIntervalSeconds, err := strconv.Atoi(getEnv("PASSWORD_RESET_REQUEST_INTERVAL_SECONDS", "60"))
+	if err != nil {
+		resetRequestIntervalSeconds = 60
+	}
+
+	// Concurrent device limits (default: 2 for students, unlimited for staff)
+	maxStudentSessions, err := strconv.Atoi(getEnv("MAX_SESSIONS_STUDENT", "2"))
+	if err != nil {
+		maxStudentSessions = 2
+	}
+
+	maxTeacherSessions, err := strconv.Atoi(getEnv("MAX_SESSIONS_TEACHER", "0"))
+	if err != nil {
+		maxTeacherSessions = 0
+	}
+
+	maxAdminSessions, err := strconv.Atoi(getEnv("MAX_SESSIONS_ADMIN", "0"))
+	if err != nil {
+		maxAdminSessions = 0
+	}
+
+	// Login brute-force protection (default: lock an account after 5 failures, an IP after 50)
+	maxAccountLoginAttempts, err := strconv.Atoi(getEnv("LOGIN_MAX_ACCOUNT_ATTEMPTS", "5"))
+	if err != nil {
+		maxAccountLoginAttempts = 5
+	}
+
+	maxIPLoginAttempts, err := strconv.Atoi(getEnv("LOGIN_MAX_IP_ATTEMPTS", "50"))
+	if err != nil {
+		maxIPLoginAttempts = 50
+	}
+
+	loginAttemptWindowMinutes, err := strconv.Atoi(getEnv("LOGIN_ATTEMPT_WINDOW_MINUTES", "15"))
+	if err != nil {
+		loginAttemptWindowMinutes = 15
+	}
+
+	loginBaseLockoutSeconds, err := strconv.Atoi(getEnv("LOGIN_BASE_LOCKOUT_SECONDS", "60"))
+	if err != nil {
+		loginBaseLockoutSeconds = 60
+	}
+
+	loginMaxLockoutMinutes, err := strconv.Atoi(getEnv("LOGIN_MAX_LOCKOUT_MINUTES", "60"))
+	if err != nil {
+		loginMaxLockoutMinutes = 60
+	}
+
+	// Two-factor challenge expiry (default: 5 minutes)
+	mfaChallengeExpiryMinutes, err := strconv.Atoi(getEnv("MFA_CHALLENGE_EXPIRY_MINUTES", "5"))
+	if err != nil {
+		mfaChallengeExpiryMinutes = 5
+	}
+
+	mfaRecoveryCodeCount, err := strconv.Atoi(getEnv("MFA_RECOVERY_CODE_COUNT", "10"))
+	if err != nil {
+		mfaRecoveryCodeCount = 10
+	}
+
+	oauthStateExpiryMinutes, err := strconv.Atoi(getEnv("OAUTH_STATE_EXPIRY_MINUTES", "10"))
+	if err != nil {
+		oauthStateExpiryMinutes = 10
+	}
+
+	oauthMockEnabled, err := strconv.ParseBool(getEnv("OAUTH_MOCK_ENABLED", "false"))
+	if err != nil {
+		oauthMockEnabled = false
+	}
+
+	otpLoginExpiryMinutes, err := strconv.Atoi(getEnv("OTP_LOGIN_EXPIRY_MINUTES", "5"))
+	if err != nil {
+		otpLoginExpiryMinutes = 5
+	}
+
+	otpLoginMaxAttempts, err := strconv.Atoi(getEnv("OTP_LOGIN_MAX_ATTEMPTS", "5"))
+	if err != nil {
+		otpLoginMaxAttempts = 5
+	}
+
+	otpLoginRequestIntervalSeconds, err := strconv.Atoi(getEnv("OTP_LOGIN_REQUEST_INTERVAL_SECONDS", "60"))
+	if err != nil {
+		otpLoginRequestIntervalSeconds = 60
+	}
+
+	otpLoginMaxRequestsPerHour, err := strconv.Atoi(getEnv("OTP_LOGIN_MAX_REQUESTS_PER_HOUR", "5"))
+	if err != nil {
+		otpLoginMaxRequestsPerHour = 5
+	}
+
+	// Role permission cache TTL (default: 60 seconds)
+	permissionCacheSeconds, err := strconv.Atoi(getEnv("RBAC_PERMISSION_CACHE_SECONDS", "60"))
+	if err != nil {
+		permissionCacheSeconds = 60
+	}
+
+	// Account deletion grace period (default: 14 days)
+	deletionGraceDays, err := strconv.Atoi(getEnv("ACCOUNT_DELETION_GRACE_DAYS", "14"))
+	if err != nil {
+		deletionGraceDays = 14
+	}
+
+	// Account deletion codes, for accounts without a known password (default: 15 minutes, 5 attempts, 60 seconds between codes)
+	deletionCodeExpiryMinutes, err := strconv.Atoi(getEnv("ACCOUNT_DELETION_CODE_EXPIRY_MINUTES", "15"))
+	if err != nil {
+		deletionCodeExpiryMinutes = 15
+	}
+
+	deletionCodeMaxAttempts, err := strconv.Atoi(getEnv("ACCOUNT_DELETION_CODE_MAX_ATTEMPTS", "5"))
+	if err != nil {
+		deletionCodeMaxAttempts = 5
+	}
+
+	deletionCodeIntervalSeconds, err := strconv.Atoi(getEnv("ACCOUNT_DELETION_CODE_INTERVAL_SECONDS", "60"))
+	if err != nil {
+		deletionCodeIntervalSeconds = 60
+	}
+
+	// Activation code guessing protection (default: lock a user after 10 failures, an IP after 30)
+	maxUserActivationAttempts, err := strconv.Atoi(getEnv("ACTIVATION_MAX_USER_ATTEMPTS", "10"))
+	if err != nil {
+		maxUserActivationAttempts = 10
+	}
+
+	maxIPActivationAttempts, err := strconv.Atoi(getEnv("ACTIVATION_MAX_IP_ATTEMPTS", "30"))
+	if err != nil {
+		maxIPActivationAttempts = 30
+	}
+
+	activationAttemptWindowMinutes, err := strconv.Atoi(getEnv("ACTIVATION_ATTEMPT_WINDOW_MINUTES", "60"))
+	if err != nil {
+		activationAttemptWindowMinutes = 60
+	}
+
+	activationBaseLockoutMinutes, err := strconv.Atoi(getEnv("ACTIVATION_BASE_LOCKOUT_MINUTES", "5"))
+	if err != nil {
+		activationBaseLockoutMinutes = 5
+	}
+
+	activationMaxLockoutHours, err := strconv.Atoi(getEnv("ACTIVATION_MAX_LOCKOUT_HOURS", "24"))
+	if err != nil {
+		activationMaxLockoutHours = 24
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
@@ -86,10 +409,100 @@ func Load() (*Config, error) {
 			AccessTokenExpiryTime:  time.Duration(accessTokenExpiryMinutes) * time.Minute,
 			RefreshTokenSecret:     getEnv("JWT_REFRESH_TOKEN_SECRET", "default-refresh-secret-key"),
 			RefreshTokenExpiryTime: time.Duration(refreshTokenExpiryDays) * 24 * time.Hour,
+			TokenVersionCacheTTL:   time.Duration(tokenVersionCacheSeconds) * time.Second,
+			KeysDir:                getEnv("JWT_KEYS_DIR", ""),
+			Keys:                   getEnv("JWT_KEYS", ""),
+			ActiveKeyID:            getEnv("JWT_ACTIVE_KEY_ID", ""),
+			ImpersonationExpiry:    time.Duration(impersonationExpiryMinutes) * time.Minute,
 		},
 		Bcrypt: BcryptConfig{
 			Cost: bcryptCost,
 		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "log"),
+			From:         getEnv("MAIL_FROM", "MathVN <no-reply@mathvn.local>"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			LogDir:       getEnv("MAIL_LOG_DIR", ""),
+		},
+		SMS: SMSConfig{
+			Driver:     getEnv("SMS_DRIVER", "log"),
+			GatewayURL: getEnv("SMS_GATEWAY_URL", ""),
+			APIKey:     getEnv("SMS_API_KEY", ""),
+			LogDir:     getEnv("SMS_LOG_DIR", ""),
+		},
+		Verification: VerificationConfig{
+			EmailTokenExpiryTime:      time.Duration(emailTokenExpiryHours) * time.Hour,
+			EmailResendInterval:       time.Duration(emailResendIntervalSeconds) * time.Second,
+			VerifyEmailURL:            getEnv("VERIFY_EMAIL_URL", "http://localhost:3000/verify-email"),
+			RequireVerifiedToActivate: requireVerifiedToActivate,
+		},
+		PasswordReset: PasswordResetConfig{
+			LinkExpiryTime:   time.Duration(resetLinkExpiryMinutes) * time.Minute,
+			OTPExpiryTime:    time.Duration(resetOTPExpiryMinutes) * time.Minute,
+			MaxAttempts:      resetMaxAttempts,
+			RequestInterval:  time.Duration(resetRequestIntervalSeconds) * time.Second,
+			ResetPasswordURL: getEnv("RESET_PASSWORD_URL", "http://localhost:3000/reset-password"),
+		},
+		Session: SessionConfig{
+			MaxStudentSessions: maxStudentSessions,
+			MaxTeacherSessions: maxTeacherSessions,
+			MaxAdminSessions:   maxAdminSessions,
+			LimitPolicy:        getEnv("SESSION_LIMIT_POLICY", SessionLimitPolicyEvictOldest),
+		},
+		LoginLimit: LoginLimitConfig{
+			Store:               getEnv("LOGIN_ATTEMPT_STORE", "postgres"),
+			MaxAccountAttempts:  maxAccountLoginAttempts,
+			MaxIPAttempts:       maxIPLoginAttempts,
+			AttemptWindow:       time.Duration(loginAttemptWindowMinutes) * time.Minute,
+			BaseLockoutDuration: time.Duration(loginBaseLockoutSeconds) * time.Second,
+			MaxLockoutDuration:  time.Duration(loginMaxLockoutMinutes) * time.Minute,
+		},
+		MFA: MFAConfig{
+			Issuer:              getEnv("MFA_ISSUER", "MathVN"),
+			RequiredRoles:       splitList(getEnv("MFA_REQUIRED_ROLES", "admin")),
+			ChallengeExpiryTime: time.Duration(mfaChallengeExpiryMinutes) * time.Minute,
+			RecoveryCodeCount:   mfaRecoveryCodeCount,
+		},
+		OAuth: OAuthConfig{
+			StateExpiryTime: time.Duration(oauthStateExpiryMinutes) * time.Minute,
+			Google: OAuthProviderConfig{
+				ClientID:     getEnv("OAUTH_GOOGLE_CLIENT_ID", ""),
+				ClientSecret: getEnv("OAUTH_GOOGLE_CLIENT_SECRET", ""),
+				RedirectURL:  getEnv("OAUTH_GOOGLE_REDIRECT_URL", "http://localhost:3000/auth/callback/google"),
+			},
+			Facebook: OAuthProviderConfig{
+				ClientID:     getEnv("OAUTH_FACEBOOK_CLIENT_ID", ""),
+				ClientSecret: getEnv("OAUTH_FACEBOOK_CLIENT_SECRET", ""),
+				RedirectURL:  getEnv("OAUTH_FACEBOOK_REDIRECT_URL", "http://localhost:3000/auth/callback/facebook"),
+			},
+			MockEnabled:     oauthMockEnabled,
+			MockRedirectURL: getEnv("OAUTH_MOCK_REDIRECT_URL", "http://localhost:3000/auth/callback/mock"),
+		},
+		OTPLogin: OTPLoginConfig{
+			CodeExpiryTime:     time.Duration(otpLoginExpiryMinutes) * time.Minute,
+			MaxAttempts:        otpLoginMaxAttempts,
+			RequestInterval:    time.Duration(otpLoginRequestIntervalSeconds) * time.Second,
+			MaxRequestsPerHour: otpLoginMaxRequestsPerHour,
+		},
+		RBAC: RBACConfig{
+			PermissionCacheTTL: time.Duration(permissionCacheSeconds) * time.Second,
+		},
+		Account: AccountConfig{
+			DeletionGracePeriod:     time.Duration(deletionGraceDays) * 24 * time.Hour,
+			DeletionCodeExpiryTime:  time.Duration(deletionCodeExpiryMinutes) * time.Minute,
+			DeletionCodeMaxAttempts: deletionCodeMaxAttempts,
+			DeletionCodeInterval:    time.Duration(deletionCodeIntervalSeconds) * time.Second,
+		},
+		Activation: ActivationLimitConfig{
+			MaxUserAttempts:     maxUserActivationAttempts,
+			MaxIPAttempts:       maxIPActivationAttempts,
+			AttemptWindow:       time.Duration(activationAttemptWindowMinutes) * time.Minute,
+			BaseLockoutDuration: time.Duration(activationBaseLockoutMinutes) * time.Minute,
+			MaxLockoutDuration:  time.Duration(activationMaxLockoutHours) * time.Hour,
+		},
 	}, nil
 }
 
@@ -100,6 +513,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// splitList splits a comma separated value, skipping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // GetDSN returns PostgreSQL connection string
 func (d *DatabaseConfig) GetDSN() string {
 	if d.URL != "" {
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/usecase"
+)
+
+type AccountHandler struct {
+	accountUseCase usecase.AccountUseCase
+}
+
+func NewAccountHandler(accountUseCase usecase.AccountUseCase) *AccountHandler {
+	return &AccountHandler{
+		accountUseCase: accountUseCase,
+	}
+}
+
+// ExportData handles downloading the personal data of the current user
+// @Summary Export personal data
+// @Description Download the profile, enrollments, lesson progress, sessions and consultation requests of the current user
+// @Tags auth
+// @Produce json
+// @Produce application/zip
+// @Security BearerAuth
+// @Param format query string false "Export format (json, zip)" default(json)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/auth/me/export [get]
+func (h *AccountHandler) ExportData(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "zip" {
+		response.BadRequest(c, "Định dạng xuất không hợp lệ, chỉ hỗ trợ json hoặc zip")
+		return
+	}
+
+	export, err := h.accountUseCase.ExportData(c.Request.Context(), userID)
+	if err != nil {
+		h.handleAccountError(c, err)
+		return
+	}
+
+	if format == "json" {
+		response.OK(c, "Xuất dữ liệu cá nhân thành công", export)
+		return
+	}
+
+	archive, err := buildExportArchive(export)
+	if err != nil {
+		response.InternalServerError(c, "Không thể tạo tệp dữ liệu")
+		return
+	}
+
+	filename := fmt.Sprintf("mathvn-data-%s.zip", export.ExportedAt.Format("20060102"))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+// SendDeletionCode handles emailing a code that confirms deleting the current account
+// @Summary Send account deletion code
+// @Description Email a code to confirm deleting the current account. Accounts created through a social login have no known password and use this code instead.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Router /api/v1/auth/me/deletion-code [post]
+func (h *AccountHandler) SendDeletionCode(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.accountUseCase.SendDeletionCode(c.Request.Context(), userID)
+	if err != nil {
+		h.handleAccountError(c, err)
+		return
+	}
+
+	response.OK(c, "Mã xác nhận xóa tài khoản đã được gửi đến email của bạn", result)
+}
+
+// DeleteAccount handles deleting the current user's account
+// @Summary Delete account
+// @Description Schedule the current account for deletion after a grace period and log out every device. Logging in again before then cancels the deletion. Confirm with the current password or a code from /auth/me/deletion-code.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body usecase.DeleteAccountInput true "Current password or deletion code"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/auth/me [delete]
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.DeleteAccountInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	var client usecase.ClientInfo
+	setClientInfo(c, &client)
+
+	result, err := h.accountUseCase.RequestDeletion(c.Request.Context(), userID, &input, &client)
+	if err != nil {
+		h.handleAccountError(c, err)
+		return
+	}
+
+	response.OK(c, "Tài khoản sẽ bị xóa sau thời gian chờ, đăng nhập lại để hủy yêu cầu", result)
+}
+
+// buildExportArchive packs each part of the export into its own JSON file
+func buildExportArchive(export *domain.AccountExport) ([]byte, error) {
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"enrollments.json", export.Enrollments},
+		{"lesson_progress.json", export.LessonProgress},
+		{"sessions.json", export.Sessions},
+		{"consultation_requests.json", export.ConsultationRequests},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// handleAccountError handles account errors
+func (h *AccountHandler) handleAccountError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		response.NotFound(c, "Không tìm thấy người dùng")
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		response.BadRequest(c, "Mật khẩu không chính xác")
+	case errors.Is(err, domain.ErrAccountDeletionNotAllowed):
+		response.Forbidden(c, "Tài khoản quản trị viên không thể tự xóa")
+	case errors.Is(err, domain.ErrDeletionConfirmationMissing):
+		response.BadRequest(c, "Vui lòng nhập mật khẩu hoặc mã xác nhận xóa tài khoản")
+	case errors.Is(err, domain.ErrInvalidDeletionCode):
+		response.BadRequest(c, "Mã xác nhận xóa tài khoản không đúng")
+	case errors.Is(err, domain.ErrDeletionCodeExpired):
+		response.BadRequest(c, "Mã xác nhận xóa tài khoản đã hết hạn, vui lòng yêu cầu mã mới")
+	case errors.Is(err, domain.ErrDeletionCodeTooManyAttempts):
+		response.TooManyRequests(c, "Nhập sai quá nhiều lần, vui lòng yêu cầu mã mới")
+	case errors.Is(err, domain.ErrDeletionCodeTooSoon):
+		response.TooManyRequests(c, "Vui lòng đợi một lát trước khi yêu cầu mã mới")
+	default:
+		response.InternalServerError(c, "Đã xảy ra lỗi hệ thống")
+	}
+}
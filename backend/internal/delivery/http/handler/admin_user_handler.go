@@ -25,7 +25,7 @@ func NewAdminUserHandler(adminUserUseCase domain.AdminUserUseCase) *AdminUserHan
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param search query string false "Search by name/email/phone"
-// @Param role query string false "Filter by role (student, teacher, admin)"
+// @Param role query string false "Filter by role (student, teacher, admin, sales)"
 // @Success 200 {object} response.Response
 // @Router /api/v1/admin/users [get]
 func (h *AdminUserHandler) ListUsers(c *gin.Context) {
@@ -225,3 +225,122 @@ func (h *AdminUserHandler) ToggleUserStatus(c *gin.Context) {
 
 	response.OK(c, "User status updated successfully", nil)
 }
+
+// ListUserSessions lists the devices a user is logged in on
+// @Summary List user sessions
+// @Tags admin/users
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/users/{id}/sessions [get]
+func (h *AdminUserHandler) ListUserSessions(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	sessions, err := h.adminUserUseCase.ListUserSessions(c.Request.Context(), userID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFound(c, "User not found")
+			return
+		}
+		response.InternalServerError(c, "Failed to fetch user sessions")
+		return
+	}
+
+	response.OK(c, "User sessions fetched successfully", sessions)
+}
+
+// SetSessionLimit overrides how many devices a user may be logged in on
+// @Summary Set user session limit
+// @Tags admin/users
+// @Param id path string true "User ID"
+// @Param body body object true "Session limit payload, null restores the role default and 0 means unlimited"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/users/{id}/session-limit [put]
+func (h *AdminUserHandler) SetSessionLimit(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req struct {
+		MaxSessions *int `json:"max_sessions"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request")
+		return
+	}
+
+	err = h.adminUserUseCase.SetSessionLimit(c.Request.Context(), userID, req.MaxSessions)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidSessionLimit:
+			response.BadRequest(c, "Session limit must not be negative")
+		case domain.ErrUserNotFound:
+			response.NotFound(c, "User not found")
+		default:
+			response.InternalServerError(c, "Failed to update session limit")
+		}
+		return
+	}
+
+	response.OK(c, "Session limit updated successfully", nil)
+}
+
+// UnlockUser lifts a temporary login lockout caused by failed login attempts
+// @Summary Unlock user login
+// @Tags admin/users
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/users/{id}/unlock [post]
+func (h *AdminUserHandler) UnlockUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	err = h.adminUserUseCase.UnlockUser(c.Request.Context(), userID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFound(c, "User not found")
+			return
+		}
+		response.InternalServerError(c, "Failed to unlock user")
+		return
+	}
+
+	response.OK(c, "User unlocked successfully", nil)
+}
+
+// ResetUserMFA removes two-factor authentication from a user who lost their device
+// @Summary Reset user MFA
+// @Tags admin/users
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/users/{id}/mfa [delete]
+func (h *AdminUserHandler) ResetUserMFA(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	err = h.adminUserUseCase.ResetUserMFA(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			response.NotFound(c, "User not found")
+		case domain.ErrMFANotEnabled:
+			response.BadRequest(c, "User does not have two-factor authentication")
+		default:
+			response.InternalServerError(c, "Failed to reset two-factor authentication")
+		}
+		return
+	}
+
+	response.OK(c, "Two-factor authentication reset successfully", nil)
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/pkg/spreadsheet"
+)
+
+// maxImportFileSize bounds the size of an uploaded import file
+const maxImportFileSize = 5 << 20
+
+// ImportUsers creates users in bulk from a CSV or XLSX file
+// @Summary Import users
+// @Description The file needs full_name, email and phone_number columns and may have a course_ids column (IDs separated by ";"). Users get a generated password, returned in the report.
+// @Tags admin/users
+// @Accept multipart/form-data
+// @Produce json
+// @Produce text/csv
+// @Param file formData file true "CSV or XLSX file"
+// @Param dry_run formData bool false "Only validate the rows" default(false)
+// @Param enroll formData bool false "Enroll the users in the courses of their row" default(false)
+// @Param access_days formData int false "Days of access given by the enrollments" default(365)
+// @Param format query string false "Report format (json, csv)" default(json)
+// @Success 200 {object} response.Response
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/v1/admin/users/import [post]
+func (h *AdminUserHandler) ImportUsers(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		response.BadRequest(c, "Định dạng báo cáo không hợp lệ, chỉ hỗ trợ json hoặc csv")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "Thiếu tệp nhập người dùng")
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		response.BadRequest(c, "Tệp nhập người dùng không được vượt quá 5 MB")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(c, "Không thể đọc tệp nhập người dùng")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxImportFileSize))
+	if err != nil {
+		response.BadRequest(c, "Không thể đọc tệp nhập người dùng")
+		return
+	}
+
+	rows, err := spreadsheet.Read(fileHeader.Filename, data)
+	if err != nil {
+		if errors.Is(err, spreadsheet.ErrUnsupportedFormat) {
+			response.BadRequest(c, "Tệp nhập người dùng phải có định dạng .csv hoặc .xlsx")
+			return
+		}
+		response.BadRequest(c, "Không thể phân tích tệp nhập người dùng")
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+	enroll, _ := strconv.ParseBool(c.PostForm("enroll"))
+
+	accessDays := domain.DefaultAccessDays
+	if value := c.PostForm("access_days"); value != "" {
+		accessDays, err = strconv.Atoi(value)
+		if err != nil || accessDays <= 0 {
+			response.BadRequest(c, "Số ngày truy cập không hợp lệ")
+			return
+		}
+	}
+
+	result, err := h.adminUserUseCase.ImportUsers(c.Request.Context(), rows, domain.UserImportOptions{
+		DryRun:     dryRun,
+		Enroll:     enroll,
+		AccessDays: accessDays,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrImportEmpty):
+			response.BadRequest(c, "Tệp nhập người dùng không có dòng dữ liệu nào")
+		case errors.Is(err, domain.ErrImportMissingColumns):
+			response.BadRequest(c, "Tệp nhập người dùng phải có các cột full_name, email và phone_number")
+		case errors.Is(err, domain.ErrImportTooManyRows):
+			response.BadRequest(c, "Tệp nhập người dùng có quá nhiều dòng")
+		default:
+			response.InternalServerError(c, "Không thể nhập người dùng")
+		}
+		return
+	}
+
+	if format == "csv" {
+		filename := fmt.Sprintf("user-import-%s.csv", time.Now().Format("20060102-150405"))
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", importReportCSV(result))
+		return
+	}
+
+	if result.DryRun {
+		response.OK(c, "Tệp nhập người dùng hợp lệ", result)
+		return
+	}
+	response.Created(c, "Nhập người dùng thành công", result)
+}
+
+// importReportCSV writes one line per row with the initial passwords, to hand out to the users
+func importReportCSV(result *domain.UserImportResult) []byte {
+	var buf bytes.Buffer
+	buf.Write([]byte{0xEF, 0xBB, 0xBF}) // Lets Excel detect UTF-8 names
+
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"row", "full_name", "email", "phone_number", "status", "password", "course_ids", "enrolled", "errors"})
+	for _, row := range result.Rows {
+		courseIDs := make([]string, len(row.CourseIDs))
+		for i, id := range row.CourseIDs {
+			courseIDs[i] = id.String()
+		}
+
+		w.Write([]string{
+			strconv.Itoa(row.Row),
+			row.FullName,
+			row.Email,
+			row.PhoneNumber,
+			string(row.Status),
+			row.Password,
+			strings.Join(courseIDs, ";"),
+			strconv.Itoa(row.Enrolled),
+			strings.Join(row.Errors, "; "),
+		})
+	}
+	w.Flush()
+
+	return buf.Bytes()
+}
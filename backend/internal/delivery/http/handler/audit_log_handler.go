@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+type AuditLogHandler struct {
+	auditLogUseCase domain.AuditLogUseCase
+}
+
+func NewAuditLogHandler(auditLogUseCase domain.AuditLogUseCase) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditLogUseCase: auditLogUseCase,
+	}
+}
+
+// ListAuditLogs lists changes made by admins, newest first
+// @Summary List audit logs
+// @Tags admin/audit-logs
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param actor_id query string false "Filter by the admin who made the change"
+// @Param target_type query string false "Filter by target type (user, course, section, lesson, activation_code, consultation, ip_address)"
+// @Param target_id query string false "Filter by target ID"
+// @Param action query string false "Filter by action (e.g. user.update_role)"
+// @Param from query string false "Changes at or after this time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Changes before this time (RFC3339, or YYYY-MM-DD to include that whole day)"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/audit-logs [get]
+func (h *AuditLogHandler) ListAuditLogs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := &domain.AuditLogFilter{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+	}
+
+	if actorStr := c.Query("actor_id"); actorStr != "" {
+		actorID, err := uuid.Parse(actorStr)
+		if err != nil {
+			response.BadRequest(c, "Invalid actor ID")
+			return
+		}
+		filter.ActorID = &actorID
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, _, ok := parseAuditTime(fromStr)
+		if !ok {
+			response.BadRequest(c, "Invalid from date")
+			return
+		}
+		filter.From = &from
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, dateOnly, ok := parseAuditTime(toStr)
+		if !ok {
+			response.BadRequest(c, "Invalid to date")
+			return
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	result, err := h.auditLogUseCase.ListAuditLogs(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		response.InternalServerError(c, "Failed to fetch audit logs")
+		return
+	}
+
+	response.OK(c, "Audit logs fetched successfully", result)
+}
+
+// parseAuditTime accepts an RFC3339 timestamp or a plain date, and reports which one it got
+func parseAuditTime(value string) (time.Time, bool, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, true
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, true
+	}
+	return time.Time{}, false, false
+}
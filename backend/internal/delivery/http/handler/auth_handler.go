@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -24,7 +25,7 @@ func NewAuthHandler(authUseCase usecase.AuthUseCase) *AuthHandler {
 
 // Register handles user registration
 // @Summary Register a new user
-// @Description Register a new user with email and password
+// @Description Register a new student or parent account with email and password
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -40,6 +41,8 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	setClientInfo(c, &input.ClientInfo)
+
 	result, err := h.authUseCase.Register(c.Request.Context(), &input)
 	if err != nil {
 		h.handleAuthError(c, err)
@@ -72,18 +75,72 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	setClientInfo(c, &input.ClientInfo)
+
 	result, err := h.authUseCase.Login(c.Request.Context(), &input)
 	if err != nil {
 		h.handleAuthError(c, err)
 		return
 	}
 
-	response.OK(c, "Đăng nhập thành công", gin.H{
-		"user":          result.User,
-		"access_token":  result.AccessToken,
-		"refresh_token": result.RefreshToken,
-		"expires_in":    result.ExpiresIn,
-	})
+	respondLogin(c, result)
+}
+
+// RequestLoginOTP handles sending a login code by SMS
+// @Summary Request login code
+// @Description Send a one-time login code to a registered phone number
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body usecase.RequestLoginOTPInput true "Request login code input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Router /api/v1/auth/otp/request [post]
+func (h *AuthHandler) RequestLoginOTP(c *gin.Context) {
+	var input usecase.RequestLoginOTPInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	result, err := h.authUseCase.RequestLoginOTP(c.Request.Context(), &input)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Nếu số điện thoại đã được đăng ký, mã đăng nhập đã được gửi qua SMS", result)
+}
+
+// VerifyLoginOTP handles logging in with a code sent by SMS
+// @Summary Login with SMS code
+// @Description Log in with the phone number and the code sent by SMS
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body usecase.VerifyLoginOTPInput true "Verify login code input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Router /api/v1/auth/otp/verify [post]
+func (h *AuthHandler) VerifyLoginOTP(c *gin.Context) {
+	var input usecase.VerifyLoginOTPInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	setClientInfo(c, &input.ClientInfo)
+
+	result, err := h.authUseCase.VerifyLoginOTP(c.Request.Context(), &input)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	respondLogin(c, result)
 }
 
 // GetProfile handles getting current user profile
@@ -219,6 +276,8 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
+	setClientInfo(c, &input.ClientInfo)
+
 	result, err := h.authUseCase.RefreshToken(c.Request.Context(), &input)
 	if err != nil {
 		h.handleAuthError(c, err)
@@ -290,6 +349,658 @@ func (h *AuthHandler) LogoutAll(c *gin.Context) {
 	response.OK(c, "Đăng xuất khỏi tất cả thiết bị thành công", nil)
 }
 
+// Impersonate handles an admin logging in as another user
+// @Summary Impersonate user
+// @Description Get a short-lived access token to see the app as the user, the admin is kept in the act claim
+// @Tags admin/users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/users/{id}/impersonate [post]
+func (h *AuthHandler) Impersonate(c *gin.Context) {
+	// Get admin ID from context (set by auth middleware)
+	actorIDStr, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return
+	}
+
+	actorID, ok := actorIDStr.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID người dùng không hợp lệ")
+		return
+	}
+
+	var client usecase.ClientInfo
+	setClientInfo(c, &client)
+
+	result, err := h.authUseCase.Impersonate(c.Request.Context(), actorID, userID, &client)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Đăng nhập thay người dùng thành công", result)
+}
+
+// ListSessions handles listing the current user's sessions
+// @Summary List sessions
+// @Description List the devices the current user is logged in on
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return
+	}
+
+	userID, ok := userIDStr.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return
+	}
+
+	sessions, err := h.authUseCase.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách phiên đăng nhập thành công", sessions)
+}
+
+// RevokeSession handles logging out of one device
+// @Summary Revoke session
+// @Description Log the current user out of one device
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return
+	}
+
+	userID, ok := userIDStr.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID phiên đăng nhập không hợp lệ")
+		return
+	}
+
+	if err := h.authUseCase.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Đã đăng xuất khỏi thiết bị", nil)
+}
+
+// VerifyEmail handles email verification
+// @Summary Verify email
+// @Description Verify the user's email address using the token sent by email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body usecase.VerifyEmailInput true "Verify email input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/v1/auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var input usecase.VerifyEmailInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	if err := h.authUseCase.VerifyEmail(c.Request.Context(), &input); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Xác thực email thành công", nil)
+}
+
+// ResendVerification handles resending the verification email
+// @Summary Resend verification email
+// @Description Send a new email verification link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body usecase.ResendVerificationInput true "Resend verification input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/v1/auth/resend-verification [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var input usecase.ResendVerificationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	if err := h.authUseCase.ResendVerification(c.Request.Context(), &input); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Nếu email đã được đăng ký, một liên kết xác thực mới đã được gửi", nil)
+}
+
+// ForgotPassword handles password reset requests
+// @Summary Forgot password
+// @Description Send a reset link by email or an OTP by SMS, depending on the identifier
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body usecase.ForgotPasswordInput true "Forgot password input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/v1/auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var input usecase.ForgotPasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	result, err := h.authUseCase.ForgotPassword(c.Request.Context(), &input)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	message := "Nếu tài khoản tồn tại, liên kết đặt lại mật khẩu đã được gửi tới email của bạn"
+	if result.Channel == domain.PasswordResetChannelSMS {
+		message = "Nếu tài khoản tồn tại, mã OTP đã được gửi tới số điện thoại của bạn"
+	}
+
+	response.OK(c, message, result)
+}
+
+// ResetPassword handles setting a new password with a reset token or OTP
+// @Summary Reset password
+// @Description Set a new password using the link token sent by email or the OTP sent by SMS
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body usecase.ResetPasswordInput true "Reset password input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Router /api/v1/auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var input usecase.ResetPasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	if err := h.authUseCase.ResetPassword(c.Request.Context(), &input); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Đặt lại mật khẩu thành công, vui lòng đăng nhập lại", nil)
+}
+
+// VerifyMFA handles the second step of a login with two-factor authentication
+// @Summary Verify two-factor code
+// @Description Exchange the MFA challenge token from login and a TOTP or recovery code for tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body usecase.VerifyMFAInput true "Verify MFA input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Router /api/v1/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var input usecase.VerifyMFAInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	setClientInfo(c, &input.ClientInfo)
+
+	result, err := h.authUseCase.VerifyMFA(c.Request.Context(), &input)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Đăng nhập thành công", gin.H{
+		"user":          result.User,
+		"access_token":  result.AccessToken,
+		"refresh_token": result.RefreshToken,
+		"expires_in":    result.ExpiresIn,
+	})
+}
+
+// GetMFAStatus handles getting the two-factor authentication state
+// @Summary Get MFA status
+// @Description Get whether two-factor authentication is enabled or required for the current user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/auth/mfa [get]
+func (h *AuthHandler) GetMFAStatus(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return
+	}
+
+	userID, ok := userIDStr.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return
+	}
+
+	status, err := h.authUseCase.GetMFAStatus(c.Request.Context(), userID)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy trạng thái xác thực hai bước thành công", status)
+}
+
+// SetupTOTP handles starting TOTP enrollment
+// @Summary Set up TOTP
+// @Description Generate a TOTP secret and otpauth URI for an authenticator app
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/auth/mfa/totp/setup [post]
+func (h *AuthHandler) SetupTOTP(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return
+	}
+
+	userID, ok := userIDStr.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return
+	}
+
+	setup, err := h.authUseCase.SetupTOTP(c.Request.Context(), userID)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Quét mã bằng ứng dụng xác thực rồi nhập mã để xác nhận", setup)
+}
+
+// ConfirmTOTP handles finishing TOTP enrollment
+// @Summary Confirm TOTP
+// @Description Enable two-factor authentication with a code from the authenticator app and get recovery codes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body usecase.MFACodeInput true "MFA code input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/auth/mfa/totp/confirm [post]
+func (h *AuthHandler) ConfirmTOTP(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return
+	}
+
+	userID, ok := userIDStr.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return
+	}
+
+	var input usecase.MFACodeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	codes, err := h.authUseCase.ConfirmTOTP(c.Request.Context(), userID, &input)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Đã bật xác thực hai bước, vui lòng lưu mã khôi phục và đăng nhập lại", codes)
+}
+
+// DisableMFA handles turning off two-factor authentication
+// @Summary Disable MFA
+// @Description Turn off two-factor authentication with the password and a current code
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body usecase.DisableMFAInput true "Disable MFA input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/auth/mfa/disable [post]
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return
+	}
+
+	userID, ok := userIDStr.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return
+	}
+
+	var input usecase.DisableMFAInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	if err := h.authUseCase.DisableMFA(c.Request.Context(), userID, &input); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Đã tắt xác thực hai bước", nil)
+}
+
+// RegenerateRecoveryCodes handles replacing the recovery codes
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes, the old ones stop working
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body usecase.MFACodeInput true "MFA code input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/v1/auth/mfa/recovery-codes [post]
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return
+	}
+
+	userID, ok := userIDStr.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return
+	}
+
+	var input usecase.MFACodeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	codes, err := h.authUseCase.RegenerateRecoveryCodes(c.Request.Context(), userID, &input)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Đã tạo mã khôi phục mới", codes)
+}
+
+// OAuthProviders handles listing the social login providers
+// @Summary List social login providers
+// @Description List the enabled social login providers
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/v1/auth/oauth/providers [get]
+func (h *AuthHandler) OAuthProviders(c *gin.Context) {
+	response.OK(c, "Lấy danh sách phương thức đăng nhập thành công", gin.H{
+		"providers": h.authUseCase.OAuthProviders(),
+	})
+}
+
+// StartOAuthLogin handles starting a social login
+// @Summary Start social login
+// @Description Get the provider sign-in URL, the provider redirects back to the frontend with code and state.
+// @Description A nonce cookie is set, the callback must be sent from the same browser.
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Provider name, e.g. google or facebook"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/auth/oauth/{provider} [get]
+func (h *AuthHandler) StartOAuthLogin(c *gin.Context) {
+	result, err := h.authUseCase.StartOAuthLogin(c.Param("provider"))
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	setOAuthNonceCookie(c, result)
+	response.OK(c, "Tạo liên kết đăng nhập thành công", result)
+}
+
+// OAuthCallback handles finishing a social login
+// @Summary Finish social login
+// @Description Exchange the code and state from the provider redirect for tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name, e.g. google or facebook"
+// @Param input body usecase.OAuthCallbackInput true "OAuth callback input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/auth/oauth/{provider}/callback [post]
+func (h *AuthHandler) OAuthCallback(c *gin.Context) {
+	var input usecase.OAuthCallbackInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	setClientInfo(c, &input.ClientInfo)
+	input.Nonce = takeOAuthNonceCookie(c)
+
+	result, err := h.authUseCase.OAuthLogin(c.Request.Context(), c.Param("provider"), &input)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	respondLogin(c, result)
+}
+
+// StartOAuthLink handles starting to link a social login to the current user
+// @Summary Start linking a social login
+// @Description Get the provider sign-in URL for linking the provider account to the current user.
+// @Description The frontend sends the code and state of the redirect to the link callback instead of the login callback.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Provider name, e.g. google or facebook"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/auth/oauth/{provider}/link [get]
+func (h *AuthHandler) StartOAuthLink(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.authUseCase.StartOAuthLink(userID, c.Param("provider"))
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	setOAuthNonceCookie(c, result)
+	response.OK(c, "Tạo liên kết đăng nhập thành công", result)
+}
+
+// LinkOAuthIdentity handles finishing linking a social login to the current user
+// @Summary Finish linking a social login
+// @Description Exchange the code and state from the provider redirect and link the provider account to the current user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Provider name, e.g. google or facebook"
+// @Param input body usecase.OAuthCallbackInput true "OAuth callback input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/auth/oauth/{provider}/link/callback [post]
+func (h *AuthHandler) LinkOAuthIdentity(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.OAuthCallbackInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	setClientInfo(c, &input.ClientInfo)
+	input.Nonce = takeOAuthNonceCookie(c)
+
+	identity, err := h.authUseCase.LinkOAuthIdentity(c.Request.Context(), userID, c.Param("provider"), &input)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	response.OK(c, "Liên kết tài khoản mạng xã hội thành công", identity)
+}
+
+// The cookie holding the nonce of the social login started in the browser
+const (
+	oauthNonceCookie     = "oauth_nonce"
+	oauthNonceCookiePath = "/api/v1/auth/oauth"
+)
+
+// setOAuthNonceCookie keeps the nonce of a social login in an HttpOnly cookie
+func setOAuthNonceCookie(c *gin.Context, result *usecase.OAuthStartOutput) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthNonceCookie, result.Nonce, int(result.ExpiresIn), oauthNonceCookiePath, "", isSecureRequest(c), true)
+}
+
+// takeOAuthNonceCookie reads the nonce cookie and clears it, a nonce completes one callback only
+func takeOAuthNonceCookie(c *gin.Context) string {
+	nonce, err := c.Cookie(oauthNonceCookie)
+	if err != nil {
+		return ""
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthNonceCookie, "", -1, oauthNonceCookiePath, "", isSecureRequest(c), true)
+	return nonce
+}
+
+// isSecureRequest reports whether the client reached the API over HTTPS, also behind a proxy
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
+
+// JWKS publishes the public keys that verify access tokens
+// @Summary JSON Web Key Set
+// @Description Public keys for verifying access tokens, selected by the kid header
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwtkeys.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	// Verifiers refetch on an unknown kid, a short cache keeps rotation quick
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authUseCase.GetJWKS())
+}
+
+// respondLogin writes the result of a login, which may still need a second factor
+func respondLogin(c *gin.Context, result *usecase.AuthOutput) {
+	// The first factor was correct, the client continues with /auth/mfa/verify
+	if result.MFARequired {
+		response.OK(c, "Vui lòng nhập mã xác thực hai bước", gin.H{
+			"mfa_required": true,
+			"mfa_token":    result.MFAToken,
+			"expires_in":   result.ExpiresIn,
+		})
+		return
+	}
+
+	response.OK(c, "Đăng nhập thành công", gin.H{
+		"user":               result.User,
+		"access_token":       result.AccessToken,
+		"refresh_token":      result.RefreshToken,
+		"expires_in":         result.ExpiresIn,
+		"mfa_setup_required": result.MFASetupRequired,
+	})
+}
+
+// setClientInfo records the address and user agent of the requesting device
+func setClientInfo(c *gin.Context, info *usecase.ClientInfo) {
+	info.IPAddress = c.ClientIP()
+	info.UserAgent = c.Request.UserAgent()
+}
+
 // handleAuthError handles authentication errors
 func (h *AuthHandler) handleAuthError(c *gin.Context, err error) {
 	switch {
@@ -299,12 +1010,16 @@ func (h *AuthHandler) handleAuthError(c *gin.Context, err error) {
 		response.Conflict(c, "Email đã được sử dụng")
 	case errors.Is(err, domain.ErrInvalidCredentials):
 		response.Unauthorized(c, "Email/SĐT hoặc mật khẩu không đúng")
+	case errors.Is(err, domain.ErrAccountLocked):
+		response.TooManyRequests(c, "Tài khoản tạm thời bị khóa do đăng nhập sai nhiều lần, vui lòng thử lại sau")
 	case errors.Is(err, domain.ErrUserNotActive):
 		response.Forbidden(c, "Tài khoản đã bị vô hiệu hóa")
 	case errors.Is(err, domain.ErrInvalidEmail):
 		response.BadRequest(c, "Email không hợp lệ")
 	case errors.Is(err, domain.ErrInvalidPassword):
 		response.BadRequest(c, "Mật khẩu phải có ít nhất 8 ký tự")
+	case errors.Is(err, domain.ErrInvalidSignupRole):
+		response.BadRequest(c, "Chỉ có thể đăng ký tài khoản học sinh hoặc phụ huynh")
 	case errors.Is(err, domain.ErrInvalidToken):
 		response.Unauthorized(c, "Token không hợp lệ")
 	case errors.Is(err, domain.ErrTokenExpired):
@@ -315,10 +1030,64 @@ func (h *AuthHandler) handleAuthError(c *gin.Context, err error) {
 		response.Unauthorized(c, "Refresh token đã hết hạn")
 	case errors.Is(err, domain.ErrRefreshTokenRevoked):
 		response.Unauthorized(c, "Refresh token đã bị thu hồi")
+	case errors.Is(err, domain.ErrRefreshTokenReused):
+		response.Unauthorized(c, "Phát hiện phiên đăng nhập bất thường, vui lòng đăng nhập lại")
+	case errors.Is(err, domain.ErrCannotImpersonate):
+		response.Forbidden(c, "Không thể đăng nhập thay người dùng này")
+	case errors.Is(err, domain.ErrSessionNotFound):
+		response.NotFound(c, "Không tìm thấy phiên đăng nhập")
+	case errors.Is(err, domain.ErrSessionLimitReached):
+		response.Forbidden(c, "Tài khoản đã đăng nhập trên số thiết bị tối đa, vui lòng đăng xuất trên thiết bị khác hoặc liên hệ quản trị viên")
 	case errors.Is(err, domain.ErrPhoneNumberAlreadyExists):
 		response.Conflict(c, "Số điện thoại đã được sử dụng")
 	case errors.Is(err, domain.ErrInvalidPhoneNumber):
 		response.BadRequest(c, "Số điện thoại không hợp lệ")
+	case errors.Is(err, domain.ErrInvalidVerificationToken):
+		response.BadRequest(c, "Liên kết xác thực không hợp lệ")
+	case errors.Is(err, domain.ErrVerificationTokenExpired):
+		response.BadRequest(c, "Liên kết xác thực đã hết hạn")
+	case errors.Is(err, domain.ErrInvalidEmailOrPhone):
+		response.BadRequest(c, "Email hoặc số điện thoại không hợp lệ")
+	case errors.Is(err, domain.ErrInvalidResetToken):
+		response.BadRequest(c, "Mã đặt lại mật khẩu không hợp lệ")
+	case errors.Is(err, domain.ErrResetTokenExpired):
+		response.BadRequest(c, "Mã đặt lại mật khẩu đã hết hạn")
+	case errors.Is(err, domain.ErrResetTooManyAttempts):
+		response.TooManyRequests(c, "Nhập sai quá nhiều lần, vui lòng yêu cầu mã mới")
+	case errors.Is(err, domain.ErrInvalidLoginOTP):
+		response.Unauthorized(c, "Mã đăng nhập không đúng")
+	case errors.Is(err, domain.ErrLoginOTPExpired):
+		response.Unauthorized(c, "Mã đăng nhập đã hết hạn, vui lòng yêu cầu mã mới")
+	case errors.Is(err, domain.ErrLoginOTPTooManyAttempts):
+		response.TooManyRequests(c, "Nhập sai quá nhiều lần, vui lòng yêu cầu mã mới")
+	case errors.Is(err, domain.ErrLoginOTPTooSoon):
+		response.TooManyRequests(c, "Vui lòng đợi một lát trước khi yêu cầu mã mới")
+	case errors.Is(err, domain.ErrLoginOTPLimitReached):
+		response.TooManyRequests(c, "Số điện thoại đã yêu cầu quá nhiều mã, vui lòng thử lại sau")
+	case errors.Is(err, domain.ErrInvalidMFAToken):
+		response.Unauthorized(c, "Phiên xác thực hai bước không hợp lệ hoặc đã hết hạn, vui lòng đăng nhập lại")
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		response.BadRequest(c, "Mã xác thực không đúng")
+	case errors.Is(err, domain.ErrMFACodeReused):
+		response.BadRequest(c, "Mã xác thực đã được sử dụng, vui lòng đợi mã mới")
+	case errors.Is(err, domain.ErrMFANotEnabled):
+		response.BadRequest(c, "Tài khoản chưa bật xác thực hai bước")
+	case errors.Is(err, domain.ErrMFAAlreadyEnabled):
+		response.Conflict(c, "Xác thực hai bước đã được bật")
+	case errors.Is(err, domain.ErrMFASetupNotStarted):
+		response.BadRequest(c, "Vui lòng thiết lập ứng dụng xác thực trước")
+	case errors.Is(err, domain.ErrMFARequired):
+		response.Forbidden(c, "Tài khoản của bạn bắt buộc dùng xác thực hai bước")
+	case errors.Is(err, domain.ErrOAuthProviderNotFound):
+		response.NotFound(c, "Phương thức đăng nhập không được hỗ trợ")
+	case errors.Is(err, domain.ErrInvalidOAuthState):
+		response.BadRequest(c, "Phiên đăng nhập đã hết hạn, vui lòng thử lại")
+	case errors.Is(err, domain.ErrOAuthExchangeFailed):
+		response.Unauthorized(c, "Không thể xác thực với nhà cung cấp, vui lòng thử lại")
+	case errors.Is(err, domain.ErrOAuthEmailNotVerified):
+		response.BadRequest(c, "Không xác minh được email của tài khoản mạng xã hội, vui lòng đăng nhập bằng email rồi liên kết tài khoản mạng xã hội trong phần cài đặt")
+	case errors.Is(err, domain.ErrIdentityAlreadyLinked):
+		response.Conflict(c, "Tài khoản mạng xã hội đã được liên kết với tài khoản khác")
 	default:
 		response.InternalServerError(c, "Đã xảy ra lỗi hệ thống")
 	}
@@ -44,7 +44,17 @@ func (h *CourseHandler) GetCourse(c *gin.Context) {
 	var err error
 
 	if includeDetails {
-		course, err = h.courseUseCase.GetCourseWithDetails(c.Request.Context(), idOrSlug)
+		// Viewer identity is optional, it is set only when a valid token was sent
+		var viewerID *uuid.UUID
+		if userID, exists := c.Get("userID"); exists {
+			if id, ok := userID.(uuid.UUID); ok {
+				viewerID = &id
+			}
+		}
+		viewerRole, _ := c.Get("userRole")
+		role, _ := viewerRole.(domain.UserRole)
+
+		course, err = h.courseUseCase.GetCourseWithDetails(c.Request.Context(), idOrSlug, viewerID, role)
 	} else {
 		course, err = h.courseUseCase.GetCourse(c.Request.Context(), idOrSlug)
 	}
@@ -57,6 +67,51 @@ func (h *CourseHandler) GetCourse(c *gin.Context) {
 	response.OK(c, "Lấy thông tin khóa học thành công", course)
 }
 
+// GetLessonPlayback handles getting the media reference of a lesson
+// @Summary Get lesson playback
+// @Description Get the video of a lesson, requires an active enrollment unless the lesson is a preview
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param lessonId path string true "Lesson ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/lessons/{lessonId}/playback [get]
+func (h *CourseHandler) GetLessonPlayback(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return
+	}
+
+	roleValue, _ := c.Get("userRole")
+	role, _ := roleValue.(domain.UserRole)
+
+	lessonID, err := uuid.Parse(c.Param("lessonId"))
+	if err != nil {
+		response.BadRequest(c, "ID bài học không hợp lệ")
+		return
+	}
+
+	playback, err := h.courseUseCase.GetLessonPlayback(c.Request.Context(), userID, role, lessonID)
+	if err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy video bài học thành công", playback)
+}
+
 // ListCourses handles listing courses with filters
 // @Summary List courses
 // @Description Get a list of courses with filters, pagination, and sorting
@@ -349,6 +404,20 @@ func (h *CourseHandler) handleCourseError(c *gin.Context, err error) {
 		response.NotFound(c, "Không tìm thấy chương học")
 	case errors.Is(err, domain.ErrCourseLessonNotFound):
 		response.NotFound(c, "Không tìm thấy bài học")
+	case errors.Is(err, domain.ErrLessonAccessDenied):
+		response.Forbidden(c, "Bạn cần kích hoạt khóa học để xem bài học này")
+	case errors.Is(err, domain.ErrCourseAccessDenied):
+		response.Forbidden(c, "Bạn không có quyền thực hiện thao tác này trên khóa học")
+	case errors.Is(err, domain.ErrCourseStaffNotFound):
+		response.NotFound(c, "Người dùng không phải giảng viên của khóa học")
+	case errors.Is(err, domain.ErrInvalidCourseStaffRole):
+		response.BadRequest(c, "Vai trò giảng viên không hợp lệ (co_instructor hoặc ta)")
+	case errors.Is(err, domain.ErrCourseOwnerImmutable):
+		response.BadRequest(c, "Không thể thay đổi hoặc xóa giảng viên chính của khóa học")
+	case errors.Is(err, domain.ErrUserCannotTeach):
+		response.BadRequest(c, "Vai trò của người dùng không có quyền giảng dạy")
+	case errors.Is(err, domain.ErrUserNotFound):
+		response.NotFound(c, "Không tìm thấy người dùng")
 	default:
 		response.InternalServerError(c, "Đã xảy ra lỗi hệ thống")
 	}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// SetCourseStaffRequest represents the request body for adding a teacher to a course
+type SetCourseStaffRequest struct {
+	Role domain.CourseStaffRole `json:"role" binding:"required"` // co_instructor or ta
+}
+
+// ListCourseStaff handles listing the staff of a course
+// @Summary List course staff
+// @Tags admin/courses
+// @Param id path string true "Course ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/courses/{id}/staff [get]
+func (h *CourseHandler) ListCourseStaff(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID khóa học không hợp lệ")
+		return
+	}
+
+	staff, err := h.courseUseCase.ListCourseStaff(c.Request.Context(), courseID)
+	if err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách giảng viên thành công", staff)
+}
+
+// SetCourseStaff handles adding a co-instructor or TA to a course, or changing their role
+// @Summary Set course staff member
+// @Tags admin/courses
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param userId path string true "User ID"
+// @Param body body SetCourseStaffRequest true "Staff role"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/courses/{id}/staff/{userId} [put]
+func (h *CourseHandler) SetCourseStaff(c *gin.Context) {
+	courseID, userID, ok := parseCourseStaffParams(c)
+	if !ok {
+		return
+	}
+
+	var req SetCourseStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ")
+		return
+	}
+
+	staff, err := h.courseUseCase.SetCourseStaff(c.Request.Context(), courseID, userID, req.Role)
+	if err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Cập nhật giảng viên thành công", staff)
+}
+
+// RemoveCourseStaff handles removing a co-instructor or TA from a course
+// @Summary Remove course staff member
+// @Tags admin/courses
+// @Param id path string true "Course ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/courses/{id}/staff/{userId} [delete]
+func (h *CourseHandler) RemoveCourseStaff(c *gin.Context) {
+	courseID, userID, ok := parseCourseStaffParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.courseUseCase.RemoveCourseStaff(c.Request.Context(), courseID, userID); err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Xóa giảng viên khỏi khóa học thành công", nil)
+}
+
+// ListTeacherCourseStaff handles listing the staff of a course the current teacher is on
+// @Summary List staff of my course
+// @Tags teacher/courses
+// @Security BearerAuth
+// @Param id path string true "Course ID"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/courses/{id}/staff [get]
+func (h *CourseHandler) ListTeacherCourseStaff(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	courseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID khóa học không hợp lệ")
+		return
+	}
+
+	staff, err := h.courseUseCase.ListInstructorCourseStaff(c.Request.Context(), instructorID, courseID)
+	if err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách giảng viên thành công", staff)
+}
+
+// SetTeacherCourseStaff handles the course owner adding a co-instructor or TA
+// @Summary Set staff member of my course
+// @Tags teacher/courses
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param userId path string true "User ID"
+// @Param body body SetCourseStaffRequest true "Staff role"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/courses/{id}/staff/{userId} [put]
+func (h *CourseHandler) SetTeacherCourseStaff(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	courseID, userID, ok := parseCourseStaffParams(c)
+	if !ok {
+		return
+	}
+
+	var req SetCourseStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ")
+		return
+	}
+
+	staff, err := h.courseUseCase.SetInstructorCourseStaff(c.Request.Context(), instructorID, courseID, userID, req.Role)
+	if err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Cập nhật giảng viên thành công", staff)
+}
+
+// RemoveTeacherCourseStaff handles the course owner removing a co-instructor or TA
+// @Summary Remove staff member of my course
+// @Tags teacher/courses
+// @Security BearerAuth
+// @Param id path string true "Course ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/courses/{id}/staff/{userId} [delete]
+func (h *CourseHandler) RemoveTeacherCourseStaff(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	courseID, userID, ok := parseCourseStaffParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.courseUseCase.RemoveInstructorCourseStaff(c.Request.Context(), instructorID, courseID, userID); err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Xóa giảng viên khỏi khóa học thành công", nil)
+}
+
+// parseCourseStaffParams reads the course and user IDs from the path, responding 400 when invalid
+func parseCourseStaffParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	courseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID khóa học không hợp lệ")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		response.BadRequest(c, "ID người dùng không hợp lệ")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return courseID, userID, true
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// Teacher portal handlers, the use case only lets teachers touch courses they are on the staff of
+
+// ListTeacherCourses handles listing the courses the current teacher is on the staff of
+// @Summary List my courses
+// @Tags teacher/courses
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Router /api/v1/teacher/courses [get]
+func (h *CourseHandler) ListTeacherCourses(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	courses, total, err := h.courseUseCase.ListInstructorCourses(c.Request.Context(), instructorID, page, pageSize)
+	if err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách khóa học thành công", gin.H{
+		"items": courses,
+		"total": total,
+	})
+}
+
+// GetTeacherCourse handles getting a course of the current instructor with all lessons
+// @Summary Get my course
+// @Tags teacher/courses
+// @Security BearerAuth
+// @Param id path string true "Course ID"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/courses/{id} [get]
+func (h *CourseHandler) GetTeacherCourse(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	courseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID khóa học không hợp lệ")
+		return
+	}
+
+	course, err := h.courseUseCase.GetInstructorCourse(c.Request.Context(), instructorID, courseID)
+	if err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy thông tin khóa học thành công", course)
+}
+
+// UpdateTeacherCourse handles updating a course of the current instructor
+// @Summary Update my course
+// @Description Only the course owner may update it. Price, original price and status are kept, an admin sets them.
+// @Tags teacher/courses
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param body body domain.Course true "Course data"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/courses/{id} [put]
+func (h *CourseHandler) UpdateTeacherCourse(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	courseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID khóa học không hợp lệ")
+		return
+	}
+
+	var req domain.Course
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ")
+		return
+	}
+	req.ID = courseID
+
+	if err := h.courseUseCase.UpdateInstructorCourse(c.Request.Context(), instructorID, &req); err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Cập nhật khóa học thành công", req)
+}
+
+// ListTeacherCourseStudents handles listing the students of a course with their progress
+// @Summary List students of my course
+// @Tags teacher/courses
+// @Security BearerAuth
+// @Param id path string true "Course ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/courses/{id}/students [get]
+func (h *CourseHandler) ListTeacherCourseStudents(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	courseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID khóa học không hợp lệ")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	students, total, err := h.courseUseCase.ListCourseStudents(c.Request.Context(), instructorID, courseID, page, pageSize)
+	if err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách học viên thành công", gin.H{
+		"items": students,
+		"total": total,
+	})
+}
+
+// CreateTeacherSection handles adding a section to a course of the current instructor
+// @Summary Create section in my course
+// @Tags teacher/sections
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body domain.CourseSection true "Section data"
+// @Success 201 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/sections [post]
+func (h *CourseHandler) CreateTeacherSection(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CourseSection
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ")
+		return
+	}
+
+	if err := h.courseUseCase.CreateInstructorSection(c.Request.Context(), instructorID, &req); err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.Created(c, "Tạo chương học thành công", req)
+}
+
+// UpdateTeacherSection handles updating a section of a course of the current instructor
+// @Summary Update section in my course
+// @Tags teacher/sections
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Section ID"
+// @Param body body domain.CourseSection true "Section data"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/sections/{id} [put]
+func (h *CourseHandler) UpdateTeacherSection(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID chương học không hợp lệ")
+		return
+	}
+
+	var req domain.CourseSection
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ")
+		return
+	}
+	req.ID = id
+
+	if err := h.courseUseCase.UpdateInstructorSection(c.Request.Context(), instructorID, &req); err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Cập nhật chương học thành công", req)
+}
+
+// CreateTeacherLesson handles adding a lesson to a course of the current instructor
+// @Summary Create lesson in my course
+// @Tags teacher/lessons
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body domain.CourseLesson true "Lesson data"
+// @Success 201 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/lessons [post]
+func (h *CourseHandler) CreateTeacherLesson(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CourseLesson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ")
+		return
+	}
+
+	if err := h.courseUseCase.CreateInstructorLesson(c.Request.Context(), instructorID, &req); err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.Created(c, "Tạo bài học thành công", req)
+}
+
+// UpdateTeacherLesson handles updating a lesson of a course of the current instructor
+// @Summary Update lesson in my course
+// @Tags teacher/lessons
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Lesson ID"
+// @Param body body domain.CourseLesson true "Lesson data"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/teacher/lessons/{id} [put]
+func (h *CourseHandler) UpdateTeacherLesson(c *gin.Context) {
+	instructorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID bài học không hợp lệ")
+		return
+	}
+
+	var req domain.CourseLesson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ")
+		return
+	}
+	req.ID = id
+
+	if err := h.courseUseCase.UpdateInstructorLesson(c.Request.Context(), instructorID, &req); err != nil {
+		h.handleCourseError(c, err)
+		return
+	}
+
+	response.OK(c, "Cập nhật bài học thành công", req)
+}
+
+// currentUserID returns the authenticated user ID, responding 401 when it is missing
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "Chưa xác thực")
+		return uuid.Nil, false
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		response.Unauthorized(c, "Token không hợp lệ")
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
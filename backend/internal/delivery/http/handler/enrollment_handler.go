@@ -37,6 +37,7 @@ func NewEnrollmentHandler(enrollmentUseCase usecase.EnrollmentUseCase) *Enrollme
 // @Failure 401 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
 // @Router /api/v1/enrollments/activate [post]
 func (h *EnrollmentHandler) ActivateCourse(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
@@ -57,6 +58,7 @@ func (h *EnrollmentHandler) ActivateCourse(c *gin.Context) {
 		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
 		return
 	}
+	setClientInfo(c, &input.ClientInfo)
 
 	result, err := h.enrollmentUseCase.ActivateCourse(c.Request.Context(), userID, &input)
 	if err != nil {
@@ -165,7 +167,7 @@ func (h *EnrollmentHandler) CheckEnrollment(c *gin.Context) {
 // @Failure 403 {object} response.Response
 // @Router /api/v1/enrollments/activation-codes [post]
 func (h *EnrollmentHandler) CreateActivationCode(c *gin.Context) {
-	// Get user ID and role from context (set by auth middleware)
+	// Get user ID from context (set by auth middleware)
 	userIDValue, exists := c.Get("userID")
 	if !exists {
 		response.Unauthorized(c, "Chưa xác thực")
@@ -178,19 +180,6 @@ func (h *EnrollmentHandler) CreateActivationCode(c *gin.Context) {
 		return
 	}
 
-	// Check if user is admin
-	roleValue, exists := c.Get("userRole")
-	if !exists {
-		response.Forbidden(c, "Không có quyền truy cập")
-		return
-	}
-
-	role, ok := roleValue.(domain.UserRole)
-	if !ok || role != domain.RoleAdmin {
-		response.Forbidden(c, "Chỉ admin mới có quyền tạo mã kích hoạt")
-		return
-	}
-
 	var input usecase.CreateActivationCodeInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
@@ -219,12 +208,32 @@ func (h *EnrollmentHandler) handleEnrollmentError(c *gin.Context, err error) {
 		response.BadRequest(c, "Mã kích hoạt đã bị vô hiệu hoá")
 	case errors.Is(err, domain.ErrActivationCodeInvalid):
 		response.BadRequest(c, "Mã kích hoạt không hợp lệ")
+	case errors.Is(err, domain.ErrActivationLocked):
+		response.TooManyRequests(c, "Bạn đã nhập sai mã kích hoạt quá nhiều lần, vui lòng thử lại sau")
+	case errors.Is(err, domain.ErrActivationLockNotFound):
+		response.NotFound(c, "Không tìm thấy lượt khóa kích hoạt")
 	case errors.Is(err, domain.ErrAlreadyEnrolled):
 		response.Conflict(c, "Bạn đã đăng ký khoá học này rồi")
 	case errors.Is(err, domain.ErrEnrollmentNotFound):
 		response.NotFound(c, "Không tìm thấy đăng ký")
 	case errors.Is(err, domain.ErrCourseNotFound):
 		response.NotFound(c, "Không tìm thấy khoá học")
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		response.Forbidden(c, "Vui lòng xác thực email trước khi kích hoạt khoá học")
+	case errors.Is(err, domain.ErrActivationCodeBatchNotFound):
+		response.NotFound(c, "Không tìm thấy lô mã kích hoạt")
+	case errors.Is(err, domain.ErrInvalidActivationCodeBatch):
+		response.BadRequest(c, "Thông tin lô mã kích hoạt không hợp lệ")
+	case errors.Is(err, domain.ErrInvalidRedemptionInterval):
+		response.BadRequest(c, "Khoảng thời gian thống kê không hợp lệ")
+	case errors.Is(err, domain.ErrInvalidAccessPolicy):
+		response.BadRequest(c, "Thời hạn học hoặc danh sách khoá học của mã kích hoạt không hợp lệ")
+	case errors.Is(err, domain.ErrCourseBundleNotFound):
+		response.NotFound(c, "Không tìm thấy gói khoá học")
+	case errors.Is(err, domain.ErrInvalidCourseBundle):
+		response.BadRequest(c, "Thông tin gói khoá học không hợp lệ")
+	case errors.Is(err, domain.ErrCourseBundleInUse):
+		response.Conflict(c, "Gói khoá học đang được dùng bởi mã kích hoạt")
 	default:
 		response.InternalServerError(c, "Đã xảy ra lỗi hệ thống")
 	}
@@ -245,19 +254,6 @@ func (h *EnrollmentHandler) handleEnrollmentError(c *gin.Context, err error) {
 // @Failure 403 {object} response.Response
 // @Router /api/v1/enrollments/activation-codes [get]
 func (h *EnrollmentHandler) ListActivationCodes(c *gin.Context) {
-	// Check if user is admin
-	roleValue, exists := c.Get("userRole")
-	if !exists {
-		response.Forbidden(c, "Không có quyền truy cập")
-		return
-	}
-
-	role, ok := roleValue.(domain.UserRole)
-	if !ok || role != domain.RoleAdmin {
-		response.Forbidden(c, "Chỉ admin mới có quyền xem danh sách mã kích hoạt")
-		return
-	}
-
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
 	courseIDStr := c.Query("course_id")
@@ -295,19 +291,6 @@ func (h *EnrollmentHandler) ListActivationCodes(c *gin.Context) {
 // @Failure 403 {object} response.Response
 // @Router /api/v1/enrollments/activation-codes/{id} [delete]
 func (h *EnrollmentHandler) DeleteActivationCode(c *gin.Context) {
-	// Check if user is admin
-	roleValue, exists := c.Get("userRole")
-	if !exists {
-		response.Forbidden(c, "Không có quyền truy cập")
-		return
-	}
-
-	role, ok := roleValue.(domain.UserRole)
-	if !ok || role != domain.RoleAdmin {
-		response.Forbidden(c, "Chỉ admin mới có quyền xóa mã kích hoạt")
-		return
-	}
-
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -336,19 +319,6 @@ func (h *EnrollmentHandler) DeleteActivationCode(c *gin.Context) {
 // @Failure 403 {object} response.Response
 // @Router /api/v1/enrollments/activation-codes/{id} [put]
 func (h *EnrollmentHandler) UpdateActivationCode(c *gin.Context) {
-	// Check if user is admin
-	roleValue, exists := c.Get("userRole")
-	if !exists {
-		response.Forbidden(c, "Không có quyền truy cập")
-		return
-	}
-
-	role, ok := roleValue.(domain.UserRole)
-	if !ok || role != domain.RoleAdmin {
-		response.Forbidden(c, "Chỉ admin mới có quyền cập nhật mã kích hoạt")
-		return
-	}
-
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -0,0 +1,327 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/usecase"
+	"github.com/mathvn/backend/pkg/pdf"
+)
+
+// Card sheet layout: 2 columns x 5 rows of cards per A4 page
+const (
+	cardColumns    = 2
+	cardRows       = 5
+	cardPageMargin = 30.0
+	cardPadding    = 14.0
+)
+
+// CreateActivationCodeBatch handles generating activation codes in bulk (admin only)
+// @Summary Create activation code batch
+// @Description Generate a batch of activation codes for a course, e.g. for bookstores and schools. Codes of both formats end with a check character that rejects typos before the database lookup.
+// @Tags enrollments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body usecase.CreateActivationCodeBatchInput true "Batch creation input"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/activation-codes/batch [post]
+func (h *EnrollmentHandler) CreateActivationCodeBatch(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.CreateActivationCodeBatchInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	batch, err := h.enrollmentUseCase.CreateActivationCodeBatch(c.Request.Context(), userID, &input)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.Created(c, "Tạo lô mã kích hoạt thành công", batch)
+}
+
+// ListActivationCodeBatches handles listing activation code batches (admin only)
+// @Summary List activation code batches
+// @Description List activation code batches with optional course filter
+// @Tags enrollments
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Param course_id query string false "Filter by course ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/activation-codes/batches [get]
+func (h *EnrollmentHandler) ListActivationCodeBatches(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	courseIDStr := c.Query("course_id")
+	var courseID *string
+	if courseIDStr != "" {
+		courseID = &courseIDStr
+	}
+
+	batches, total, err := h.enrollmentUseCase.ListActivationCodeBatches(c.Request.Context(), page, pageSize, courseID)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách lô mã kích hoạt thành công", gin.H{
+		"items": batches,
+		"pagination": gin.H{
+			"page":        page,
+			"page_size":   pageSize,
+			"total":       total,
+			"total_pages": int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+	})
+}
+
+// GetActivationCodeBatch handles getting a batch with its codes, as JSON or as a CSV or PDF download (admin only)
+// @Summary Get activation code batch
+// @Description Get a batch with its codes. format=csv downloads a spreadsheet, format=pdf a printable card sheet
+// @Tags enrollments
+// @Produce json
+// @Produce text/csv
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Param format query string false "json (default), csv or pdf"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/activation-codes/batches/{id} [get]
+func (h *EnrollmentHandler) GetActivationCodeBatch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID không hợp lệ")
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" && format != "pdf" {
+		response.BadRequest(c, "Định dạng không hợp lệ")
+		return
+	}
+
+	batch, err := h.enrollmentUseCase.GetActivationCodeBatch(c.Request.Context(), id)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("activation-codes-%s.%s", batch.ID.String()[:8], format)
+	switch format {
+	case "csv":
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", activationCodeBatchCSV(batch))
+	case "pdf":
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusOK, "application/pdf", activationCodeBatchPDF(batch))
+	default:
+		response.OK(c, "Lấy lô mã kích hoạt thành công", batch)
+	}
+}
+
+// GetActivationCodeBatchStats handles getting the redemption analytics of a batch (admin only)
+// @Summary Get activation code batch stats
+// @Description Get the redemption rate of a batch over time and how many of its codes can still be used
+// @Tags enrollments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Param interval query string false "Timeline bucket: day (default), week or month"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/activation-codes/batches/{id}/stats [get]
+func (h *EnrollmentHandler) GetActivationCodeBatchStats(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID không hợp lệ")
+		return
+	}
+
+	interval := domain.RedemptionInterval(c.Query("interval"))
+	stats, err := h.enrollmentUseCase.GetActivationCodeBatchStats(c.Request.Context(), id, interval)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy thống kê lô mã kích hoạt thành công", stats)
+}
+
+// ListCodeRedemptions handles listing who redeemed an activation code (admin only)
+// @Summary List activation code redemptions
+// @Description List who redeemed an activation code, when and from which IP address, newest first
+// @Tags enrollments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Activation Code ID"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/activation-codes/{id}/redemptions [get]
+func (h *EnrollmentHandler) ListCodeRedemptions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID không hợp lệ")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	redemptions, total, err := h.enrollmentUseCase.ListCodeRedemptions(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy lịch sử sử dụng mã kích hoạt thành công", gin.H{
+		"items": redemptions,
+		"pagination": gin.H{
+			"page":        page,
+			"page_size":   pageSize,
+			"total":       total,
+			"total_pages": int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+	})
+}
+
+// activationCodeBatchCSV writes one line per code of the batch
+func activationCodeBatchCSV(batch *domain.ActivationCodeBatch) []byte {
+	var buf bytes.Buffer
+	buf.Write([]byte{0xEF, 0xBB, 0xBF}) // Lets Excel detect UTF-8 course titles
+
+	courseTitle := ""
+	if batch.Course != nil {
+		courseTitle = batch.Course.Title
+	}
+
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"code", "course", "batch", "max_uses", "current_uses", "expires_at", "access", "is_active"})
+	for _, code := range batch.Codes {
+		maxUses := ""
+		if code.MaxUses != nil {
+			maxUses = strconv.Itoa(*code.MaxUses)
+		}
+		expiresAt := ""
+		if code.ExpiresAt != nil {
+			expiresAt = code.ExpiresAt.Format(time.RFC3339)
+		}
+
+		w.Write([]string{
+			code.Code,
+			courseTitle,
+			batch.Label,
+			maxUses,
+			strconv.Itoa(code.CurrentUses),
+			expiresAt,
+			accessLabel(code),
+			strconv.FormatBool(code.IsActive),
+		})
+	}
+	w.Flush()
+
+	return buf.Bytes()
+}
+
+// activationCodeBatchPDF lays out the codes as cards with cut lines, ready to print and hand out
+func activationCodeBatchPDF(batch *domain.ActivationCodeBatch) []byte {
+	doc := pdf.New()
+
+	courseTitle := ""
+	if batch.Course != nil {
+		courseTitle = truncate(batch.Course.Title, 40)
+	}
+	expiry := "Không thời hạn"
+	if batch.ExpiresAt != nil {
+		expiry = batch.ExpiresAt.Format("02/01/2006")
+	}
+
+	cardWidth := (pdf.PageWidth - 2*cardPageMargin) / cardColumns
+	cardHeight := (pdf.PageHeight - 2*cardPageMargin) / cardRows
+
+	var page *pdf.Page
+	for i, code := range batch.Codes {
+		slot := i % (cardColumns * cardRows)
+		if slot == 0 {
+			page = doc.AddPage()
+		}
+
+		x := cardPageMargin + float64(slot%cardColumns)*cardWidth
+		top := pdf.PageHeight - cardPageMargin - float64(slot/cardColumns)*cardHeight
+		left := x + cardPadding
+
+		page.Rect(x, top-cardHeight, cardWidth, cardHeight, true)
+		page.Text(left, top-cardPadding-12, pdf.HelveticaBold, 12, courseTitle)
+		page.Text(left, top-cardPadding-32, pdf.Helvetica, 9, "Mã kích hoạt")
+		page.Text(left, top-cardPadding-56, pdf.CourierBold, 20, code.Code)
+		page.Text(left, top-cardPadding-80, pdf.Helvetica, 9, "Hạn sử dụng: "+expiry)
+		page.Text(left, top-cardPadding-94, pdf.Helvetica, 9, "Thời hạn học: "+accessLabel(code))
+		page.Text(left, top-cardPadding-108, pdf.Helvetica, 9, "Lô: "+truncate(batch.Label, 50))
+		page.Text(left, top-cardHeight+cardPadding+12, pdf.Helvetica, 8, "Đăng nhập, vào mục Kích hoạt khoá học")
+		page.Text(left, top-cardHeight+cardPadding, pdf.Helvetica, 8, "và nhập mã trên để bắt đầu học.")
+	}
+
+	return doc.Bytes()
+}
+
+// accessLabel describes how long a code gives access, e.g. "365 ngày"
+func accessLabel(code *domain.ActivationCode) string {
+	switch code.AccessType {
+	case domain.AccessTypeLifetime:
+		return "Trọn đời"
+	case domain.AccessTypeUntil:
+		if code.AccessUntil != nil {
+			return "Đến " + code.AccessUntil.Format("02/01/2006")
+		}
+	}
+
+	days := domain.DefaultAccessDays
+	if code.AccessDays != nil {
+		days = *code.AccessDays
+	}
+	return fmt.Sprintf("%d ngày", days)
+}
+
+// truncate shortens s to at most n characters so it fits on a card
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
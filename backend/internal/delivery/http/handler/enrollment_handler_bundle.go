@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/usecase"
+)
+
+// CreateCourseBundle handles creating a course bundle (admin only)
+// @Summary Create course bundle
+// @Description Create a package of courses that one activation code can unlock, e.g. "Grade 9 full year"
+// @Tags enrollments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body usecase.CourseBundleInput true "Bundle input"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/course-bundles [post]
+func (h *EnrollmentHandler) CreateCourseBundle(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.CourseBundleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	bundle, err := h.enrollmentUseCase.CreateCourseBundle(c.Request.Context(), userID, &input)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.Created(c, "Tạo gói khoá học thành công", bundle)
+}
+
+// ListCourseBundles handles listing course bundles (admin only)
+// @Summary List course bundles
+// @Description List course bundles, newest first
+// @Tags enrollments
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/course-bundles [get]
+func (h *EnrollmentHandler) ListCourseBundles(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	bundles, total, err := h.enrollmentUseCase.ListCourseBundles(c.Request.Context(), page, pageSize)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách gói khoá học thành công", gin.H{
+		"items": bundles,
+		"pagination": gin.H{
+			"page":        page,
+			"page_size":   pageSize,
+			"total":       total,
+			"total_pages": int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+	})
+}
+
+// GetCourseBundle handles getting a course bundle with its courses (admin only)
+// @Summary Get course bundle
+// @Description Get a course bundle with its courses
+// @Tags enrollments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bundle ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/course-bundles/{id} [get]
+func (h *EnrollmentHandler) GetCourseBundle(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID không hợp lệ")
+		return
+	}
+
+	bundle, err := h.enrollmentUseCase.GetCourseBundle(c.Request.Context(), id)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy gói khoá học thành công", bundle)
+}
+
+// UpdateCourseBundle handles updating a course bundle (admin only)
+// @Summary Update course bundle
+// @Description Update a course bundle. Codes already sold for the bundle unlock its new courses
+// @Tags enrollments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bundle ID"
+// @Param input body usecase.CourseBundleInput true "Bundle input"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/course-bundles/{id} [put]
+func (h *EnrollmentHandler) UpdateCourseBundle(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID không hợp lệ")
+		return
+	}
+
+	var input usecase.CourseBundleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	bundle, err := h.enrollmentUseCase.UpdateCourseBundle(c.Request.Context(), id, &input)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Cập nhật gói khoá học thành công", bundle)
+}
+
+// DeleteCourseBundle handles deleting a course bundle (admin only)
+// @Summary Delete course bundle
+// @Description Delete a course bundle that no activation code uses
+// @Tags enrollments
+// @Security BearerAuth
+// @Param id path string true "Bundle ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/admin/course-bundles/{id} [delete]
+func (h *EnrollmentHandler) DeleteCourseBundle(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID không hợp lệ")
+		return
+	}
+
+	if err := h.enrollmentUseCase.DeleteCourseBundle(c.Request.Context(), id); err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Xóa gói khoá học thành công", nil)
+}
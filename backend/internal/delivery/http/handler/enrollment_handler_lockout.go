@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"math"
+	"net"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+)
+
+// ListActivationLockouts handles listing users and IPs locked out of code redemption (admin only)
+// @Summary List activation lockouts
+// @Description List the users and client IPs temporarily blocked from redeeming activation codes after too many unknown codes, latest lock end first
+// @Tags enrollments
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/v1/admin/activation-lockouts [get]
+func (h *EnrollmentHandler) ListActivationLockouts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	lockouts, total, err := h.enrollmentUseCase.ListActivationLockouts(c.Request.Context(), page, pageSize)
+	if err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách khóa kích hoạt thành công", gin.H{
+		"items": lockouts,
+		"pagination": gin.H{
+			"page":        page,
+			"page_size":   pageSize,
+			"total":       total,
+			"total_pages": int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+	})
+}
+
+// ClearUserActivationLockout handles unlocking code redemption for a user (admin only)
+// @Summary Clear user activation lockout
+// @Description Let a user locked out for entering too many unknown codes redeem activation codes again
+// @Tags enrollments
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/activation-lockouts/users/{id} [delete]
+func (h *EnrollmentHandler) ClearUserActivationLockout(c *gin.Context) {
+	adminID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID không hợp lệ")
+		return
+	}
+
+	if err := h.enrollmentUseCase.ClearUserActivationLockout(c.Request.Context(), adminID, id); err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Mở khóa kích hoạt cho người dùng thành công", nil)
+}
+
+// ClearIPActivationLockout handles unlocking code redemption for a client IP (admin only)
+// @Summary Clear IP activation lockout
+// @Description Let a client IP locked out for too many unknown codes, e.g. a school network, redeem activation codes again
+// @Tags enrollments
+// @Security BearerAuth
+// @Param ip path string true "Client IP address"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/admin/activation-lockouts/ips/{ip} [delete]
+func (h *EnrollmentHandler) ClearIPActivationLockout(c *gin.Context) {
+	adminID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	ip := net.ParseIP(c.Param("ip"))
+	if ip == nil {
+		response.BadRequest(c, "Địa chỉ IP không hợp lệ")
+		return
+	}
+
+	if err := h.enrollmentUseCase.ClearIPActivationLockout(c.Request.Context(), adminID, ip.String()); err != nil {
+		h.handleEnrollmentError(c, err)
+		return
+	}
+
+	response.OK(c, "Mở khóa kích hoạt cho địa chỉ IP thành công", nil)
+}
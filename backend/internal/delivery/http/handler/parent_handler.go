@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/usecase"
+)
+
+type ParentHandler struct {
+	parentUseCase usecase.ParentUseCase
+}
+
+func NewParentHandler(parentUseCase usecase.ParentUseCase) *ParentHandler {
+	return &ParentHandler{
+		parentUseCase: parentUseCase,
+	}
+}
+
+// InviteChild handles a parent inviting a student account
+// @Summary Invite a child
+// @Description Send a link request to a student account found by email or phone number. The parent sees the student once the invite is accepted.
+// @Description The response is the same whether or not a student has the email or phone number.
+// @Tags parent
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body usecase.InviteChildInput true "Student email or phone number"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/v1/parent/children [post]
+func (h *ParentHandler) InviteChild(c *gin.Context) {
+	parentID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.InviteChildInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.BadRequest(c, "Dữ liệu không hợp lệ: "+err.Error())
+		return
+	}
+
+	if err := h.parentUseCase.InviteChild(c.Request.Context(), parentID, &input); err != nil {
+		h.handleParentError(c, err)
+		return
+	}
+
+	response.OK(c, "Nếu tài khoản học sinh tồn tại, lời mời liên kết đã được gửi", nil)
+}
+
+// ListChildren handles listing the children of the current parent
+// @Summary List children
+// @Description List the students who accepted the current parent's invite
+// @Tags parent
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Router /api/v1/parent/children [get]
+func (h *ParentHandler) ListChildren(c *gin.Context) {
+	parentID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	links, err := h.parentUseCase.ListChildren(c.Request.Context(), parentID)
+	if err != nil {
+		h.handleParentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách con thành công", links)
+}
+
+// RemoveChild handles cancelling an invite or unlinking a student
+// @Summary Remove a child
+// @Tags parent
+// @Produce json
+// @Security BearerAuth
+// @Param studentId path string true "Student ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/parent/children/{studentId} [delete]
+func (h *ParentHandler) RemoveChild(c *gin.Context) {
+	parentID, studentID, ok := parseChildParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.parentUseCase.RemoveChild(c.Request.Context(), parentID, studentID); err != nil {
+		h.handleParentError(c, err)
+		return
+	}
+
+	response.OK(c, "Đã hủy liên kết", nil)
+}
+
+// GetChildEnrollments handles listing the courses of a linked student
+// @Summary List a child's courses
+// @Tags parent
+// @Produce json
+// @Security BearerAuth
+// @Param studentId path string true "Student ID"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/parent/children/{studentId}/courses [get]
+func (h *ParentHandler) GetChildEnrollments(c *gin.Context) {
+	parentID, studentID, ok := parseChildParams(c)
+	if !ok {
+		return
+	}
+
+	enrollments, err := h.parentUseCase.GetChildEnrollments(c.Request.Context(), parentID, studentID)
+	if err != nil {
+		h.handleParentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách khóa học thành công", enrollments)
+}
+
+// GetChildCourseProgress handles a linked student's progress in a course
+// @Summary Get a child's course progress
+// @Tags parent
+// @Produce json
+// @Security BearerAuth
+// @Param studentId path string true "Student ID"
+// @Param courseId path string true "Course ID"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/parent/children/{studentId}/courses/{courseId}/progress [get]
+func (h *ParentHandler) GetChildCourseProgress(c *gin.Context) {
+	parentID, studentID, ok := parseChildParams(c)
+	if !ok {
+		return
+	}
+
+	courseID, err := uuid.Parse(c.Param("courseId"))
+	if err != nil {
+		response.BadRequest(c, "ID khóa học không hợp lệ")
+		return
+	}
+
+	progress, err := h.parentUseCase.GetChildCourseProgress(c.Request.Context(), parentID, studentID, courseID)
+	if err != nil {
+		h.handleParentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy tiến độ học tập thành công", progress)
+}
+
+// GetDashboard handles the parent dashboard
+// @Summary Parent dashboard
+// @Description Aggregate the course progress of every linked child
+// @Tags parent
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Router /api/v1/parent/dashboard [get]
+func (h *ParentHandler) GetDashboard(c *gin.Context) {
+	parentID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	dashboard, err := h.parentUseCase.GetDashboard(c.Request.Context(), parentID)
+	if err != nil {
+		h.handleParentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy tổng quan thành công", dashboard)
+}
+
+// ListParentLinks handles listing the parents of the current student
+// @Summary List parent links
+// @Description List the parents who invited or are linked to the current student
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Router /api/v1/auth/parents [get]
+func (h *ParentHandler) ListParentLinks(c *gin.Context) {
+	studentID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	links, err := h.parentUseCase.ListParentLinks(c.Request.Context(), studentID)
+	if err != nil {
+		h.handleParentError(c, err)
+		return
+	}
+
+	response.OK(c, "Lấy danh sách phụ huynh thành công", links)
+}
+
+// AcceptParentLink handles a student accepting a parent invite
+// @Summary Accept a parent invite
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Parent link ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/auth/parents/{id}/accept [post]
+func (h *ParentHandler) AcceptParentLink(c *gin.Context) {
+	studentID, linkID, ok := parseParentLinkParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.parentUseCase.AcceptParentLink(c.Request.Context(), studentID, linkID); err != nil {
+		h.handleParentError(c, err)
+		return
+	}
+
+	response.OK(c, "Đã chấp nhận liên kết phụ huynh", nil)
+}
+
+// RemoveParentLink handles a student declining an invite or unlinking a parent
+// @Summary Remove a parent link
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Parent link ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/v1/auth/parents/{id} [delete]
+func (h *ParentHandler) RemoveParentLink(c *gin.Context) {
+	studentID, linkID, ok := parseParentLinkParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.parentUseCase.RemoveParentLink(c.Request.Context(), studentID, linkID); err != nil {
+		h.handleParentError(c, err)
+		return
+	}
+
+	response.OK(c, "Đã hủy liên kết phụ huynh", nil)
+}
+
+// parseChildParams reads the current parent and the student in the path
+func parseChildParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	parentID, ok := currentUserID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	studentID, err := uuid.Parse(c.Param("studentId"))
+	if err != nil {
+		response.BadRequest(c, "ID học sinh không hợp lệ")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return parentID, studentID, true
+}
+
+// parseParentLinkParams reads the current student and the link in the path
+func parseParentLinkParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	studentID, ok := currentUserID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	linkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "ID liên kết không hợp lệ")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return studentID, linkID, true
+}
+
+// handleParentError handles parent link errors
+func (h *ParentHandler) handleParentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidEmailOrPhone):
+		response.BadRequest(c, "Email hoặc số điện thoại không hợp lệ")
+	case errors.Is(err, domain.ErrParentLinkNotFound):
+		response.NotFound(c, "Không tìm thấy liên kết")
+	case errors.Is(err, domain.ErrParentLinkNotAccepted):
+		response.Forbidden(c, "Học sinh chưa chấp nhận lời mời liên kết")
+	case errors.Is(err, domain.ErrEnrollmentNotFound):
+		response.NotFound(c, "Học sinh chưa đăng ký khóa học này")
+	case errors.Is(err, domain.ErrEnrollmentExpired):
+		response.Forbidden(c, "Khóa học của học sinh đã hết hạn")
+	default:
+		response.InternalServerError(c, "Đã xảy ra lỗi hệ thống")
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/usecase"
+)
+
+// AuditContextMiddleware attributes changes audited by the usecases to the
+// authenticated user, or to the admin behind an impersonation token.
+// Must run after AuthMiddleware.
+func AuditContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get("actorID")
+		if !ok {
+			value, ok = c.Get("userID")
+		}
+		if actorID, isUUID := value.(uuid.UUID); ok && isUUID {
+			ctx := usecase.WithAuditActor(c.Request.Context(), usecase.AuditActor{
+				UserID:    actorID,
+				IPAddress: c.ClientIP(),
+				UserAgent: c.Request.UserAgent(),
+			})
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		c.Next()
+	}
+}
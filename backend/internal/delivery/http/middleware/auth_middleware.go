@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"errors"
+	"log"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
 	"github.com/mathvn/backend/internal/usecase"
 )
 
@@ -30,23 +33,72 @@ func AuthMiddleware(authUseCase usecase.AuthUseCase) gin.HandlerFunc {
 		token := parts[1]
 
 		// Validate token
-		userID, err := authUseCase.ValidateToken(token)
+		claims, err := authUseCase.ValidateToken(token)
 		if err != nil {
 			response.Unauthorized(c, "Token không hợp lệ hoặc đã hết hạn")
 			c.Abort()
 			return
 		}
 
-		// Set user ID in context
-		c.Set("userID", userID)
+		// Reject tokens issued before a role change, deactivation or logout from all devices
+		if err := authUseCase.CheckTokenVersion(c.Request.Context(), claims); err != nil {
+			if errors.Is(err, domain.ErrTokenRevoked) {
+				response.Unauthorized(c, "Phiên đăng nhập đã hết hiệu lực, vui lòng đăng nhập lại")
+			} else {
+				response.InternalServerError(c, "Đã xảy ra lỗi hệ thống")
+			}
+			c.Abort()
+			return
+		}
+
+		// Set user ID and role in context (role is used for authorization checks)
+		c.Set("userID", claims.UserID)
+		c.Set("userRole", claims.Role)
+		c.Set("mfaVerified", claims.MFAVerified)
+		setActor(c, claims)
+
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware sets the user identity when a valid token is present,
+// but lets anonymous requests through for public routes
+func OptionalAuthMiddleware(authUseCase usecase.AuthUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			c.Next()
+			return
+		}
+
+		claims, err := authUseCase.ValidateToken(parts[1])
+		if err != nil {
+			c.Next()
+			return
+		}
 
-		// Fetch user profile to get role (for authorization checks)
-		profile, err := authUseCase.GetProfile(c.Request.Context(), userID)
-		if err == nil && profile != nil {
-			c.Set("userRole", profile.Role)
+		if err := authUseCase.CheckTokenVersion(c.Request.Context(), claims); err != nil {
+			c.Next()
+			return
 		}
 
+		c.Set("userID", claims.UserID)
+		c.Set("userRole", claims.Role)
+		c.Set("mfaVerified", claims.MFAVerified)
+		setActor(c, claims)
+
 		c.Next()
 	}
 }
 
+// setActor exposes the admin behind an impersonation token as actorID,
+// userID stays the impersonated user. Every such request is logged.
+func setActor(c *gin.Context, claims *usecase.AccessTokenClaims) {
+	if claims.ActorID == nil {
+		return
+	}
+
+	c.Set("actorID", *claims.ActorID)
+	log.Printf("impersonation: admin %s as user %s %s %s", *claims.ActorID, claims.UserID, c.Request.Method, c.Request.URL.Path)
+}
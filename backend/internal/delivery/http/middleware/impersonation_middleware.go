@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+)
+
+// BlockImpersonationMiddleware rejects requests made with an impersonation token,
+// for account changes only the user may make. Must run after AuthMiddleware.
+func BlockImpersonationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, impersonated := c.Get("actorID"); impersonated {
+			response.Forbidden(c, "Không thể thực hiện thao tác này khi đang đăng nhập thay người dùng")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/usecase"
+)
+
+// MFAPolicyMiddleware blocks roles that must use two-factor authentication
+// until they have signed in with a second factor. Must run after AuthMiddleware.
+func MFAPolicyMiddleware(authUseCase usecase.AuthUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("userRole")
+		userRole, _ := role.(domain.UserRole)
+
+		if !authUseCase.IsMFARequired(userRole) {
+			c.Next()
+			return
+		}
+
+		if verified, _ := c.Get("mfaVerified"); verified != true {
+			response.Forbidden(c, "Tài khoản của bạn cần bật xác thực hai bước để thực hiện thao tác này")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
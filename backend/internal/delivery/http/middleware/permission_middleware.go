@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mathvn/backend/internal/delivery/http/response"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/usecase"
+)
+
+// RequirePermission ensures the user's role grants every listed permission.
+// Must run after AuthMiddleware.
+func RequirePermission(permissionUseCase usecase.PermissionUseCase, permissions ...domain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, exists := c.Get("userRole")
+		if !exists {
+			response.Unauthorized(c, "Không tìm thấy thông tin quyền hạn")
+			c.Abort()
+			return
+		}
+		userRole, _ := role.(domain.UserRole)
+
+		for _, permission := range permissions {
+			allowed, err := permissionUseCase.HasPermission(c.Request.Context(), userRole, permission)
+			if err != nil {
+				response.InternalServerError(c, "Không thể kiểm tra quyền hạn")
+				c.Abort()
+				return
+			}
+			if !allowed {
+				response.Forbidden(c, "Bạn không có quyền thực hiện thao tác này")
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
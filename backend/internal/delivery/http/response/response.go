@@ -75,6 +75,11 @@ func Conflict(c *gin.Context, message string) {
 	Error(c, http.StatusConflict, "CONFLICT", message)
 }
 
+// TooManyRequests sends a 429 Too Many Requests response
+func TooManyRequests(c *gin.Context, message string) {
+	Error(c, http.StatusTooManyRequests, "TOO_MANY_REQUESTS", message)
+}
+
 // InternalServerError sends a 500 Internal Server Error response
 func InternalServerError(c *gin.Context, message string) {
 	Error(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/mathvn/backend/internal/delivery/http/handler"
 	"github.com/mathvn/backend/internal/delivery/http/middleware"
+	"github.com/mathvn/backend/internal/domain"
 	"github.com/mathvn/backend/internal/usecase"
 )
 
@@ -16,7 +17,11 @@ type Router struct {
 	consultationHandler *handler.ConsultationHandler
 	statsHandler        *handler.StatsHandler
 	adminUserHandler    *handler.AdminUserHandler
+	auditLogHandler     *handler.AuditLogHandler
+	accountHandler      *handler.AccountHandler
+	parentHandler       *handler.ParentHandler
 	authUseCase         usecase.AuthUseCase
+	permissionUseCase   usecase.PermissionUseCase
 }
 
 // NewRouter creates a new router
@@ -28,7 +33,11 @@ func NewRouter(
 	consultationHandler *handler.ConsultationHandler,
 	statsHandler *handler.StatsHandler,
 	adminUserHandler *handler.AdminUserHandler,
+	auditLogHandler *handler.AuditLogHandler,
+	accountHandler *handler.AccountHandler,
+	parentHandler *handler.ParentHandler,
 	authUseCase usecase.AuthUseCase,
+	permissionUseCase usecase.PermissionUseCase,
 ) *Router {
 	return &Router{
 		authHandler:         authHandler,
@@ -38,10 +47,19 @@ func NewRouter(
 		consultationHandler: consultationHandler,
 		statsHandler:        statsHandler,
 		adminUserHandler:    adminUserHandler,
+		auditLogHandler:     auditLogHandler,
+		accountHandler:      accountHandler,
+		parentHandler:       parentHandler,
 		authUseCase:         authUseCase,
+		permissionUseCase:   permissionUseCase,
 	}
 }
 
+// can returns a middleware that requires the permission
+func (r *Router) can(permission domain.Permission) gin.HandlerFunc {
+	return middleware.RequirePermission(r.permissionUseCase, permission)
+}
+
 // Setup configures all routes
 func (r *Router) Setup(engine *gin.Engine) {
 	// Global middlewares
@@ -56,6 +74,9 @@ func (r *Router) Setup(engine *gin.Engine) {
 		})
 	})
 
+	// Public keys for verifying access tokens
+	engine.GET("/.well-known/jwks.json", r.authHandler.JWKS)
+
 	// API v1 routes
 	v1 := engine.Group("/api/v1")
 	{
@@ -64,8 +85,18 @@ func (r *Router) Setup(engine *gin.Engine) {
 		{
 			auth.POST("/register", r.authHandler.Register)
 			auth.POST("/login", r.authHandler.Login)
+			auth.POST("/otp/request", r.authHandler.RequestLoginOTP)
+			auth.POST("/otp/verify", r.authHandler.VerifyLoginOTP)
 			auth.POST("/refresh-token", r.authHandler.RefreshToken)
 			auth.POST("/logout", r.authHandler.Logout)
+			auth.POST("/verify-email", r.authHandler.VerifyEmail)
+			auth.POST("/resend-verification", r.authHandler.ResendVerification)
+			auth.POST("/forgot-password", r.authHandler.ForgotPassword)
+			auth.POST("/reset-password", r.authHandler.ResetPassword)
+			auth.POST("/mfa/verify", r.authHandler.VerifyMFA)
+			auth.GET("/oauth/providers", r.authHandler.OAuthProviders)
+			auth.GET("/oauth/:provider", r.authHandler.StartOAuthLogin)
+			auth.POST("/oauth/:provider/callback", r.authHandler.OAuthCallback)
 		}
 
 		// Protected auth routes
@@ -73,29 +104,61 @@ func (r *Router) Setup(engine *gin.Engine) {
 		authProtected.Use(middleware.AuthMiddleware(r.authUseCase))
 		{
 			authProtected.GET("/profile", r.authHandler.GetProfile)
-			authProtected.PUT("/profile", r.authHandler.UpdateProfile)
-			authProtected.POST("/change-password", r.authHandler.ChangePassword)
-			authProtected.POST("/logout-all", r.authHandler.LogoutAll)
+			authProtected.GET("/sessions", r.authHandler.ListSessions)
+			authProtected.GET("/mfa", r.authHandler.GetMFAStatus)
+		}
+
+		// Account changes that an admin impersonating the user must not make
+		account := v1.Group("/auth")
+		account.Use(middleware.AuthMiddleware(r.authUseCase))
+		account.Use(middleware.BlockImpersonationMiddleware())
+		{
+			account.PUT("/profile", r.authHandler.UpdateProfile)
+			account.POST("/change-password", r.authHandler.ChangePassword)
+			account.POST("/logout-all", r.authHandler.LogoutAll)
+			account.DELETE("/sessions/:id", r.authHandler.RevokeSession)
+			account.POST("/mfa/totp/setup", r.authHandler.SetupTOTP)
+			account.POST("/mfa/totp/confirm", r.authHandler.ConfirmTOTP)
+			account.POST("/mfa/disable", r.authHandler.DisableMFA)
+			account.POST("/mfa/recovery-codes", r.authHandler.RegenerateRecoveryCodes)
+			account.GET("/me/export", r.accountHandler.ExportData)
+			account.POST("/me/deletion-code", r.accountHandler.SendDeletionCode)
+			account.DELETE("/me", r.accountHandler.DeleteAccount)
+			account.GET("/parents", r.parentHandler.ListParentLinks)
+			account.POST("/parents/:id/accept", r.parentHandler.AcceptParentLink)
+			account.DELETE("/parents/:id", r.parentHandler.RemoveParentLink)
+			account.GET("/oauth/:provider/link", r.authHandler.StartOAuthLink)
+			account.POST("/oauth/:provider/link/callback", r.authHandler.LinkOAuthIdentity)
 		}
 
 		// Public course routes
 		courses := v1.Group("/courses")
+		courses.Use(middleware.OptionalAuthMiddleware(r.authUseCase))
 		{
 			courses.GET("", r.courseHandler.ListCourses)
 			courses.GET("/:id", r.courseHandler.GetCourse)
 		}
 
+		// Protected lesson routes
+		lessons := v1.Group("/lessons")
+		lessons.Use(middleware.AuthMiddleware(r.authUseCase))
+		{
+			lessons.GET("/:lessonId/playback", r.courseHandler.GetLessonPlayback)
+		}
+
 		// Protected enrollment routes
 		enrollments := v1.Group("/enrollments")
 		enrollments.Use(middleware.AuthMiddleware(r.authUseCase))
+		enrollments.Use(middleware.MFAPolicyMiddleware(r.authUseCase))
+		enrollments.Use(middleware.AuditContextMiddleware())
 		{
 			enrollments.POST("/activate", r.enrollmentHandler.ActivateCourse)
 			enrollments.GET("/my-courses", r.enrollmentHandler.GetMyCourses)
 			enrollments.GET("/check/:courseId", r.enrollmentHandler.CheckEnrollment)
-			enrollments.POST("/activation-codes", r.enrollmentHandler.CreateActivationCode)
-			enrollments.GET("/activation-codes", r.enrollmentHandler.ListActivationCodes)
-			enrollments.DELETE("/activation-codes/:id", r.enrollmentHandler.DeleteActivationCode)
-			enrollments.PUT("/activation-codes/:id", r.enrollmentHandler.UpdateActivationCode)
+			enrollments.POST("/activation-codes", r.can(domain.PermissionCodesCreate), r.enrollmentHandler.CreateActivationCode)
+			enrollments.GET("/activation-codes", r.can(domain.PermissionCodesView), r.enrollmentHandler.ListActivationCodes)
+			enrollments.DELETE("/activation-codes/:id", r.can(domain.PermissionCodesManage), r.enrollmentHandler.DeleteActivationCode)
+			enrollments.PUT("/activation-codes/:id", r.can(domain.PermissionCodesManage), r.enrollmentHandler.UpdateActivationCode)
 		}
 
 		// Protected progress routes
@@ -114,42 +177,107 @@ func (r *Router) Setup(engine *gin.Engine) {
 			consultations.POST("", r.consultationHandler.CreateRequest)
 		}
 
-		// Admin routes - protected by AuthMiddleware + AdminMiddleware
+		// Parent routes - read-only access to the linked children who accepted the invite
+		parent := v1.Group("/parent")
+		parent.Use(middleware.AuthMiddleware(r.authUseCase))
+		parent.Use(r.can(domain.PermissionChildrenView))
+		{
+			parent.GET("/dashboard", r.parentHandler.GetDashboard)
+			parent.GET("/children", r.parentHandler.ListChildren)
+			parent.POST("/children", r.parentHandler.InviteChild)
+			parent.DELETE("/children/:studentId", r.parentHandler.RemoveChild)
+			parent.GET("/children/:studentId/courses", r.parentHandler.GetChildEnrollments)
+			parent.GET("/children/:studentId/courses/:courseId/progress", r.parentHandler.GetChildCourseProgress)
+		}
+
+		// Teacher portal - teachers manage the courses they are on the staff of
+		teacher := v1.Group("/teacher")
+		teacher.Use(middleware.AuthMiddleware(r.authUseCase))
+		teacher.Use(middleware.MFAPolicyMiddleware(r.authUseCase))
+		teacher.Use(middleware.AuditContextMiddleware())
+		teacher.Use(r.can(domain.PermissionCoursesTeach))
+		{
+			teacher.GET("/courses", r.courseHandler.ListTeacherCourses)
+			teacher.GET("/courses/:id", r.courseHandler.GetTeacherCourse)
+			teacher.PUT("/courses/:id", r.courseHandler.UpdateTeacherCourse)
+			teacher.GET("/courses/:id/students", r.courseHandler.ListTeacherCourseStudents)
+			teacher.GET("/courses/:id/staff", r.courseHandler.ListTeacherCourseStaff)
+			teacher.PUT("/courses/:id/staff/:userId", r.courseHandler.SetTeacherCourseStaff)
+			teacher.DELETE("/courses/:id/staff/:userId", r.courseHandler.RemoveTeacherCourseStaff)
+			teacher.POST("/sections", r.courseHandler.CreateTeacherSection)
+			teacher.PUT("/sections/:id", r.courseHandler.UpdateTeacherSection)
+			teacher.POST("/lessons", r.courseHandler.CreateTeacherLesson)
+			teacher.PUT("/lessons/:id", r.courseHandler.UpdateTeacherLesson)
+		}
+
+		// Admin routes - protected by AuthMiddleware, each route requires its own permission
 		admin := v1.Group("/admin")
 		admin.Use(middleware.AuthMiddleware(r.authUseCase))
-		admin.Use(middleware.AdminMiddleware())
+		admin.Use(middleware.MFAPolicyMiddleware(r.authUseCase))
+		admin.Use(middleware.AuditContextMiddleware())
 		{
 			// Dashboard stats
-			admin.GET("/stats", r.statsHandler.GetDashboardStats)
+			admin.GET("/stats", r.can(domain.PermissionStatsView), r.statsHandler.GetDashboardStats)
 
 			// User management
-			admin.GET("/users", r.adminUserHandler.ListUsers)
-			admin.POST("/users", r.adminUserHandler.CreateUser)
-			admin.PUT("/users/:id", r.adminUserHandler.UpdateUser)
-			admin.DELETE("/users/:id", r.adminUserHandler.DeleteUser)
-			admin.PUT("/users/:id/role", r.adminUserHandler.UpdateUserRole)
-			admin.PATCH("/users/:id/status", r.adminUserHandler.ToggleUserStatus)
+			admin.GET("/users", r.can(domain.PermissionUsersView), r.adminUserHandler.ListUsers)
+			admin.POST("/users", r.can(domain.PermissionUsersManage), r.adminUserHandler.CreateUser)
+			admin.POST("/users/import", r.can(domain.PermissionUsersManage), r.adminUserHandler.ImportUsers)
+			admin.PUT("/users/:id", r.can(domain.PermissionUsersManage), r.adminUserHandler.UpdateUser)
+			admin.DELETE("/users/:id", r.can(domain.PermissionUsersManage), r.adminUserHandler.DeleteUser)
+			admin.PUT("/users/:id/role", r.can(domain.PermissionUsersManage), r.adminUserHandler.UpdateUserRole)
+			admin.PATCH("/users/:id/status", r.can(domain.PermissionUsersManage), r.adminUserHandler.ToggleUserStatus)
+			admin.GET("/users/:id/sessions", r.can(domain.PermissionUsersView), r.adminUserHandler.ListUserSessions)
+			admin.PUT("/users/:id/session-limit", r.can(domain.PermissionUsersManage), r.adminUserHandler.SetSessionLimit)
+			admin.POST("/users/:id/unlock", r.can(domain.PermissionUsersManage), r.adminUserHandler.UnlockUser)
+			admin.POST("/users/:id/impersonate", r.can(domain.PermissionUsersImpersonate), r.authHandler.Impersonate)
+			admin.DELETE("/users/:id/mfa", r.can(domain.PermissionUsersManage), r.adminUserHandler.ResetUserMFA)
 
 			// Course management
-			admin.GET("/courses", r.courseHandler.ListAdminCourses)
-			admin.POST("/courses", r.courseHandler.CreateCourse)
-			admin.PUT("/courses/:id", r.courseHandler.UpdateCourse)
-			admin.DELETE("/courses/:id", r.courseHandler.DeleteCourse)
+			admin.GET("/courses", r.can(domain.PermissionCoursesWrite), r.courseHandler.ListAdminCourses)
+			admin.POST("/courses", r.can(domain.PermissionCoursesWrite), r.courseHandler.CreateCourse)
+			admin.PUT("/courses/:id", r.can(domain.PermissionCoursesWrite), r.courseHandler.UpdateCourse)
+			admin.DELETE("/courses/:id", r.can(domain.PermissionCoursesWrite), r.courseHandler.DeleteCourse)
+			admin.GET("/courses/:id/staff", r.can(domain.PermissionCoursesWrite), r.courseHandler.ListCourseStaff)
+			admin.PUT("/courses/:id/staff/:userId", r.can(domain.PermissionCoursesWrite), r.courseHandler.SetCourseStaff)
+			admin.DELETE("/courses/:id/staff/:userId", r.can(domain.PermissionCoursesWrite), r.courseHandler.RemoveCourseStaff)
 
 			// Section management
-			admin.POST("/sections", r.courseHandler.CreateSection)
-			admin.PUT("/sections/:id", r.courseHandler.UpdateSection)
-			admin.DELETE("/sections/:id", r.courseHandler.DeleteSection)
+			admin.POST("/sections", r.can(domain.PermissionCoursesWrite), r.courseHandler.CreateSection)
+			admin.PUT("/sections/:id", r.can(domain.PermissionCoursesWrite), r.courseHandler.UpdateSection)
+			admin.DELETE("/sections/:id", r.can(domain.PermissionCoursesWrite), r.courseHandler.DeleteSection)
 
 			// Lesson management
-			admin.POST("/lessons", r.courseHandler.CreateLesson)
-			admin.PUT("/lessons/:id", r.courseHandler.UpdateLesson)
-			admin.DELETE("/lessons/:id", r.courseHandler.DeleteLesson)
+			admin.POST("/lessons", r.can(domain.PermissionCoursesWrite), r.courseHandler.CreateLesson)
+			admin.PUT("/lessons/:id", r.can(domain.PermissionCoursesWrite), r.courseHandler.UpdateLesson)
+			admin.DELETE("/lessons/:id", r.can(domain.PermissionCoursesWrite), r.courseHandler.DeleteLesson)
+
+			// Activation code batches and redemption history
+			admin.POST("/activation-codes/batch", r.can(domain.PermissionCodesCreate), r.enrollmentHandler.CreateActivationCodeBatch)
+			admin.GET("/activation-codes/batches", r.can(domain.PermissionCodesView), r.enrollmentHandler.ListActivationCodeBatches)
+			admin.GET("/activation-codes/batches/:id", r.can(domain.PermissionCodesView), r.enrollmentHandler.GetActivationCodeBatch)
+			admin.GET("/activation-codes/batches/:id/stats", r.can(domain.PermissionCodesView), r.enrollmentHandler.GetActivationCodeBatchStats)
+			admin.GET("/activation-codes/:id/redemptions", r.can(domain.PermissionCodesView), r.enrollmentHandler.ListCodeRedemptions)
+
+			// Users and IPs locked out for guessing activation codes
+			admin.GET("/activation-lockouts", r.can(domain.PermissionCodesView), r.enrollmentHandler.ListActivationLockouts)
+			admin.DELETE("/activation-lockouts/users/:id", r.can(domain.PermissionCodesManage), r.enrollmentHandler.ClearUserActivationLockout)
+			admin.DELETE("/activation-lockouts/ips/:ip", r.can(domain.PermissionCodesManage), r.enrollmentHandler.ClearIPActivationLockout)
+
+			// Course bundles unlocked by activation codes
+			admin.GET("/course-bundles", r.can(domain.PermissionCodesView), r.enrollmentHandler.ListCourseBundles)
+			admin.POST("/course-bundles", r.can(domain.PermissionCodesManage), r.enrollmentHandler.CreateCourseBundle)
+			admin.GET("/course-bundles/:id", r.can(domain.PermissionCodesView), r.enrollmentHandler.GetCourseBundle)
+			admin.PUT("/course-bundles/:id", r.can(domain.PermissionCodesManage), r.enrollmentHandler.UpdateCourseBundle)
+			admin.DELETE("/course-bundles/:id", r.can(domain.PermissionCodesManage), r.enrollmentHandler.DeleteCourseBundle)
 
 			// Consultation management
-			admin.GET("/consultations", r.consultationHandler.ListRequests)
-			admin.PUT("/consultations/:id", r.consultationHandler.UpdateRequest)
-			admin.DELETE("/consultations/:id", r.consultationHandler.DeleteRequest)
+			admin.GET("/consultations", r.can(domain.PermissionConsultationsView), r.consultationHandler.ListRequests)
+			admin.PUT("/consultations/:id", r.can(domain.PermissionConsultationsManage), r.consultationHandler.UpdateRequest)
+			admin.DELETE("/consultations/:id", r.can(domain.PermissionConsultationsManage), r.consultationHandler.DeleteRequest)
+
+			// Audit log
+			admin.GET("/audit-logs", r.can(domain.PermissionAuditLogsView), r.auditLogHandler.ListAuditLogs)
 		}
 	}
 }
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// AccountExport holds the personal data of a user, returned by the data export
+type AccountExport struct {
+	ExportedAt           time.Time              `json:"exported_at"`
+	Profile              *User                  `json:"profile"`
+	Enrollments          []*Enrollment          `json:"enrollments"`
+	LessonProgress       []*LessonProgress      `json:"lesson_progress"`
+	Sessions             []*Session             `json:"sessions"`
+	ConsultationRequests []*ConsultationRequest `json:"consultation_requests"`
+}
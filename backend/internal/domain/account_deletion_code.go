@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountDeletionCode represents a single-use code sent by email to confirm deleting an account.
+// It stands in for the password of accounts created through a social login, whose password is unknown.
+type AccountDeletionCode struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	CodeHash  string     `json:"-"` // Never expose code hash in JSON
+	Attempts  int        `json:"attempts"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsExpired checks if the deletion code is expired
+func (c *AccountDeletionCode) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+// IsUsed checks if the deletion code has already been used
+func (c *AccountDeletionCode) IsUsed() bool {
+	return c.UsedAt != nil
+}
@@ -3,25 +3,53 @@ package domain
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// AccessType is how long the enrollments created by an activation code last
+type AccessType string
+
+const (
+	AccessTypeLifetime AccessType = "lifetime" // Never expires
+	AccessTypeDays     AccessType = "days"     // AccessDays from activation
+	AccessTypeUntil    AccessType = "until"    // Until the fixed AccessUntil date
+)
+
+// DefaultAccessDays is the access length of codes that do not choose one
+const DefaultAccessDays = 365
+
+// IsValid checks if the access type is valid
+func (t AccessType) IsValid() bool {
+	switch t {
+	case AccessTypeLifetime, AccessTypeDays, AccessTypeUntil:
+		return true
+	}
+	return false
+}
+
 // ActivationCode represents an activation code for course enrollment
 type ActivationCode struct {
-	ID          uuid.UUID  `json:"id"`
-	Code        string     `json:"code"`
-	CourseID    uuid.UUID  `json:"course_id"`
-	MaxUses     *int       `json:"max_uses,omitempty"`
-	CurrentUses int        `json:"current_uses"`
-	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
-	IsActive    bool       `json:"is_active"`
-	CreatedBy   uuid.UUID  `json:"created_by"`
-	Note        *string    `json:"note,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID          uuid.UUID   `json:"id"`
+	Code        string      `json:"code"`
+	CourseID    uuid.UUID   `json:"course_id"`
+	MaxUses     *int        `json:"max_uses,omitempty"`
+	CurrentUses int         `json:"current_uses"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
+	IsActive    bool        `json:"is_active"`
+	CreatedBy   uuid.UUID   `json:"created_by"`
+	Note        *string     `json:"note,omitempty"`
+	BatchID     *uuid.UUID  `json:"batch_id,omitempty"` // Set for codes generated in a batch
+	AccessType  AccessType  `json:"access_type"`
+	AccessDays  *int        `json:"access_days,omitempty"`  // Set when AccessType is days
+	AccessUntil *time.Time  `json:"access_until,omitempty"` // Set when AccessType is until
+	BundleID    *uuid.UUID  `json:"bundle_id,omitempty"`    // Every course of the bundle is unlocked too
+	CourseIDs   []uuid.UUID `json:"course_ids,omitempty"`   // Courses unlocked besides CourseID
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
 
 	// Relations (optional, loaded separately)
 	Course  *Course `json:"course,omitempty"`
@@ -45,6 +73,26 @@ func (ac *ActivationCode) IsValid() error {
 	return nil
 }
 
+// EnrollmentExpiresAt returns when an enrollment activated at the given time ends, nil for lifetime access
+func (ac *ActivationCode) EnrollmentExpiresAt(activatedAt time.Time) *time.Time {
+	switch ac.AccessType {
+	case AccessTypeLifetime:
+		return nil
+	case AccessTypeUntil:
+		if ac.AccessUntil != nil {
+			until := *ac.AccessUntil
+			return &until
+		}
+	}
+
+	days := DefaultAccessDays
+	if ac.AccessDays != nil {
+		days = *ac.AccessDays
+	}
+	expiresAt := activatedAt.AddDate(0, 0, days)
+	return &expiresAt
+}
+
 // CanBeUsed returns true if the code can be used
 func (ac *ActivationCode) CanBeUsed() bool {
 	return ac.IsValid() == nil
@@ -62,8 +110,15 @@ func (ac *ActivationCode) RemainingUses() int {
 	return remaining
 }
 
+// hexCodeCharset is the alphabet of hex codes
+const hexCodeCharset = "0123456789ABCDEF"
+
+// hexCodePattern matches hex codes with a check character, after the batch prefix if any.
+// Hex codes made before check characters end in a group of four and are not matched.
+var hexCodePattern = regexp.MustCompile(`(^|-)[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{5}$`)
+
 // GenerateActivationCode generates a random activation code
-// Format: XXXX-XXXX-XXXX (12 characters separated by dashes)
+// Format: XXXX-XXXX-XXXXC (12 hex characters separated by dashes and a check character)
 func GenerateActivationCode() (string, error) {
 	bytes := make([]byte, 6) // 6 bytes = 12 hex characters
 	if _, err := rand.Read(bytes); err != nil {
@@ -71,22 +126,64 @@ func GenerateActivationCode() (string, error) {
 	}
 
 	hex := strings.ToUpper(hex.EncodeToString(bytes))
-	// Format as XXXX-XXXX-XXXX
-	return hex[0:4] + "-" + hex[4:8] + "-" + hex[8:12], nil
+	// Format as XXXX-XXXX-XXXXC
+	return hex[0:4] + "-" + hex[4:8] + "-" + hex[8:12] + string(luhnCheckChar(hexCodeCharset, hex)), nil
 }
 
+// simpleCodeCharset is the alphabet of simple codes, without confusing chars: I, O, 0, 1
+const simpleCodeCharset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// simpleCodeLength is the number of random characters of a simple code, before the check character
+const simpleCodeLength = 8
+
 // GenerateSimpleActivationCode generates a simple alphanumeric code
-// Format: XXXXXXXX (8 uppercase alphanumeric characters)
+// Format: XXXXXXXXC (8 uppercase alphanumeric characters and a check character)
 func GenerateSimpleActivationCode() (string, error) {
-	const charset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // Removed confusing chars: I, O, 0, 1
-	bytes := make([]byte, 8)
+	bytes := make([]byte, simpleCodeLength)
 	if _, err := rand.Read(bytes); err != nil {
 		return "", err
 	}
 
 	for i := range bytes {
-		bytes[i] = charset[bytes[i]%byte(len(charset))]
+		bytes[i] = simpleCodeCharset[bytes[i]%byte(len(simpleCodeCharset))]
+	}
+
+	return string(bytes) + string(luhnCheckChar(simpleCodeCharset, string(bytes))), nil
+}
+
+// HasValidChecksum checks the check character of simple and hex codes, so typos are rejected
+// without a database lookup. The prefix of batch codes is not covered. Codes made before
+// check characters cannot be checked and are reported valid.
+func HasValidChecksum(code string) bool {
+	if hexCodePattern.MatchString(code) {
+		body := strings.ReplaceAll(code[len(code)-15:], "-", "")
+		return luhnCheckChar(hexCodeCharset, body[:len(body)-1]) == body[len(body)-1]
+	}
+
+	body := code[strings.LastIndex(code, "-")+1:]
+	if len(body) != simpleCodeLength+1 {
+		return true
+	}
+	for i := 0; i < len(body); i++ {
+		if strings.IndexByte(simpleCodeCharset, body[i]) < 0 {
+			return true
+		}
+	}
+
+	return luhnCheckChar(simpleCodeCharset, body[:simpleCodeLength]) == body[simpleCodeLength]
+}
+
+// luhnCheckChar computes the Luhn mod N check character of s over charset, which catches every
+// single mistyped character and most swaps of two neighbours
+func luhnCheckChar(charset, s string) byte {
+	n := len(charset)
+	factor := 2
+	sum := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(charset, s[i])
+		sum += addend/n + addend%n
+		factor = 3 - factor
 	}
 
-	return string(bytes), nil
+	return charset[(n-sum%n)%n]
 }
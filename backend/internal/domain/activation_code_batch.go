@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActivationCodeFormat selects the generator used for the codes of a batch
+type ActivationCodeFormat string
+
+const (
+	ActivationCodeFormatSimple ActivationCodeFormat = "simple" // XXXXXXXXC with a check character, easy to type from a printed card
+	ActivationCodeFormatHex    ActivationCodeFormat = "hex"    // XXXX-XXXX-XXXXC with a check character
+)
+
+// IsValid checks if the activation code format is valid
+func (f ActivationCodeFormat) IsValid() bool {
+	switch f {
+	case ActivationCodeFormatSimple, ActivationCodeFormatHex:
+		return true
+	}
+	return false
+}
+
+// Generate generates one random code in the format
+func (f ActivationCodeFormat) Generate() (string, error) {
+	if f == ActivationCodeFormatHex {
+		return GenerateActivationCode()
+	}
+	return GenerateSimpleActivationCode()
+}
+
+// ActivationCodeBatch is a set of activation codes generated together for one course
+type ActivationCodeBatch struct {
+	ID        uuid.UUID  `json:"id"`
+	Label     string     `json:"label"`
+	CourseID  uuid.UUID  `json:"course_id"`
+	Prefix    *string    `json:"prefix,omitempty"`
+	Quantity  int        `json:"quantity"`
+	MaxUses   *int       `json:"max_uses,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Access policy and extra courses given to every code, see ActivationCode
+	AccessType  AccessType  `json:"access_type"`
+	AccessDays  *int        `json:"access_days,omitempty"`
+	AccessUntil *time.Time  `json:"access_until,omitempty"`
+	BundleID    *uuid.UUID  `json:"bundle_id,omitempty"`
+	CourseIDs   []uuid.UUID `json:"course_ids,omitempty"`
+
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations (optional, loaded separately)
+	Course *Course           `json:"course,omitempty"`
+	Codes  []*ActivationCode `json:"codes,omitempty"`
+}
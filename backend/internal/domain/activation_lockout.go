@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActivationLockoutScope tells whether a user or a client IP is blocked from redeeming codes
+type ActivationLockoutScope string
+
+const (
+	ActivationLockoutScopeUser ActivationLockoutScope = "user"
+	ActivationLockoutScopeIP   ActivationLockoutScope = "ip"
+)
+
+// ActivationLockout is a user or client IP temporarily blocked from redeeming activation
+// codes after too many unknown codes
+type ActivationLockout struct {
+	Scope        ActivationLockoutScope `json:"scope"`
+	UserID       *uuid.UUID             `json:"user_id,omitempty"`    // Set for the user scope
+	IPAddress    *string                `json:"ip_address,omitempty"` // Set for the ip scope
+	FailedCount  int                    `json:"failed_count"`
+	LastFailedAt time.Time              `json:"last_failed_at"`
+	LockedUntil  time.Time              `json:"locked_until"`
+
+	// Relations (optional, loaded separately)
+	User *LinkedUser `json:"user,omitempty"`
+}
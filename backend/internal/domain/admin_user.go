@@ -14,6 +14,11 @@ type AdminUserUseCase interface {
 	DeleteUser(ctx context.Context, userID uuid.UUID) error
 	UpdateUserRole(ctx context.Context, userID uuid.UUID, role UserRole) error
 	ToggleUserStatus(ctx context.Context, userID uuid.UUID, isActive bool) error
+	ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*Session, error)
+	SetSessionLimit(ctx context.Context, userID uuid.UUID, maxSessions *int) error
+	UnlockUser(ctx context.Context, userID uuid.UUID) error
+	ResetUserMFA(ctx context.Context, userID uuid.UUID) error
+	ImportUsers(ctx context.Context, rows [][]string, opts UserImportOptions) (*UserImportResult, error)
 }
 
 type PaginatedUsers struct {
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit target types
+const (
+	AuditTargetUser                = "user"
+	AuditTargetCourse              = "course"
+	AuditTargetSection             = "section"
+	AuditTargetLesson              = "lesson"
+	AuditTargetActivationCode      = "activation_code"
+	AuditTargetActivationCodeBatch = "activation_code_batch"
+	AuditTargetCourseBundle        = "course_bundle"
+	AuditTargetConsultation        = "consultation"
+	AuditTargetIPAddress           = "ip_address" // Target ID is the address itself
+)
+
+// Audit actions, named <target>.<verb>
+const (
+	AuditActionUserCreate                = "user.create"
+	AuditActionUserUpdate                = "user.update"
+	AuditActionUserDelete                = "user.delete"
+	AuditActionUserUpdateRole            = "user.update_role"
+	AuditActionUserToggleStatus          = "user.toggle_status"
+	AuditActionUserSetSessionLimit       = "user.set_session_limit"
+	AuditActionUserUnlock                = "user.unlock"
+	AuditActionUserResetMFA              = "user.reset_mfa"
+	AuditActionUserImport                = "user.import"
+	AuditActionUserActivationUnlock      = "user.activation_unlock"
+	AuditActionIPAddressActivationUnlock = "ip_address.activation_unlock"
+	AuditActionCourseCreate              = "course.create"
+	AuditActionCourseUpdate              = "course.update"
+	AuditActionCourseDelete              = "course.delete"
+	AuditActionCourseStaffSet            = "course.staff_set"
+	AuditActionCourseStaffRemove         = "course.staff_remove"
+	AuditActionSectionCreate             = "section.create"
+	AuditActionSectionUpdate             = "section.update"
+	AuditActionSectionDelete             = "section.delete"
+	AuditActionLessonCreate              = "lesson.create"
+	AuditActionLessonUpdate              = "lesson.update"
+	AuditActionLessonDelete              = "lesson.delete"
+	AuditActionActivationCodeCreate      = "activation_code.create"
+	AuditActionActivationCodeUpdate      = "activation_code.update"
+	AuditActionActivationCodeDelete      = "activation_code.delete"
+	AuditActionActivationCodeBatchCreate = "activation_code_batch.create"
+	AuditActionCourseBundleCreate        = "course_bundle.create"
+	AuditActionCourseBundleUpdate        = "course_bundle.update"
+	AuditActionCourseBundleDelete        = "course_bundle.delete"
+	AuditActionConsultationUpdate        = "consultation.update"
+	AuditActionConsultationDelete        = "consultation.delete"
+)
+
+// AuditLog records a change an admin made
+type AuditLog struct {
+	ID         uuid.UUID              `json:"id"`
+	ActorID    *uuid.UUID             `json:"actor_id,omitempty"` // NULL once the admin account is deleted
+	ActorEmail string                 `json:"actor_email,omitempty"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type"`
+	TargetID   string                 `json:"target_id"`
+	Before     map[string]interface{} `json:"before,omitempty"` // Empty for creations
+	After      map[string]interface{} `json:"after,omitempty"`  // Empty for deletions
+	Changes    map[string]AuditChange `json:"changes,omitempty"`
+	IPAddress  *string                `json:"ip_address,omitempty"`
+	UserAgent  *string                `json:"user_agent,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AuditChange is the old and new value of one changed field
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditLogFilter narrows down an audit log listing, zero fields match everything
+type AuditLogFilter struct {
+	ActorID    *uuid.UUID
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+}
+
+// PaginatedAuditLogs is a page of audit log entries
+type PaginatedAuditLogs struct {
+	Logs       []*AuditLog `json:"logs"`
+	Total      int64       `json:"total"`
+	Page       int         `json:"page"`
+	Limit      int         `json:"limit"`
+	TotalPages int         `json:"total_pages"`
+}
+
+// AuditLogUseCase defines the interface for browsing the audit log
+type AuditLogUseCase interface {
+	ListAuditLogs(ctx context.Context, filter *AuditLogFilter, page, limit int) (*PaginatedAuditLogs, error)
+}
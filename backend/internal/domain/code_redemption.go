@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CodeRedemption records one successful redemption of an activation code
+type CodeRedemption struct {
+	ID               uuid.UUID  `json:"id"`
+	ActivationCodeID uuid.UUID  `json:"activation_code_id"`
+	UserID           *uuid.UUID `json:"user_id,omitempty"`
+	CoursesUnlocked  int        `json:"courses_unlocked"` // Enrollments created or renewed
+	IPAddress        *string    `json:"ip_address,omitempty"`
+	UserAgent        *string    `json:"user_agent,omitempty"`
+	RedeemedAt       time.Time  `json:"redeemed_at"`
+
+	// Relations (optional, loaded separately)
+	User *LinkedUser `json:"user,omitempty"`
+}
+
+// RedemptionInterval is the bucket size of a redemption timeline
+type RedemptionInterval string
+
+const (
+	RedemptionIntervalDay   RedemptionInterval = "day"
+	RedemptionIntervalWeek  RedemptionInterval = "week"
+	RedemptionIntervalMonth RedemptionInterval = "month"
+)
+
+// IsValid checks if the redemption interval is valid
+func (i RedemptionInterval) IsValid() bool {
+	switch i {
+	case RedemptionIntervalDay, RedemptionIntervalWeek, RedemptionIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// RedemptionPoint is one bucket of a redemption timeline
+type RedemptionPoint struct {
+	Period         time.Time `json:"period"`          // Start of the bucket
+	Redemptions    int       `json:"redemptions"`     // Redemptions in the bucket
+	NewCodes       int       `json:"new_codes"`       // Codes redeemed for the first time in the bucket
+	RedemptionRate float64   `json:"redemption_rate"` // Share of the batch codes redeemed by the end of the bucket
+}
+
+// ActivationCodeBatchStats shows how the codes of a batch are being used
+type ActivationCodeBatchStats struct {
+	BatchID          uuid.UUID          `json:"batch_id"`
+	TotalCodes       int                `json:"total_codes"`
+	RedeemedCodes    int                `json:"redeemed_codes"`    // Used at least once
+	RemainingCodes   int                `json:"remaining_codes"`   // Active, unexpired and with uses left
+	TotalRedemptions int                `json:"total_redemptions"` // Sum of the uses of every code
+	RedemptionRate   float64            `json:"redemption_rate"`   // RedeemedCodes / TotalCodes
+	Interval         RedemptionInterval `json:"interval"`
+	Timeline         []RedemptionPoint  `json:"timeline"` // Only redemptions recorded in code_redemptions
+}
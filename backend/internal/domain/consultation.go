@@ -41,6 +41,8 @@ type ConsultationRepository interface {
 	List(ctx context.Context, limit, offset int) ([]*ConsultationRequest, int, error)
 	Update(ctx context.Context, req *ConsultationRequest) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	ListByPhone(ctx context.Context, phone string) ([]*ConsultationRequest, error)
+	AnonymizeByPhone(ctx context.Context, phone string) error
 }
 
 type ConsultationUseCase interface {
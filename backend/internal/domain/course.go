@@ -52,6 +52,7 @@ type Course struct {
 	// Relations (optional, loaded separately)
 	Instructor *User            `json:"instructor,omitempty"`
 	Sections   []*CourseSection `json:"sections,omitempty"`
+	Staff      []*CourseStaff   `json:"staff,omitempty"`
 }
 
 // CourseSection represents a section/chapter in a course
@@ -84,12 +85,19 @@ type CourseLesson struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// HideMedia removes the playback references so the lesson can be listed publicly
+func (l *CourseLesson) HideMedia() {
+	l.VideoURL = nil
+	l.YouTubeID = nil
+}
+
 // CourseFilter represents filters for course queries
 type CourseFilter struct {
 	Status       *CourseStatus `json:"status,omitempty"`
 	Levels       []CourseLevel `json:"levels,omitempty"`
 	Grades       []string      `json:"grades,omitempty"`
 	InstructorID *uuid.UUID    `json:"instructor_id,omitempty"`
+	StaffUserID  *uuid.UUID    `json:"staff_user_id,omitempty"` // Courses the user teaches in any staff role
 	IsFeatured   *bool         `json:"is_featured,omitempty"`
 	Search       *string       `json:"search,omitempty"`
 }
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseBundle is a package of courses unlocked together by one activation code, e.g. "Grade 9 full year"
+type CourseBundle struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Description *string     `json:"description,omitempty"`
+	CourseIDs   []uuid.UUID `json:"course_ids"` // In display order
+	CreatedBy   uuid.UUID   `json:"created_by"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+
+	// Relations (optional, loaded separately)
+	Courses []*Course `json:"courses,omitempty"`
+}
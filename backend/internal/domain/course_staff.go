@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseStaffRole represents what a teacher may do in a course
+type CourseStaffRole string
+
+const (
+	CourseStaffOwner        CourseStaffRole = "owner"         // Lead teacher, the course instructor
+	CourseStaffCoInstructor CourseStaffRole = "co_instructor" // Edits sections and lessons
+	CourseStaffTA           CourseStaffRole = "ta"            // Views the course and its students
+)
+
+// IsValid checks if the course staff role is valid
+func (r CourseStaffRole) IsValid() bool {
+	switch r {
+	case CourseStaffOwner, CourseStaffCoInstructor, CourseStaffTA:
+		return true
+	}
+	return false
+}
+
+// CanEditContent reports whether the role may add and edit sections and lessons
+func (r CourseStaffRole) CanEditContent() bool {
+	return r == CourseStaffOwner || r == CourseStaffCoInstructor
+}
+
+// IsOwner reports whether the role may edit the course itself and manage its staff
+func (r CourseStaffRole) IsOwner() bool {
+	return r == CourseStaffOwner
+}
+
+// CourseStaff is a teacher of a course
+type CourseStaff struct {
+	CourseID  uuid.UUID       `json:"course_id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Role      CourseStaffRole `json:"role"`
+	FullName  string          `json:"full_name"`
+	Avatar    *string         `json:"avatar,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
@@ -12,13 +12,69 @@ var (
 	ErrInvalidPassword          = errors.New("invalid password")
 	ErrInvalidPhoneNumber       = errors.New("invalid phone number")
 	ErrPhoneNumberAlreadyExists = errors.New("phone number already exists")
+	ErrInvalidEmailOrPhone      = errors.New("invalid email or phone number")
+	ErrAccountLocked            = errors.New("account temporarily locked")
+	ErrInvalidSignupRole        = errors.New("only student or parent accounts can sign up")
+
+	// User import errors
+	ErrImportMissingColumns = errors.New("import file is missing required columns")
+	ErrImportEmpty          = errors.New("import file has no rows")
+	ErrImportTooManyRows    = errors.New("import file has too many rows")
 
 	// Token errors
 	ErrInvalidToken        = errors.New("invalid token")
 	ErrTokenExpired        = errors.New("token expired")
+	ErrTokenRevoked        = errors.New("token revoked")
 	ErrInvalidRefreshToken = errors.New("invalid refresh token")
 	ErrRefreshTokenExpired = errors.New("refresh token expired")
 	ErrRefreshTokenRevoked = errors.New("refresh token revoked")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionLimitReached = errors.New("active session limit reached")
+	ErrInvalidSessionLimit = errors.New("invalid session limit")
+	ErrCannotImpersonate   = errors.New("user cannot be impersonated")
+
+	// Account deletion errors
+	ErrAccountDeletionNotAllowed   = errors.New("admin accounts cannot be deleted by their owner")
+	ErrDeletionConfirmationMissing = errors.New("password or deletion code required")
+	ErrInvalidDeletionCode         = errors.New("invalid account deletion code")
+	ErrDeletionCodeExpired         = errors.New("account deletion code expired")
+	ErrDeletionCodeTooManyAttempts = errors.New("too many wrong account deletion codes")
+	ErrDeletionCodeTooSoon         = errors.New("account deletion code was requested too recently")
+
+	// Email verification errors
+	ErrInvalidVerificationToken = errors.New("invalid verification token")
+	ErrVerificationTokenExpired = errors.New("verification token expired")
+	ErrEmailNotVerified         = errors.New("email not verified")
+
+	// Password reset errors
+	ErrInvalidResetToken    = errors.New("invalid password reset token")
+	ErrResetTokenExpired    = errors.New("password reset token expired")
+	ErrResetTooManyAttempts = errors.New("too many wrong password reset codes")
+
+	// Passwordless phone login errors
+	ErrInvalidLoginOTP         = errors.New("invalid login code")
+	ErrLoginOTPExpired         = errors.New("login code expired")
+	ErrLoginOTPTooManyAttempts = errors.New("too many wrong login codes")
+	ErrLoginOTPTooSoon         = errors.New("login code was requested too recently")
+	ErrLoginOTPLimitReached    = errors.New("too many login codes requested")
+
+	// Two-factor authentication errors
+	ErrMFANotEnabled      = errors.New("two-factor authentication not enabled")
+	ErrMFAAlreadyEnabled  = errors.New("two-factor authentication already enabled")
+	ErrMFASetupNotStarted = errors.New("two-factor authentication setup not started")
+	ErrInvalidMFACode     = errors.New("invalid two-factor authentication code")
+	ErrMFACodeReused      = errors.New("two-factor authentication code already used")
+	ErrInvalidMFAToken    = errors.New("invalid or expired two-factor challenge")
+	ErrMFARequired        = errors.New("two-factor authentication required")
+
+	// Social login errors
+	ErrOAuthProviderNotFound = errors.New("social login provider not found")
+	ErrInvalidOAuthState     = errors.New("invalid or expired social login state")
+	ErrOAuthExchangeFailed   = errors.New("social login code exchange failed")
+	ErrOAuthEmailNotVerified = errors.New("social login account has no verified email")
+	ErrIdentityNotFound      = errors.New("linked identity not found")
+	ErrIdentityAlreadyLinked = errors.New("identity already linked to a user")
 
 	// Course errors
 	ErrCourseNotFound        = errors.New("course not found")
@@ -27,6 +83,19 @@ var (
 	ErrInvalidCourseLevel    = errors.New("invalid course level")
 	ErrCourseSectionNotFound = errors.New("course section not found")
 	ErrCourseLessonNotFound  = errors.New("course lesson not found")
+	ErrLessonAccessDenied    = errors.New("lesson access denied")
+	ErrCourseAccessDenied    = errors.New("course is not managed by this instructor")
+
+	// Course staff errors
+	ErrCourseStaffNotFound    = errors.New("course staff member not found")
+	ErrInvalidCourseStaffRole = errors.New("invalid course staff role")
+	ErrCourseOwnerImmutable   = errors.New("course owner cannot be changed or removed")
+	ErrUserCannotTeach        = errors.New("user role cannot teach courses")
+
+	// Parent link errors
+	ErrParentLinkNotFound    = errors.New("parent link not found")
+	ErrParentLinkExists      = errors.New("parent link already exists")
+	ErrParentLinkNotAccepted = errors.New("parent link has not been accepted")
 
 	// Activation code errors
 	ErrActivationCodeNotFound = errors.New("activation code not found")
@@ -34,6 +103,20 @@ var (
 	ErrActivationCodeUsedUp   = errors.New("activation code has reached maximum uses")
 	ErrActivationCodeInactive = errors.New("activation code is inactive")
 	ErrActivationCodeInvalid  = errors.New("activation code is invalid")
+	ErrInvalidAccessPolicy    = errors.New("invalid activation code access policy")
+	ErrActivationLocked       = errors.New("too many failed activation attempts")
+	ErrActivationLockNotFound = errors.New("activation lockout not found")
+
+	// Activation code batch errors
+	ErrActivationCodeBatchNotFound = errors.New("activation code batch not found")
+	ErrInvalidActivationCodeBatch  = errors.New("invalid activation code batch")
+	ErrActivationCodeCollision     = errors.New("could not generate a unique activation code")
+	ErrInvalidRedemptionInterval   = errors.New("invalid redemption interval")
+
+	// Course bundle errors
+	ErrCourseBundleNotFound = errors.New("course bundle not found")
+	ErrInvalidCourseBundle  = errors.New("invalid course bundle")
+	ErrCourseBundleInUse    = errors.New("course bundle is used by activation codes")
 
 	// Enrollment errors
 	ErrAlreadyEnrolled    = errors.New("user is already enrolled in this course")
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// LoginAttempt tracks consecutive failed logins for one account or client IP
+type LoginAttempt struct {
+	Key          string     `json:"key"`
+	FailedCount  int        `json:"failed_count"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+}
+
+// IsLocked checks if logins for the key are temporarily blocked
+func (a *LoginAttempt) IsLocked() bool {
+	return a.LockedUntil != nil && time.Now().Before(*a.LockedUntil)
+}
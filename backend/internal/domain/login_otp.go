@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginOTP represents a single-use code sent by SMS for passwordless login.
+// Codes are also recorded for unregistered numbers so responses and rate
+// limits don't reveal which numbers have an account, UserID is nil then.
+type LoginOTP struct {
+	ID          uuid.UUID  `json:"id"`
+	PhoneNumber string     `json:"phone_number"`
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	CodeHash    string     `json:"-"` // Never expose code hash in JSON
+	Attempts    int        `json:"attempts"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	UsedAt      *time.Time `json:"used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// IsExpired checks if the login code is expired
+func (o *LoginOTP) IsExpired() bool {
+	return time.Now().After(o.ExpiresAt)
+}
+
+// IsUsed checks if the login code has already been used
+func (o *LoginOTP) IsUsed() bool {
+	return o.UsedAt != nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserMFA represents the TOTP two-factor enrollment of a user
+type UserMFA struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	TOTPSecret   string     `json:"-"` // Never expose the secret after setup
+	EnabledAt    *time.Time `json:"enabled_at,omitempty"`
+	LastUsedStep int64      `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// IsEnabled checks if the enrollment was confirmed with a valid code
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
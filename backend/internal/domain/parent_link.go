@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParentLinkStatus represents the state of a parent invite
+type ParentLinkStatus string
+
+const (
+	ParentLinkPending  ParentLinkStatus = "pending"  // Invited by the parent, waiting for the student
+	ParentLinkAccepted ParentLinkStatus = "accepted" // The parent can follow the student
+)
+
+// LinkedUser is the public profile of the other side of a parent link
+type LinkedUser struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"full_name"`
+	Email    string    `json:"email"`
+	Avatar   *string   `json:"avatar,omitempty"`
+}
+
+// ParentLink connects a parent account to a student account
+type ParentLink struct {
+	ID         uuid.UUID        `json:"id"`
+	ParentID   uuid.UUID        `json:"parent_id"`
+	StudentID  uuid.UUID        `json:"student_id"`
+	Status     ParentLinkStatus `json:"status"`
+	AcceptedAt *time.Time       `json:"accepted_at,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+
+	// Relations (optional, loaded by the listings)
+	Parent  *LinkedUser `json:"parent,omitempty"`
+	Student *LinkedUser `json:"student,omitempty"`
+}
+
+// IsAccepted reports whether the parent may see the student's data
+func (l *ParentLink) IsAccepted() bool {
+	return l.Status == ParentLinkAccepted
+}
+
+// ChildCourseProgress is how far a child has got in one actively enrolled course
+type ChildCourseProgress struct {
+	CourseID         uuid.UUID        `json:"course_id"`
+	CourseTitle      string           `json:"course_title"`
+	ImageURL         *string          `json:"image_url,omitempty"`
+	EnrollmentStatus EnrollmentStatus `json:"enrollment_status"`
+	ExpiresAt        *time.Time       `json:"expires_at,omitempty"`
+	TotalLessons     int              `json:"total_lessons"`
+	CompletedLessons int              `json:"completed_lessons"`
+	ProgressPercent  int              `json:"progress_percent"`
+}
+
+// ChildOverview summarizes the courses of one child on the parent dashboard
+type ChildOverview struct {
+	Student          *LinkedUser            `json:"student"`
+	Courses          []*ChildCourseProgress `json:"courses"`
+	ActiveCourses    int                    `json:"active_courses"`
+	CompletedLessons int                    `json:"completed_lessons"`
+	AverageProgress  int                    `json:"average_progress"` // Mean progress percent over active courses
+}
+
+// ParentDashboard aggregates the progress of every accepted child
+type ParentDashboard struct {
+	Children         []*ChildOverview `json:"children"`
+	ActiveCourses    int              `json:"active_courses"`
+	CompletedLessons int              `json:"completed_lessons"`
+	AverageProgress  int              `json:"average_progress"` // Mean progress percent over every child's active courses
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetChannel represents how a password reset code was delivered
+type PasswordResetChannel string
+
+const (
+	PasswordResetChannelEmail PasswordResetChannel = "email" // Reset link sent by email
+	PasswordResetChannelSMS   PasswordResetChannel = "sms"   // Numeric OTP sent by SMS
+)
+
+// PasswordResetToken represents a single-use password reset link or OTP
+type PasswordResetToken struct {
+	ID        uuid.UUID            `json:"id"`
+	UserID    uuid.UUID            `json:"user_id"`
+	Channel   PasswordResetChannel `json:"channel"`
+	TokenHash string               `json:"-"` // Never expose token hash in JSON
+	Attempts  int                  `json:"attempts"`
+	ExpiresAt time.Time            `json:"expires_at"`
+	UsedAt    *time.Time           `json:"used_at,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// IsExpired checks if the reset token is expired
+func (t *PasswordResetToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// IsUsed checks if the reset token has already been used
+func (t *PasswordResetToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
@@ -0,0 +1,20 @@
+package domain
+
+// Permission is an action a role may be granted, mapped to roles in the role_permissions table
+type Permission string
+
+const (
+	PermissionStatsView           Permission = "stats.view"
+	PermissionUsersView           Permission = "users.view"
+	PermissionUsersManage         Permission = "users.manage"
+	PermissionUsersImpersonate    Permission = "users.impersonate"
+	PermissionCoursesWrite        Permission = "courses.write"
+	PermissionCoursesTeach        Permission = "courses.teach" // Manage the courses one teaches
+	PermissionCodesView           Permission = "codes.view"
+	PermissionCodesCreate         Permission = "codes.create"
+	PermissionCodesManage         Permission = "codes.manage"
+	PermissionConsultationsView   Permission = "consultations.view"
+	PermissionConsultationsManage Permission = "consultations.manage"
+	PermissionAuditLogsView       Permission = "audit_logs.view"
+	PermissionChildrenView        Permission = "children.view" // Follow the progress of linked students
+)
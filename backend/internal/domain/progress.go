@@ -29,3 +29,17 @@ type CourseProgress struct {
 	LastLessonID     *uuid.UUID        `json:"last_lesson_id,omitempty"`
 	LessonProgress   []*LessonProgress `json:"lesson_progress,omitempty"`
 }
+
+// StudentProgress is how far an enrolled student has got in a course, as seen by its instructor
+type StudentProgress struct {
+	UserID           uuid.UUID        `json:"user_id"`
+	FullName         string           `json:"full_name"`
+	Email            string           `json:"email"`
+	EnrollmentStatus EnrollmentStatus `json:"enrollment_status"`
+	EnrolledAt       time.Time        `json:"enrolled_at"`
+	ExpiresAt        *time.Time       `json:"expires_at,omitempty"`
+	TotalLessons     int              `json:"total_lessons"`
+	CompletedLessons int              `json:"completed_lessons"`
+	ProgressPercent  int              `json:"progress_percent"`
+	LastWatchedAt    *time.Time       `json:"last_watched_at,omitempty"`
+}
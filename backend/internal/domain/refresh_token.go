@@ -8,13 +8,20 @@ import (
 
 // RefreshToken represents the refresh token entity in the domain layer
 type RefreshToken struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	TokenHash string    `json:"-"` // Never expose token hash in JSON
-	ExpiresAt time.Time `json:"expires_at"`
-	IsRevoked bool      `json:"is_revoked"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	TokenHash   string     `json:"-"` // Never expose token hash in JSON
+	FamilyID    uuid.UUID  `json:"family_id"`
+	ReplacedBy  *uuid.UUID `json:"replaced_by,omitempty"`
+	DeviceName  string     `json:"device_name"`
+	UserAgent   string     `json:"user_agent"`
+	IPAddress   string     `json:"ip_address"`
+	LastUsedAt  time.Time  `json:"last_used_at"`
+	MFAVerified bool       `json:"mfa_verified"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	IsRevoked   bool       `json:"is_revoked"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
 // IsExpired checks if the refresh token is expired
@@ -22,6 +29,11 @@ func (rt *RefreshToken) IsExpired() bool {
 	return time.Now().After(rt.ExpiresAt)
 }
 
+// IsRotated checks if the refresh token was exchanged for a newer one
+func (rt *RefreshToken) IsRotated() bool {
+	return rt.ReplacedBy != nil
+}
+
 // IsValid checks if the refresh token is valid (not revoked and not expired)
 func (rt *RefreshToken) IsValid() bool {
 	return !rt.IsRevoked && !rt.IsExpired()
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventType represents the kind of security event
+type SecurityEventType string
+
+const (
+	SecurityEventRefreshTokenReuse  SecurityEventType = "refresh_token_reuse"
+	SecurityEventLoginLocked        SecurityEventType = "login_locked"
+	SecurityEventLoginUnlocked      SecurityEventType = "login_unlocked"
+	SecurityEventMFAReset           SecurityEventType = "mfa_reset"
+	SecurityEventImpersonation      SecurityEventType = "impersonation"
+	SecurityEventDeletionRequested  SecurityEventType = "deletion_requested"
+	SecurityEventDeletionCancelled  SecurityEventType = "deletion_cancelled"
+	SecurityEventActivationLocked   SecurityEventType = "activation_locked"
+	SecurityEventActivationUnlocked SecurityEventType = "activation_unlocked"
+)
+
+// SecurityEvent represents a security relevant event on an account
+type SecurityEvent struct {
+	ID        uuid.UUID              `json:"id"`
+	UserID    *uuid.UUID             `json:"user_id,omitempty"`
+	EventType SecurityEventType      `json:"event_type"`
+	IPAddress *string                `json:"ip_address,omitempty"`
+	UserAgent *string                `json:"user_agent,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
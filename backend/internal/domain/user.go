@@ -8,32 +8,37 @@ import (
 
 // User represents the user entity in the domain layer
 type User struct {
-	ID           uuid.UUID `json:"id"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"` // Never expose password hash in JSON
-	FullName     string    `json:"full_name"`
-	Avatar       *string   `json:"avatar,omitempty"`
-	PhoneNumber  string    `json:"phone_number"`
-	Role         UserRole  `json:"role"`
-	IsActive     bool      `json:"is_active"`
-	IsVerified   bool      `json:"is_verified"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID             uuid.UUID `json:"id"`
+	Email          string    `json:"email"`
+	PasswordHash   string    `json:"-"` // Never expose password hash in JSON
+	FullName       string    `json:"full_name"`
+	Avatar         *string   `json:"avatar,omitempty"`
+	PhoneNumber    string    `json:"phone_number"`
+	Role           UserRole  `json:"role"`
+	IsActive       bool      `json:"is_active"`
+	IsVerified     bool      `json:"is_verified"`
+	MaxSessions    *int      `json:"max_sessions,omitempty"`    // Overrides the role's device limit, nil uses the default
+	ActiveSessions *int      `json:"active_sessions,omitempty"` // Only filled in admin listings
+	TokenVersion   int       `json:"-"`                         // Access tokens carrying an older version are rejected
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 // UserRole represents the role of a user
 type UserRole string
 
 const (
-	RoleStudent    UserRole = "student"
-	RoleTeacher    UserRole = "teacher"
-	RoleAdmin      UserRole = "admin"
+	RoleStudent UserRole = "student"
+	RoleTeacher UserRole = "teacher"
+	RoleAdmin   UserRole = "admin"
+	RoleSales   UserRole = "sales"  // Staff handling consultation requests
+	RoleParent  UserRole = "parent" // Follows the progress of linked students
 )
 
 // IsValid checks if the role is valid
 func (r UserRole) IsValid() bool {
 	switch r {
-	case RoleStudent, RoleTeacher, RoleAdmin:
+	case RoleStudent, RoleTeacher, RoleAdmin, RoleSales, RoleParent:
 		return true
 	}
 	return false
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity links an account at a social login provider to a user
+type UserIdentity struct {
+	ID              uuid.UUID `json:"id"`
+	UserID          uuid.UUID `json:"user_id"`
+	Provider        string    `json:"provider"`
+	ProviderSubject string    `json:"-"` // Stable account ID at the provider
+	Email           string    `json:"email"`
+	CreatedAt       time.Time `json:"created_at"`
+	LastLoginAt     time.Time `json:"last_login_at"`
+}
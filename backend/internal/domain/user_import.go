@@ -0,0 +1,45 @@
+package domain
+
+import "github.com/google/uuid"
+
+// UserImportRowStatus represents the outcome of one row of a user import
+type UserImportRowStatus string
+
+const (
+	UserImportRowValid   UserImportRowStatus = "valid"   // Passed validation in a dry run
+	UserImportRowCreated UserImportRowStatus = "created" // The user was created
+	UserImportRowPartial UserImportRowStatus = "partial" // The user was created but some enrollments failed, see Errors
+	UserImportRowFailed  UserImportRowStatus = "failed"  // Rejected, see Errors
+)
+
+// UserImportOptions controls a bulk user import
+type UserImportOptions struct {
+	DryRun     bool // Only validate the rows
+	Enroll     bool // Enroll the created users in the courses listed on their row
+	AccessDays int  // Length of the enrollments, DefaultAccessDays when zero
+}
+
+// UserImportRow is one row of an import file and what happened to it
+type UserImportRow struct {
+	Row         int                 `json:"row"` // Line number in the file, the header is row 1
+	FullName    string              `json:"full_name"`
+	Email       string              `json:"email"`
+	PhoneNumber string              `json:"phone_number"`
+	CourseIDs   []uuid.UUID         `json:"course_ids,omitempty"`
+	Status      UserImportRowStatus `json:"status"`
+	Errors      []string            `json:"errors,omitempty"`
+	UserID      *uuid.UUID          `json:"user_id,omitempty"`
+	Password    string              `json:"password,omitempty"` // Initial password, only returned once
+	Enrolled    int                 `json:"enrolled"`           // Courses the user was enrolled in
+}
+
+// UserImportResult summarizes a bulk user import
+type UserImportResult struct {
+	DryRun      bool             `json:"dry_run"`
+	TotalRows   int              `json:"total_rows"`
+	ValidRows   int              `json:"valid_rows"`
+	CreatedRows int              `json:"created_rows"`
+	PartialRows int              `json:"partial_rows"` // Created rows with failed enrollments, also counted in CreatedRows
+	FailedRows  int              `json:"failed_rows"`
+	Rows        []*UserImportRow `json:"rows"`
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// AccountDeletionCodeRepository defines the interface for account deletion code data operations
+type AccountDeletionCodeRepository interface {
+	// Create creates a new deletion code
+	Create(ctx context.Context, code *domain.AccountDeletionCode) error
+
+	// GetLatestByUserID retrieves the most recently issued code for a user
+	GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.AccountDeletionCode, error)
+
+	// IncrementAttempts counts one guess of a code below the limit and returns the new count,
+	// failing with ErrDeletionCodeTooManyAttempts once the limit is reached
+	IncrementAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error)
+
+	// MarkUsed marks a code as used, failing if it was already used
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+
+	// InvalidateAllForUser marks all unused codes of a user as used
+	InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// ActivationCodeBatchRepository defines the interface for activation code batch data operations
+type ActivationCodeBatchRepository interface {
+	// CreateWithCodes creates a batch and batch.Quantity codes in one transaction. Each code is
+	// taken from generate, which is called again when a code already exists.
+	// The created codes are set on batch.Codes.
+	CreateWithCodes(ctx context.Context, batch *domain.ActivationCodeBatch, generate func() (string, error)) error
+
+	// GetByID retrieves a batch by ID with its course
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ActivationCodeBatch, error)
+
+	// List retrieves batches with optional course filter and pagination, newest first
+	List(ctx context.Context, courseID *uuid.UUID, limit, offset int) ([]*domain.ActivationCodeBatch, int, error)
+
+	// ListCodes retrieves the codes of a batch
+	ListCodes(ctx context.Context, batchID uuid.UUID) ([]*domain.ActivationCode, error)
+}
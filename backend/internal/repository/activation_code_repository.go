@@ -9,7 +9,7 @@ import (
 
 // ActivationCodeRepository defines the interface for activation code data operations
 type ActivationCodeRepository interface {
-	// Create creates a new activation code
+	// Create creates a new activation code with its extra courses
 	Create(ctx context.Context, code *domain.ActivationCode) error
 
 	// GetByID retrieves an activation code by ID
@@ -21,7 +21,8 @@ type ActivationCodeRepository interface {
 	// Update updates an activation code
 	Update(ctx context.Context, code *domain.ActivationCode) error
 
-	// IncrementUses increments the current_uses count by 1
+	// IncrementUses increments the current_uses count by 1 if the code is active, unexpired and
+	// has uses left, otherwise it returns the reason the code cannot be used
 	IncrementUses(ctx context.Context, id uuid.UUID) error
 
 	// Delete deletes an activation code
@@ -32,4 +33,7 @@ type ActivationCodeRepository interface {
 
 	// ListByCreator retrieves activation codes created by a specific user
 	ListByCreator(ctx context.Context, creatorID uuid.UUID, limit, offset int) ([]*domain.ActivationCode, int, error)
+
+	// ListGrantedCourseIDs returns every course the code unlocks: its course, its extra courses and the courses of its bundle
+	ListGrantedCourseIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
 }
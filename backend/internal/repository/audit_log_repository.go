@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// AuditLogRepository defines the interface for audit log data operations
+type AuditLogRepository interface {
+	// Create records a new audit log entry
+	Create(ctx context.Context, entry *domain.AuditLog) error
+
+	// List retrieves audit log entries matching the filter with pagination, newest first
+	List(ctx context.Context, filter *domain.AuditLogFilter, limit, offset int) ([]*domain.AuditLog, int64, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// CodeRedemptionRepository defines the interface for activation code redemption data operations
+type CodeRedemptionRepository interface {
+	// Create records a redemption
+	Create(ctx context.Context, redemption *domain.CodeRedemption) error
+
+	// ListByCode retrieves the redemptions of a code with the users, newest first
+	ListByCode(ctx context.Context, codeID uuid.UUID, limit, offset int) ([]*domain.CodeRedemption, int, error)
+
+	// GetBatchStats computes the usage of the codes of a batch and its redemption timeline
+	GetBatchStats(ctx context.Context, batchID uuid.UUID, interval domain.RedemptionInterval) (*domain.ActivationCodeBatchStats, error)
+
+	// AnonymizeByUserID removes the IP address and user agent from the redemptions of a user
+	AnonymizeByUserID(ctx context.Context, userID uuid.UUID) error
+}
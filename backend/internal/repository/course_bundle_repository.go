@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// CourseBundleRepository defines the interface for course bundle data operations
+type CourseBundleRepository interface {
+	// Create creates a bundle with its courses in one transaction
+	Create(ctx context.Context, bundle *domain.CourseBundle) error
+
+	// GetByID retrieves a bundle by ID with its course IDs
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.CourseBundle, error)
+
+	// List retrieves bundles with pagination, newest first
+	List(ctx context.Context, limit, offset int) ([]*domain.CourseBundle, int, error)
+
+	// Update updates a bundle and replaces its courses in one transaction
+	Update(ctx context.Context, bundle *domain.CourseBundle) error
+
+	// Delete deletes a bundle
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// IsInUse checks if activation codes or batches still unlock the bundle
+	IsInUse(ctx context.Context, id uuid.UUID) (bool, error)
+}
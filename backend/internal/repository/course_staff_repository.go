@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// CourseStaffRepository defines the interface for course staff data operations
+type CourseStaffRepository interface {
+	// ListByCourse retrieves the staff of a course, owner first
+	ListByCourse(ctx context.Context, courseID uuid.UUID) ([]*domain.CourseStaff, error)
+
+	// GetRole retrieves the role a user holds in a course
+	GetRole(ctx context.Context, courseID, userID uuid.UUID) (domain.CourseStaffRole, error)
+
+	// Upsert adds a user to the staff of a course or changes their role
+	Upsert(ctx context.Context, staff *domain.CourseStaff) error
+
+	// Delete removes a user from the staff of a course
+	Delete(ctx context.Context, courseID, userID uuid.UUID) error
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// LoginAttemptRepository defines the interface for failed login tracking.
+// Keys identify either an account or a client IP.
+type LoginAttemptRepository interface {
+	// Get retrieves the attempts for a key, a key without failures returns an empty record
+	Get(ctx context.Context, key string) (*domain.LoginAttempt, error)
+
+	// RegisterFailure atomically counts a failed login and returns the updated record.
+	// The count restarts when the last failure is older than window and the key is not locked.
+	RegisterFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempt, error)
+
+	// RemoveFailure takes back one failure counted by RegisterFailure, the count never goes below zero
+	RemoveFailure(ctx context.Context, key string) error
+
+	// Lock blocks logins for a key until the given time
+	Lock(ctx context.Context, key string, until time.Time) error
+
+	// Reset clears the failures and lock of a key
+	Reset(ctx context.Context, key string) error
+
+	// ListLocked retrieves the keys starting with prefix that are locked now, latest lock end first
+	ListLocked(ctx context.Context, prefix string, limit, offset int) ([]*domain.LoginAttempt, int, error)
+
+	// DeleteStale deletes unlocked records whose last failure is before the given time
+	DeleteStale(ctx context.Context, before time.Time) error
+}
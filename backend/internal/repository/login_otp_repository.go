@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// LoginOTPRepository defines the interface for passwordless login code data operations
+type LoginOTPRepository interface {
+	// Create creates a new login code
+	Create(ctx context.Context, otp *domain.LoginOTP) error
+
+	// GetLatestByPhone retrieves the most recently issued code for a phone number
+	GetLatestByPhone(ctx context.Context, phoneNumber string) (*domain.LoginOTP, error)
+
+	// CountSince counts the codes issued for a phone number since a point in time
+	CountSince(ctx context.Context, phoneNumber string, since time.Time) (int, error)
+
+	// IncrementAttempts counts one guess of a code below the limit and returns the new count,
+	// failing with ErrLoginOTPTooManyAttempts once the limit is reached
+	IncrementAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error)
+
+	// MarkUsed marks a code as used, failing if it was already used
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+
+	// InvalidateAllForPhone marks all unused codes of a phone number as used
+	InvalidateAllForPhone(ctx context.Context, phoneNumber string) error
+
+	// DeleteCreatedBefore deletes codes issued before a point in time
+	DeleteCreatedBefore(ctx context.Context, before time.Time) error
+}
@@ -0,0 +1,136 @@
+// Package memory provides in-process repository implementations
+// for single instance deployments and local development.
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// loginAttemptRepository implements repository.LoginAttemptRepository in memory
+type loginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]*domain.LoginAttempt
+}
+
+// NewLoginAttemptRepository creates a new in-memory login attempt repository.
+// Counters are lost on restart and not shared between instances.
+func NewLoginAttemptRepository() repository.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		attempts: make(map[string]*domain.LoginAttempt),
+	}
+}
+
+// Get retrieves the attempts for a key
+func (r *loginAttemptRepository) Get(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		return &domain.LoginAttempt{Key: key}, nil
+	}
+
+	copied := *attempt
+	return &copied, nil
+}
+
+// RegisterFailure atomically counts a failed login and returns the updated record
+func (r *loginAttemptRepository) RegisterFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = &domain.LoginAttempt{Key: key}
+		r.attempts[key] = attempt
+	}
+
+	if attempt.LastFailedAt.Before(now.Add(-window)) && !attempt.IsLocked() {
+		attempt.FailedCount = 0
+	}
+	attempt.FailedCount++
+	attempt.LastFailedAt = now
+
+	copied := *attempt
+	return &copied, nil
+}
+
+// RemoveFailure takes back one failure counted by RegisterFailure
+func (r *loginAttemptRepository) RemoveFailure(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempt, ok := r.attempts[key]; ok && attempt.FailedCount > 0 {
+		attempt.FailedCount--
+	}
+	return nil
+}
+
+// Lock blocks logins for a key until the given time
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempt, ok := r.attempts[key]; ok {
+		attempt.LockedUntil = &until
+	}
+	return nil
+}
+
+// Reset clears the failures and lock of a key
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+// ListLocked retrieves the keys starting with prefix that are locked now, latest lock end first
+func (r *loginAttemptRepository) ListLocked(ctx context.Context, prefix string, limit, offset int) ([]*domain.LoginAttempt, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	locked := []*domain.LoginAttempt{}
+	for key, attempt := range r.attempts {
+		if strings.HasPrefix(key, prefix) && attempt.IsLocked() {
+			copied := *attempt
+			locked = append(locked, &copied)
+		}
+	}
+	sort.Slice(locked, func(i, j int) bool {
+		return locked[i].LockedUntil.After(*locked[j].LockedUntil)
+	})
+
+	total := len(locked)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return locked[offset:end], total, nil
+}
+
+// DeleteStale deletes unlocked records whose last failure is before the given time
+func (r *loginAttemptRepository) DeleteStale(ctx context.Context, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, attempt := range r.attempts {
+		if attempt.LastFailedAt.Before(before) && !attempt.IsLocked() {
+			delete(r.attempts, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// MFARepository defines the interface for two-factor authentication data operations
+type MFARepository interface {
+	// GetByUserID retrieves the enrollment of a user, ErrMFANotEnabled if there is none
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error)
+
+	// SavePending stores a new unconfirmed secret, replacing an unconfirmed enrollment
+	SavePending(ctx context.Context, userID uuid.UUID, secret string) error
+
+	// Enable confirms the enrollment and replaces the recovery codes in one transaction
+	Enable(ctx context.Context, userID uuid.UUID, usedStep int64, recoveryCodeHashes []string) error
+
+	// UseStep records an accepted TOTP step. It fails with ErrMFACodeReused
+	// if the step is not newer than the last accepted one.
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) error
+
+	// Delete removes the enrollment and recovery codes of a user
+	Delete(ctx context.Context, userID uuid.UUID) error
+
+	// ReplaceRecoveryCodes replaces all recovery codes of a user
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+
+	// UseRecoveryCode marks an unused recovery code as used, ErrInvalidMFACode if there is none
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+
+	// CountUnusedRecoveryCodes counts the recovery codes a user has left
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// ParentLinkRepository defines the interface for parent link data operations
+type ParentLinkRepository interface {
+	// Create creates a pending link, returns ErrParentLinkExists if the parent already invited the student
+	Create(ctx context.Context, link *domain.ParentLink) error
+
+	// GetByID retrieves a link by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ParentLink, error)
+
+	// GetByParentAndStudent retrieves the link between a parent and a student
+	GetByParentAndStudent(ctx context.Context, parentID, studentID uuid.UUID) (*domain.ParentLink, error)
+
+	// ListAcceptedByParent retrieves the accepted links of a parent with the student profiles.
+	// Pending links are left out so a parent cannot tell whether an invite reached an account.
+	ListAcceptedByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.ParentLink, error)
+
+	// ListByStudent retrieves the links of a student with the parent profiles
+	ListByStudent(ctx context.Context, studentID uuid.UUID) ([]*domain.ParentLink, error)
+
+	// Accept marks a pending link as accepted
+	Accept(ctx context.Context, id uuid.UUID) error
+
+	// Delete removes a link
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteByUserID removes every link a user is the parent or the student of
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
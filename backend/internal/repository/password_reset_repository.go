@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// PasswordResetTokenRepository defines the interface for password reset token data operations
+type PasswordResetTokenRepository interface {
+	// Create creates a new password reset token
+	Create(ctx context.Context, token *domain.PasswordResetToken) error
+
+	// GetByTokenHash retrieves a password reset token by its hash
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error)
+
+	// GetLatestByUserID retrieves the most recently issued token for a user
+	GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.PasswordResetToken, error)
+
+	// IncrementAttempts counts one guess of a token below the limit and returns the new count,
+	// failing with ErrResetTooManyAttempts once the limit is reached
+	IncrementAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error)
+
+	// MarkUsed marks a token as used, failing if it was already used
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+
+	// InvalidateAllForUser marks all unused tokens of a user as used
+	InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error
+
+	// DeleteExpired deletes expired password reset tokens
+	DeleteExpired(ctx context.Context) error
+}
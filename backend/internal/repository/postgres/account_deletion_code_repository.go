@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// accountDeletionCodeRepository implements repository.AccountDeletionCodeRepository
+type accountDeletionCodeRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAccountDeletionCodeRepository creates a new PostgreSQL account deletion code repository
+func NewAccountDeletionCodeRepository(db *pgxpool.Pool) repository.AccountDeletionCodeRepository {
+	return &accountDeletionCodeRepository{db: db}
+}
+
+// Create creates a new deletion code in the database
+func (r *accountDeletionCodeRepository) Create(ctx context.Context, code *domain.AccountDeletionCode) error {
+	query := `
+		INSERT INTO account_deletion_codes (id, user_id, code_hash, attempts, expires_at, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if code.ID == uuid.Nil {
+		code.ID = uuid.New()
+	}
+	if code.CreatedAt.IsZero() {
+		code.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		code.ID,
+		code.UserID,
+		code.CodeHash,
+		code.Attempts,
+		code.ExpiresAt,
+		code.UsedAt,
+		code.CreatedAt,
+	)
+
+	return err
+}
+
+// GetLatestByUserID retrieves the most recently issued code for a user
+func (r *accountDeletionCodeRepository) GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.AccountDeletionCode, error) {
+	query := `
+		SELECT id, user_id, code_hash, attempts, expires_at, used_at, created_at
+		FROM account_deletion_codes
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	code := &domain.AccountDeletionCode{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&code.ID,
+		&code.UserID,
+		&code.CodeHash,
+		&code.Attempts,
+		&code.ExpiresAt,
+		&code.UsedAt,
+		&code.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInvalidDeletionCode
+	}
+
+	return code, err
+}
+
+// IncrementAttempts counts one guess of a code and returns the new attempt count.
+// The limit check and the increment are one statement, so concurrent guesses cannot
+// all read the old count and exceed the limit.
+func (r *accountDeletionCodeRepository) IncrementAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error) {
+	query := `
+		UPDATE account_deletion_codes
+		SET attempts = attempts + 1
+		WHERE id = $1 AND attempts < $2 AND used_at IS NULL
+		RETURNING attempts
+	`
+
+	var attempts int
+	err := r.db.QueryRow(ctx, query, id, maxAttempts).Scan(&attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrDeletionCodeTooManyAttempts
+	}
+
+	return attempts, err
+}
+
+// MarkUsed marks a code as used, failing if it was already used
+func (r *accountDeletionCodeRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE account_deletion_codes
+		SET used_at = $2
+		WHERE id = $1 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrInvalidDeletionCode
+	}
+
+	return nil
+}
+
+// InvalidateAllForUser marks all unused codes of a user as used
+func (r *accountDeletionCodeRepository) InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE account_deletion_codes
+		SET used_at = $2
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, time.Now())
+	return err
+}
@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// maxCodeAttempts bounds how many codes are generated for one slot before giving up on collisions
+const maxCodeAttempts = 5
+
+// activationCodeBatchRepository implements repository.ActivationCodeBatchRepository
+type activationCodeBatchRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewActivationCodeBatchRepository creates a new activation code batch repository
+func NewActivationCodeBatchRepository(db *pgxpool.Pool) repository.ActivationCodeBatchRepository {
+	return &activationCodeBatchRepository{db: db}
+}
+
+// CreateWithCodes creates a batch and its codes in one transaction
+func (r *activationCodeBatchRepository) CreateWithCodes(ctx context.Context, batch *domain.ActivationCodeBatch, generate func() (string, error)) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if batch.ID == uuid.Nil {
+		batch.ID = uuid.New()
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO activation_code_batches (id, label, course_id, prefix, quantity, max_uses, expires_at, access_type, access_days, access_until, bundle_id, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW())
+		RETURNING created_at
+	`,
+		batch.ID,
+		batch.Label,
+		batch.CourseID,
+		batch.Prefix,
+		batch.Quantity,
+		batch.MaxUses,
+		batch.ExpiresAt,
+		batch.AccessType,
+		batch.AccessDays,
+		batch.AccessUntil,
+		batch.BundleID,
+		batch.CreatedBy,
+	).Scan(&batch.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO activation_codes (id, code, course_id, max_uses, current_uses, expires_at, is_active, created_by, batch_id, access_type, access_days, access_until, bundle_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 0, $5, true, $6, $7, $8, $9, $10, $11, $12, $12)
+		ON CONFLICT (code) DO NOTHING
+	`
+
+	codes := make([]*domain.ActivationCode, 0, batch.Quantity)
+	for len(codes) < batch.Quantity {
+		code := &domain.ActivationCode{
+			ID:          uuid.New(),
+			CourseID:    batch.CourseID,
+			MaxUses:     batch.MaxUses,
+			ExpiresAt:   batch.ExpiresAt,
+			IsActive:    true,
+			CreatedBy:   batch.CreatedBy,
+			BatchID:     &batch.ID,
+			AccessType:  batch.AccessType,
+			AccessDays:  batch.AccessDays,
+			AccessUntil: batch.AccessUntil,
+			BundleID:    batch.BundleID,
+			CourseIDs:   batch.CourseIDs,
+			CreatedAt:   batch.CreatedAt,
+			UpdatedAt:   batch.CreatedAt,
+		}
+
+		inserted := false
+		for attempt := 0; attempt < maxCodeAttempts && !inserted; attempt++ {
+			code.Code, err = generate()
+			if err != nil {
+				return err
+			}
+
+			result, err := tx.Exec(ctx, query,
+				code.ID,
+				code.Code,
+				code.CourseID,
+				code.MaxUses,
+				code.ExpiresAt,
+				code.CreatedBy,
+				code.BatchID,
+				code.AccessType,
+				code.AccessDays,
+				code.AccessUntil,
+				code.BundleID,
+				code.CreatedAt,
+			)
+			if err != nil {
+				return err
+			}
+			inserted = result.RowsAffected() == 1
+		}
+		if !inserted {
+			return domain.ErrActivationCodeCollision
+		}
+		if err := insertActivationCodeCourses(ctx, tx, code); err != nil {
+			return err
+		}
+
+		codes = append(codes, code)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	batch.Codes = codes
+	return nil
+}
+
+// GetByID retrieves a batch by ID with its course
+func (r *activationCodeBatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ActivationCodeBatch, error) {
+	query := `
+		SELECT b.id, b.label, b.course_id, b.prefix, b.quantity, b.max_uses, b.expires_at, b.access_type, b.access_days, b.access_until, b.bundle_id, b.created_by, b.created_at,
+		       c.id, c.title, c.slug
+		FROM activation_code_batches b
+		JOIN courses c ON b.course_id = c.id
+		WHERE b.id = $1
+	`
+
+	batch := &domain.ActivationCodeBatch{}
+	course := &domain.Course{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&batch.ID,
+		&batch.Label,
+		&batch.CourseID,
+		&batch.Prefix,
+		&batch.Quantity,
+		&batch.MaxUses,
+		&batch.ExpiresAt,
+		&batch.AccessType,
+		&batch.AccessDays,
+		&batch.AccessUntil,
+		&batch.BundleID,
+		&batch.CreatedBy,
+		&batch.CreatedAt,
+		&course.ID,
+		&course.Title,
+		&course.Slug,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrActivationCodeBatchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Course = course
+	return batch, nil
+}
+
+// List retrieves batches with optional course filter and pagination, newest first
+func (r *activationCodeBatchRepository) List(ctx context.Context, courseID *uuid.UUID, limit, offset int) ([]*domain.ActivationCodeBatch, int, error) {
+	countQuery := `SELECT COUNT(*) FROM activation_code_batches WHERE ($1::uuid IS NULL OR course_id = $1)`
+	var total int
+	if err := r.db.QueryRow(ctx, countQuery, courseID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT b.id, b.label, b.course_id, b.prefix, b.quantity, b.max_uses, b.expires_at, b.access_type, b.access_days, b.access_until, b.bundle_id, b.created_by, b.created_at,
+		       c.id, c.title, c.slug
+		FROM activation_code_batches b
+		JOIN courses c ON b.course_id = c.id
+		WHERE ($1::uuid IS NULL OR b.course_id = $1)
+		ORDER BY b.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, courseID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	batches := []*domain.ActivationCodeBatch{}
+	for rows.Next() {
+		batch := &domain.ActivationCodeBatch{}
+		course := &domain.Course{}
+		if err := rows.Scan(
+			&batch.ID,
+			&batch.Label,
+			&batch.CourseID,
+			&batch.Prefix,
+			&batch.Quantity,
+			&batch.MaxUses,
+			&batch.ExpiresAt,
+			&batch.AccessType,
+			&batch.AccessDays,
+			&batch.AccessUntil,
+			&batch.BundleID,
+			&batch.CreatedBy,
+			&batch.CreatedAt,
+			&course.ID,
+			&course.Title,
+			&course.Slug,
+		); err != nil {
+			return nil, 0, err
+		}
+		batch.Course = course
+		batches = append(batches, batch)
+	}
+
+	return batches, total, rows.Err()
+}
+
+// ListCodes retrieves the codes of a batch
+func (r *activationCodeBatchRepository) ListCodes(ctx context.Context, batchID uuid.UUID) ([]*domain.ActivationCode, error) {
+	query := `
+		SELECT id, code, course_id, max_uses, current_uses, expires_at, is_active, created_by, note, batch_id, access_type, access_days, access_until, bundle_id, created_at, updated_at
+		FROM activation_codes
+		WHERE batch_id = $1
+		ORDER BY created_at, code
+	`
+
+	rows, err := r.db.Query(ctx, query, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codes := []*domain.ActivationCode{}
+	for rows.Next() {
+		code := &domain.ActivationCode{}
+		if err := rows.Scan(
+			&code.ID,
+			&code.Code,
+			&code.CourseID,
+			&code.MaxUses,
+			&code.CurrentUses,
+			&code.ExpiresAt,
+			&code.IsActive,
+			&code.CreatedBy,
+			&code.Note,
+			&code.BatchID,
+			&code.AccessType,
+			&code.AccessDays,
+			&code.AccessUntil,
+			&code.BundleID,
+			&code.CreatedAt,
+			&code.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadActivationCodeCourses(ctx, r.db, codes...); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
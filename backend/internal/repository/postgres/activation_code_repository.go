@@ -13,7 +13,7 @@ import (
 
 // activationCodeRepository implements repository.ActivationCodeRepository
 type activationCodeRepository struct {
-	db *pgxpool.Pool
+	db dbtx
 }
 
 // NewActivationCodeRepository creates a new activation code repository
@@ -21,11 +21,17 @@ func NewActivationCodeRepository(db *pgxpool.Pool) repository.ActivationCodeRepo
 	return &activationCodeRepository{db: db}
 }
 
-// Create creates a new activation code
+// Create creates a new activation code with its extra courses
 func (r *activationCodeRepository) Create(ctx context.Context, code *domain.ActivationCode) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO activation_codes (id, code, course_id, max_uses, current_uses, expires_at, is_active, created_by, note, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		INSERT INTO activation_codes (id, code, course_id, max_uses, current_uses, expires_at, is_active, created_by, note, batch_id, access_type, access_days, access_until, bundle_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
@@ -33,7 +39,7 @@ func (r *activationCodeRepository) Create(ctx context.Context, code *domain.Acti
 		code.ID = uuid.New()
 	}
 
-	err := r.db.QueryRow(
+	err = tx.QueryRow(
 		ctx,
 		query,
 		code.ID,
@@ -45,15 +51,27 @@ func (r *activationCodeRepository) Create(ctx context.Context, code *domain.Acti
 		code.IsActive,
 		code.CreatedBy,
 		code.Note,
+		code.BatchID,
+		code.AccessType,
+		code.AccessDays,
+		code.AccessUntil,
+		code.BundleID,
 	).Scan(&code.ID, &code.CreatedAt, &code.UpdatedAt)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := insertActivationCodeCourses(ctx, tx, code); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 // GetByID retrieves an activation code by ID
 func (r *activationCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ActivationCode, error) {
 	query := `
-		SELECT id, code, course_id, max_uses, current_uses, expires_at, is_active, created_by, note, created_at, updated_at
+		SELECT id, code, course_id, max_uses, current_uses, expires_at, is_active, created_by, note, batch_id, access_type, access_days, access_until, bundle_id, created_at, updated_at
 		FROM activation_codes
 		WHERE id = $1
 	`
@@ -69,6 +87,11 @@ func (r *activationCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 		&code.IsActive,
 		&code.CreatedBy,
 		&code.Note,
+		&code.BatchID,
+		&code.AccessType,
+		&code.AccessDays,
+		&code.AccessUntil,
+		&code.BundleID,
 		&code.CreatedAt,
 		&code.UpdatedAt,
 	)
@@ -80,13 +103,17 @@ func (r *activationCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 		return nil, err
 	}
 
+	if err := loadActivationCodeCourses(ctx, r.db, code); err != nil {
+		return nil, err
+	}
+
 	return code, nil
 }
 
 // GetByCode retrieves an activation code by its code string
 func (r *activationCodeRepository) GetByCode(ctx context.Context, codeStr string) (*domain.ActivationCode, error) {
 	query := `
-		SELECT ac.id, ac.code, ac.course_id, ac.max_uses, ac.current_uses, ac.expires_at, ac.is_active, ac.created_by, ac.note, ac.created_at, ac.updated_at,
+		SELECT ac.id, ac.code, ac.course_id, ac.max_uses, ac.current_uses, ac.expires_at, ac.is_active, ac.created_by, ac.note, ac.batch_id, ac.access_type, ac.access_days, ac.access_until, ac.bundle_id, ac.created_at, ac.updated_at,
 		       c.id, c.title, c.slug, c.description, c.short_description, c.image_url, c.price, c.level, c.status
 		FROM activation_codes ac
 		LEFT JOIN courses c ON ac.course_id = c.id
@@ -107,6 +134,11 @@ func (r *activationCodeRepository) GetByCode(ctx context.Context, codeStr string
 		&code.IsActive,
 		&code.CreatedBy,
 		&code.Note,
+		&code.BatchID,
+		&code.AccessType,
+		&code.AccessDays,
+		&code.AccessUntil,
+		&code.BundleID,
 		&code.CreatedAt,
 		&code.UpdatedAt,
 		&course.ID,
@@ -135,6 +167,10 @@ func (r *activationCodeRepository) GetByCode(ctx context.Context, codeStr string
 	}
 	code.Course = course
 
+	if err := loadActivationCodeCourses(ctx, r.db, code); err != nil {
+		return nil, err
+	}
+
 	return code, nil
 }
 
@@ -158,12 +194,17 @@ func (r *activationCodeRepository) Update(ctx context.Context, code *domain.Acti
 	return nil
 }
 
-// IncrementUses increments the current_uses count by 1
+// IncrementUses increments the current_uses count by 1 if the code can still be used.
+// The check and the increment are one statement, so concurrent redemptions of the last
+// use wait on the row lock and only the first one succeeds.
 func (r *activationCodeRepository) IncrementUses(ctx context.Context, id uuid.UUID) error {
 	query := `
 		UPDATE activation_codes
 		SET current_uses = current_uses + 1, updated_at = NOW()
 		WHERE id = $1
+		  AND is_active
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		  AND (max_uses IS NULL OR current_uses < max_uses)
 	`
 
 	result, err := r.db.Exec(ctx, query, id)
@@ -172,7 +213,15 @@ func (r *activationCodeRepository) IncrementUses(ctx context.Context, id uuid.UU
 	}
 
 	if result.RowsAffected() == 0 {
-		return domain.ErrActivationCodeNotFound
+		// Report why the code could not be used
+		code, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := code.IsValid(); err != nil {
+			return err
+		}
+		return domain.ErrActivationCodeUsedUp
 	}
 
 	return nil
@@ -206,7 +255,7 @@ func (r *activationCodeRepository) List(ctx context.Context, courseID *uuid.UUID
 
 	// List query
 	query := `
-		SELECT ac.id, ac.code, ac.course_id, ac.max_uses, ac.current_uses, ac.expires_at, ac.is_active, ac.created_by, ac.note, ac.created_at, ac.updated_at,
+		SELECT ac.id, ac.code, ac.course_id, ac.max_uses, ac.current_uses, ac.expires_at, ac.is_active, ac.created_by, ac.note, ac.batch_id, ac.access_type, ac.access_days, ac.access_until, ac.bundle_id, ac.created_at, ac.updated_at,
 		       c.id, c.title, c.slug, c.description, c.short_description, c.image_url, c.price, c.level, c.status
 		FROM activation_codes ac
 		JOIN courses c ON ac.course_id = c.id
@@ -237,6 +286,11 @@ func (r *activationCodeRepository) List(ctx context.Context, courseID *uuid.UUID
 			&code.IsActive,
 			&code.CreatedBy,
 			&code.Note,
+			&code.BatchID,
+			&code.AccessType,
+			&code.AccessDays,
+			&code.AccessUntil,
+			&code.BundleID,
 			&code.CreatedAt,
 			&code.UpdatedAt,
 			&course.ID,
@@ -263,6 +317,10 @@ func (r *activationCodeRepository) List(ctx context.Context, courseID *uuid.UUID
 		codes = append(codes, code)
 	}
 
+	if err := loadActivationCodeCourses(ctx, r.db, codes...); err != nil {
+		return nil, 0, err
+	}
+
 	return codes, total, nil
 }
 
@@ -278,7 +336,7 @@ func (r *activationCodeRepository) ListByCreator(ctx context.Context, creatorID
 
 	// List query
 	query := `
-		SELECT id, code, course_id, max_uses, current_uses, expires_at, is_active, created_by, note, created_at, updated_at
+		SELECT id, code, course_id, max_uses, current_uses, expires_at, is_active, created_by, note, batch_id, access_type, access_days, access_until, bundle_id, created_at, updated_at
 		FROM activation_codes
 		WHERE created_by = $1
 		ORDER BY created_at DESC
@@ -304,6 +362,11 @@ func (r *activationCodeRepository) ListByCreator(ctx context.Context, creatorID
 			&code.IsActive,
 			&code.CreatedBy,
 			&code.Note,
+			&code.BatchID,
+			&code.AccessType,
+			&code.AccessDays,
+			&code.AccessUntil,
+			&code.BundleID,
 			&code.CreatedAt,
 			&code.UpdatedAt,
 		)
@@ -313,5 +376,106 @@ func (r *activationCodeRepository) ListByCreator(ctx context.Context, creatorID
 		codes = append(codes, code)
 	}
 
+	if err := loadActivationCodeCourses(ctx, r.db, codes...); err != nil {
+		return nil, 0, err
+	}
+
 	return codes, total, nil
 }
+
+// ListGrantedCourseIDs returns every course the code unlocks: its course, its extra courses and the courses of its bundle
+func (r *activationCodeRepository) ListGrantedCourseIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT course_id FROM (
+			SELECT course_id, 0 AS source, 0 AS position FROM activation_codes WHERE id = $1
+			UNION ALL
+			SELECT course_id, 1, 0 FROM activation_code_courses WHERE activation_code_id = $1
+			UNION ALL
+			SELECT bc.course_id, 2, bc.position
+			FROM activation_codes ac
+			JOIN course_bundle_courses bc ON bc.bundle_id = ac.bundle_id
+			WHERE ac.id = $1
+		) granted
+		ORDER BY source, position
+	`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seen := make(map[uuid.UUID]bool)
+	courseIDs := []uuid.UUID{}
+	for rows.Next() {
+		var courseID uuid.UUID
+		if err := rows.Scan(&courseID); err != nil {
+			return nil, err
+		}
+		if !seen[courseID] {
+			seen[courseID] = true
+			courseIDs = append(courseIDs, courseID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(courseIDs) == 0 {
+		return nil, domain.ErrActivationCodeNotFound
+	}
+
+	return courseIDs, nil
+}
+
+// insertActivationCodeCourses records the extra courses of a code
+func insertActivationCodeCourses(ctx context.Context, tx pgx.Tx, code *domain.ActivationCode) error {
+	for _, courseID := range code.CourseIDs {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO activation_code_courses (activation_code_id, course_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, code.ID, courseID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadActivationCodeCourses sets CourseIDs on the codes with one query
+func loadActivationCodeCourses(ctx context.Context, db dbtx, codes ...*domain.ActivationCode) error {
+	if len(codes) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*domain.ActivationCode, len(codes))
+	ids := make([]uuid.UUID, len(codes))
+	for i, code := range codes {
+		byID[code.ID] = code
+		ids[i] = code.ID
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT activation_code_id, course_id
+		FROM activation_code_courses
+		WHERE activation_code_id = ANY($1)
+		ORDER BY course_id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var codeID, courseID uuid.UUID
+		if err := rows.Scan(&codeID, &courseID); err != nil {
+			return err
+		}
+		if code, ok := byID[codeID]; ok {
+			code.CourseIDs = append(code.CourseIDs, courseID)
+		}
+	}
+
+	return rows.Err()
+}
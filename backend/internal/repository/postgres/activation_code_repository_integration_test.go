@@ -0,0 +1,162 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// Run with a migrated database:
+//
+//	TEST_DATABASE_URL=postgres://... go test -tags integration ./internal/repository/postgres/
+func newTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	pool, err := pgxpool.New(context.Background(), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	return pool
+}
+
+// newTestActivationCode stores a code for a new course and teacher, deleted again when the test ends
+func newTestActivationCode(t *testing.T, pool *pgxpool.Pool, maxUses int) *domain.ActivationCode {
+	t.Helper()
+	ctx := context.Background()
+	suffix := uuid.NewString()[:8]
+
+	teacher := &domain.User{
+		Email:    "integration-" + suffix + "@example.com",
+		FullName: "Integration Test",
+		Role:     domain.RoleTeacher,
+		IsActive: true,
+	}
+	if err := NewUserRepository(pool).Create(ctx, teacher); err != nil {
+		t.Fatal(err)
+	}
+
+	grade := "1"
+	course := &domain.Course{
+		Title:        "Integration test " + suffix,
+		Slug:         "integration-test-" + suffix,
+		InstructorID: teacher.ID,
+		Level:        domain.LevelBasic,
+		Grade:        &grade,
+		Status:       domain.StatusDraft,
+	}
+	if err := NewCourseRepository(pool).Create(ctx, course); err != nil {
+		t.Fatal(err)
+	}
+
+	code := &domain.ActivationCode{
+		Code:       "INTEGRATION-" + suffix,
+		CourseID:   course.ID,
+		MaxUses:    &maxUses,
+		IsActive:   true,
+		CreatedBy:  teacher.ID,
+		AccessType: domain.AccessTypeLifetime,
+	}
+	if err := NewActivationCodeRepository(pool).Create(ctx, code); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		// Codes go with their course, which must go before its instructor
+		if _, err := pool.Exec(context.Background(), `DELETE FROM courses WHERE id = $1`, course.ID); err != nil {
+			t.Errorf("cleanup course: %v", err)
+		}
+		if _, err := pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, teacher.ID); err != nil {
+			t.Errorf("cleanup user: %v", err)
+		}
+	})
+
+	return code
+}
+
+func TestIncrementUsesConcurrentRedemptionsOfSingleUseCode(t *testing.T) {
+	pool := newTestPool(t)
+	code := newTestActivationCode(t, pool, 1)
+	uow := NewUnitOfWork(pool)
+	ctx := context.Background()
+
+	// The first redemption keeps its transaction open until the second one waits for the row,
+	// so both run the conditional update against the same committed use count
+	incremented := make(chan struct{})
+	release := make(chan struct{})
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs[0] = uow.Do(ctx, func(repos repository.TxRepositories) error {
+			err := repos.ActivationCodes().IncrementUses(ctx, code.ID)
+			close(incremented)
+			<-release
+			return err
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		<-incremented
+		errs[1] = uow.Do(ctx, func(repos repository.TxRepositories) error {
+			return repos.ActivationCodes().IncrementUses(ctx, code.ID)
+		})
+	}()
+
+	<-incremented
+	waitForBlockedQuery(t, pool)
+	close(release)
+	wg.Wait()
+
+	if errs[0] != nil {
+		t.Errorf("first redemption: got %v, want nil", errs[0])
+	}
+	if !errors.Is(errs[1], domain.ErrActivationCodeUsedUp) {
+		t.Errorf("second redemption: got %v, want %v", errs[1], domain.ErrActivationCodeUsedUp)
+	}
+
+	stored, err := NewActivationCodeRepository(pool).GetByID(ctx, code.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.CurrentUses != 1 {
+		t.Errorf("got %d uses stored, want 1", stored.CurrentUses)
+	}
+}
+
+// waitForBlockedQuery waits until a query waits for a lock, or gives up after a few seconds.
+// It never stops the test, the open transaction must be released whatever happens.
+func waitForBlockedQuery(t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		var waiting int
+		if err := pool.QueryRow(context.Background(), `SELECT COUNT(*) FROM pg_locks WHERE NOT granted`).Scan(&waiting); err != nil {
+			t.Errorf("list waiting locks: %v", err)
+			return
+		}
+		if waiting > 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Log("second redemption never waited for the row lock")
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// auditLogRepository implements repository.AuditLogRepository
+type auditLogRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAuditLogRepository creates a new PostgreSQL audit log repository
+func NewAuditLogRepository(db *pgxpool.Pool) repository.AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+// Create records a new audit log entry
+func (r *auditLogRepository) Create(ctx context.Context, entry *domain.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (id, actor_id, action, target_type, target_id, before_data, after_data, changes, ip_address, user_agent, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	if entry.Changes == nil {
+		entry.Changes = map[string]domain.AuditChange{}
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		entry.ID,
+		entry.ActorID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		entry.Before,
+		entry.After,
+		entry.Changes,
+		entry.IPAddress,
+		entry.UserAgent,
+		entry.CreatedAt,
+	)
+
+	return err
+}
+
+// List retrieves audit log entries matching the filter with pagination, newest first
+func (r *auditLogRepository) List(ctx context.Context, filter *domain.AuditLogFilter, limit, offset int) ([]*domain.AuditLog, int64, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argCount := 1
+
+	if filter.ActorID != nil {
+		where += fmt.Sprintf(" AND a.actor_id = $%d", argCount)
+		args = append(args, *filter.ActorID)
+		argCount++
+	}
+	if filter.Action != "" {
+		where += fmt.Sprintf(" AND a.action = $%d", argCount)
+		args = append(args, filter.Action)
+		argCount++
+	}
+	if filter.TargetType != "" {
+		where += fmt.Sprintf(" AND a.target_type = $%d", argCount)
+		args = append(args, filter.TargetType)
+		argCount++
+	}
+	if filter.TargetID != "" {
+		where += fmt.Sprintf(" AND a.target_id = $%d", argCount)
+		args = append(args, filter.TargetID)
+		argCount++
+	}
+	if filter.From != nil {
+		where += fmt.Sprintf(" AND a.created_at >= $%d", argCount)
+		args = append(args, *filter.From)
+		argCount++
+	}
+	if filter.To != nil {
+		where += fmt.Sprintf(" AND a.created_at < $%d", argCount)
+		args = append(args, *filter.To)
+		argCount++
+	}
+
+	// Get total count
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM audit_logs a` + where
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT a.id, a.actor_id, COALESCE(u.email, ''), a.action, a.target_type, a.target_id,
+			a.before_data, a.after_data, a.changes, a.ip_address, a.user_agent, a.created_at
+		FROM audit_logs a
+		LEFT JOIN users u ON u.id = a.actor_id
+	` + where + fmt.Sprintf(" ORDER BY a.created_at DESC LIMIT $%d OFFSET $%d", argCount, argCount+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	logs := []*domain.AuditLog{}
+	for rows.Next() {
+		entry := &domain.AuditLog{}
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ActorID,
+			&entry.ActorEmail,
+			&entry.Action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&entry.Before,
+			&entry.After,
+			&entry.Changes,
+			&entry.IPAddress,
+			&entry.UserAgent,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		logs = append(logs, entry)
+	}
+
+	return logs, total, rows.Err()
+}
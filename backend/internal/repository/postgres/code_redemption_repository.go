@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// codeRedemptionRepository implements repository.CodeRedemptionRepository
+type codeRedemptionRepository struct {
+	db dbtx
+}
+
+// NewCodeRedemptionRepository creates a new code redemption repository
+func NewCodeRedemptionRepository(db *pgxpool.Pool) repository.CodeRedemptionRepository {
+	return &codeRedemptionRepository{db: db}
+}
+
+// Create records a redemption
+func (r *codeRedemptionRepository) Create(ctx context.Context, redemption *domain.CodeRedemption) error {
+	query := `
+		INSERT INTO code_redemptions (id, activation_code_id, user_id, courses_unlocked, ip_address, user_agent, redeemed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING redeemed_at
+	`
+
+	if redemption.ID == uuid.Nil {
+		redemption.ID = uuid.New()
+	}
+
+	return r.db.QueryRow(
+		ctx,
+		query,
+		redemption.ID,
+		redemption.ActivationCodeID,
+		redemption.UserID,
+		redemption.CoursesUnlocked,
+		redemption.IPAddress,
+		redemption.UserAgent,
+	).Scan(&redemption.RedeemedAt)
+}
+
+// ListByCode retrieves the redemptions of a code with the users, newest first
+func (r *codeRedemptionRepository) ListByCode(ctx context.Context, codeID uuid.UUID, limit, offset int) ([]*domain.CodeRedemption, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM code_redemptions WHERE activation_code_id = $1`
+	if err := r.db.QueryRow(ctx, countQuery, codeID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT cr.id, cr.activation_code_id, cr.user_id, cr.courses_unlocked, cr.ip_address, cr.user_agent, cr.redeemed_at,
+		       u.id, u.full_name, u.email, u.avatar
+		FROM code_redemptions cr
+		LEFT JOIN users u ON cr.user_id = u.id
+		WHERE cr.activation_code_id = $1
+		ORDER BY cr.redeemed_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, codeID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	redemptions := []*domain.CodeRedemption{}
+	for rows.Next() {
+		redemption := &domain.CodeRedemption{}
+		var userID *uuid.UUID
+		var fullName, email, avatar *string
+		if err := rows.Scan(
+			&redemption.ID,
+			&redemption.ActivationCodeID,
+			&redemption.UserID,
+			&redemption.CoursesUnlocked,
+			&redemption.IPAddress,
+			&redemption.UserAgent,
+			&redemption.RedeemedAt,
+			&userID,
+			&fullName,
+			&email,
+			&avatar,
+		); err != nil {
+			return nil, 0, err
+		}
+
+		if userID != nil {
+			redemption.User = &domain.LinkedUser{ID: *userID, Avatar: avatar}
+			if fullName != nil {
+				redemption.User.FullName = *fullName
+			}
+			if email != nil {
+				redemption.User.Email = *email
+			}
+		}
+		redemptions = append(redemptions, redemption)
+	}
+
+	return redemptions, total, rows.Err()
+}
+
+// GetBatchStats computes the usage of the codes of a batch and its redemption timeline
+func (r *codeRedemptionRepository) GetBatchStats(ctx context.Context, batchID uuid.UUID, interval domain.RedemptionInterval) (*domain.ActivationCodeBatchStats, error) {
+	stats := &domain.ActivationCodeBatchStats{
+		BatchID:  batchID,
+		Interval: interval,
+		Timeline: []domain.RedemptionPoint{},
+	}
+
+	// Counts come from current_uses, which also covers codes redeemed before redemptions were recorded
+	err := r.db.QueryRow(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE current_uses > 0),
+			COUNT(*) FILTER (
+				WHERE is_active
+				  AND (expires_at IS NULL OR expires_at > NOW())
+				  AND (max_uses IS NULL OR current_uses < max_uses)
+			),
+			COALESCE(SUM(current_uses), 0)
+		FROM activation_codes
+		WHERE batch_id = $1
+	`, batchID).Scan(&stats.TotalCodes, &stats.RedeemedCodes, &stats.RemainingCodes, &stats.TotalRedemptions)
+	if err != nil {
+		return nil, err
+	}
+	if stats.TotalCodes > 0 {
+		stats.RedemptionRate = float64(stats.RedeemedCodes) / float64(stats.TotalCodes)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT date_trunc($2::text, cr.redeemed_at) AS period,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE cr.redeemed_at = first.redeemed_at)
+		FROM code_redemptions cr
+		JOIN activation_codes ac ON ac.id = cr.activation_code_id
+		JOIN (
+			SELECT activation_code_id, MIN(redeemed_at) AS redeemed_at
+			FROM code_redemptions
+			GROUP BY activation_code_id
+		) first ON first.activation_code_id = cr.activation_code_id
+		WHERE ac.batch_id = $1
+		GROUP BY period
+		ORDER BY period
+	`, batchID, string(interval))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	redeemed := 0
+	for rows.Next() {
+		var point domain.RedemptionPoint
+		if err := rows.Scan(&point.Period, &point.Redemptions, &point.NewCodes); err != nil {
+			return nil, err
+		}
+
+		redeemed += point.NewCodes
+		if stats.TotalCodes > 0 {
+			point.RedemptionRate = float64(redeemed) / float64(stats.TotalCodes)
+		}
+		stats.Timeline = append(stats.Timeline, point)
+	}
+
+	return stats, rows.Err()
+}
+
+// AnonymizeByUserID removes the IP address and user agent from the redemptions of a user
+func (r *codeRedemptionRepository) AnonymizeByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE code_redemptions SET ip_address = NULL, user_agent = NULL WHERE user_id = $1`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
@@ -155,3 +155,58 @@ func (r *consultationRepository) Delete(ctx context.Context, id uuid.UUID) error
 	_, err := r.db.Exec(ctx, query, id)
 	return err
 }
+
+// ListByPhone gets the consultation requests submitted with a phone number
+func (r *consultationRepository) ListByPhone(ctx context.Context, phone string) ([]*domain.ConsultationRequest, error) {
+	query := `
+		SELECT id, student_name, parent_name, phone, birth_year, grade, academic_level, status, note, created_at, updated_at
+		FROM consultation_requests
+		WHERE phone = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, phone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := []*domain.ConsultationRequest{}
+	for rows.Next() {
+		var note *string
+		req := &domain.ConsultationRequest{}
+		err := rows.Scan(
+			&req.ID,
+			&req.StudentName,
+			&req.ParentName,
+			&req.Phone,
+			&req.BirthYear,
+			&req.Grade,
+			&req.AcademicLevel,
+			&req.Status,
+			&note,
+			&req.CreatedAt,
+			&req.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if note != nil {
+			req.Note = *note
+		}
+		requests = append(requests, req)
+	}
+
+	return requests, rows.Err()
+}
+
+// AnonymizeByPhone removes the personal data from the consultation requests of a phone number
+func (r *consultationRepository) AnonymizeByPhone(ctx context.Context, phone string) error {
+	query := `
+		UPDATE consultation_requests
+		SET student_name = 'Ẩn danh', parent_name = 'Ẩn danh', phone = '', note = NULL, updated_at = NOW()
+		WHERE phone = $1
+	`
+	_, err := r.db.Exec(ctx, query, phone)
+	return err
+}
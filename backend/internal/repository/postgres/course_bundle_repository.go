@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// courseBundleRepository implements repository.CourseBundleRepository
+type courseBundleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCourseBundleRepository creates a new course bundle repository
+func NewCourseBundleRepository(db *pgxpool.Pool) repository.CourseBundleRepository {
+	return &courseBundleRepository{db: db}
+}
+
+// Create creates a bundle with its courses in one transaction
+func (r *courseBundleRepository) Create(ctx context.Context, bundle *domain.CourseBundle) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if bundle.ID == uuid.Nil {
+		bundle.ID = uuid.New()
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO course_bundles (id, name, description, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		RETURNING created_at, updated_at
+	`, bundle.ID, bundle.Name, bundle.Description, bundle.CreatedBy).Scan(&bundle.CreatedAt, &bundle.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := insertBundleCourses(ctx, tx, bundle); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetByID retrieves a bundle by ID with its course IDs
+func (r *courseBundleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CourseBundle, error) {
+	query := `
+		SELECT id, name, description, created_by, created_at, updated_at
+		FROM course_bundles
+		WHERE id = $1
+	`
+
+	bundle := &domain.CourseBundle{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&bundle.ID,
+		&bundle.Name,
+		&bundle.Description,
+		&bundle.CreatedBy,
+		&bundle.CreatedAt,
+		&bundle.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrCourseBundleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadCourseIDs(ctx, bundle); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// List retrieves bundles with pagination, newest first
+func (r *courseBundleRepository) List(ctx context.Context, limit, offset int) ([]*domain.CourseBundle, int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM course_bundles`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, name, description, created_by, created_at, updated_at
+		FROM course_bundles
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	bundles := []*domain.CourseBundle{}
+	for rows.Next() {
+		bundle := &domain.CourseBundle{}
+		if err := rows.Scan(
+			&bundle.ID,
+			&bundle.Name,
+			&bundle.Description,
+			&bundle.CreatedBy,
+			&bundle.CreatedAt,
+			&bundle.UpdatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		bundles = append(bundles, bundle)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	for _, bundle := range bundles {
+		if err := r.loadCourseIDs(ctx, bundle); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return bundles, total, nil
+}
+
+// Update updates a bundle and replaces its courses in one transaction
+func (r *courseBundleRepository) Update(ctx context.Context, bundle *domain.CourseBundle) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE course_bundles
+		SET name = $2, description = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`, bundle.ID, bundle.Name, bundle.Description).Scan(&bundle.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrCourseBundleNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM course_bundle_courses WHERE bundle_id = $1`, bundle.ID); err != nil {
+		return err
+	}
+	if err := insertBundleCourses(ctx, tx, bundle); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Delete deletes a bundle
+func (r *courseBundleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM course_bundles WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrCourseBundleNotFound
+	}
+
+	return nil
+}
+
+// IsInUse checks if activation codes or batches still unlock the bundle
+func (r *courseBundleRepository) IsInUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS(SELECT 1 FROM activation_codes WHERE bundle_id = $1)
+		    OR EXISTS(SELECT 1 FROM activation_code_batches WHERE bundle_id = $1)
+	`
+
+	var inUse bool
+	if err := r.db.QueryRow(ctx, query, id).Scan(&inUse); err != nil {
+		return false, err
+	}
+
+	return inUse, nil
+}
+
+// loadCourseIDs sets the course IDs of a bundle in display order
+func (r *courseBundleRepository) loadCourseIDs(ctx context.Context, bundle *domain.CourseBundle) error {
+	rows, err := r.db.Query(ctx, `
+		SELECT course_id FROM course_bundle_courses
+		WHERE bundle_id = $1
+		ORDER BY position
+	`, bundle.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	bundle.CourseIDs = []uuid.UUID{}
+	for rows.Next() {
+		var courseID uuid.UUID
+		if err := rows.Scan(&courseID); err != nil {
+			return err
+		}
+		bundle.CourseIDs = append(bundle.CourseIDs, courseID)
+	}
+
+	return rows.Err()
+}
+
+// insertBundleCourses records the courses of a bundle, keeping their order
+func insertBundleCourses(ctx context.Context, tx pgx.Tx, bundle *domain.CourseBundle) error {
+	for i, courseID := range bundle.CourseIDs {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO course_bundle_courses (bundle_id, course_id, position)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		`, bundle.ID, courseID, i)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -16,7 +16,7 @@ import (
 
 // courseRepository implements repository.CourseRepository
 type courseRepository struct {
-	db *pgxpool.Pool
+	db dbtx
 }
 
 // NewCourseRepository creates a new PostgreSQL course repository
@@ -293,6 +293,11 @@ func (r *courseRepository) List(ctx context.Context, filter *domain.CourseFilter
 			args = append(args, *filter.InstructorID)
 			argIndex++
 		}
+		if filter.StaffUserID != nil {
+			conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM course_staff cs WHERE cs.course_id = c.id AND cs.user_id = $%d)", argIndex))
+			args = append(args, *filter.StaffUserID)
+			argIndex++
+		}
 		if filter.IsFeatured != nil {
 			conditions = append(conditions, fmt.Sprintf("c.is_featured = $%d", argIndex))
 			args = append(args, *filter.IsFeatured)
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// courseStaffRepository implements repository.CourseStaffRepository
+type courseStaffRepository struct {
+	db dbtx
+}
+
+// NewCourseStaffRepository creates a new PostgreSQL course staff repository
+func NewCourseStaffRepository(db *pgxpool.Pool) repository.CourseStaffRepository {
+	return &courseStaffRepository{db: db}
+}
+
+// ListByCourse retrieves the staff of a course, owner first
+func (r *courseStaffRepository) ListByCourse(ctx context.Context, courseID uuid.UUID) ([]*domain.CourseStaff, error) {
+	query := `
+		SELECT cs.course_id, cs.user_id, cs.role, u.full_name, u.avatar, cs.created_at, cs.updated_at
+		FROM course_staff cs
+		JOIN users u ON u.id = cs.user_id
+		WHERE cs.course_id = $1
+		ORDER BY CASE cs.role WHEN 'owner' THEN 0 WHEN 'co_instructor' THEN 1 ELSE 2 END, cs.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	staff := []*domain.CourseStaff{}
+	for rows.Next() {
+		member := &domain.CourseStaff{}
+		if err := rows.Scan(
+			&member.CourseID,
+			&member.UserID,
+			&member.Role,
+			&member.FullName,
+			&member.Avatar,
+			&member.CreatedAt,
+			&member.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		staff = append(staff, member)
+	}
+
+	return staff, rows.Err()
+}
+
+// GetRole retrieves the role a user holds in a course
+func (r *courseStaffRepository) GetRole(ctx context.Context, courseID, userID uuid.UUID) (domain.CourseStaffRole, error) {
+	query := `SELECT role FROM course_staff WHERE course_id = $1 AND user_id = $2`
+
+	var role domain.CourseStaffRole
+	err := r.db.QueryRow(ctx, query, courseID, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrCourseStaffNotFound
+		}
+		return "", err
+	}
+
+	return role, nil
+}
+
+// Upsert adds a user to the staff of a course or changes their role
+func (r *courseStaffRepository) Upsert(ctx context.Context, staff *domain.CourseStaff) error {
+	query := `
+		INSERT INTO course_staff (course_id, user_id, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (course_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+		RETURNING created_at, updated_at
+	`
+
+	return r.db.QueryRow(ctx, query, staff.CourseID, staff.UserID, staff.Role, time.Now()).
+		Scan(&staff.CreatedAt, &staff.UpdatedAt)
+}
+
+// Delete removes a user from the staff of a course
+func (r *courseStaffRepository) Delete(ctx context.Context, courseID, userID uuid.UUID) error {
+	query := `DELETE FROM course_staff WHERE course_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(ctx, query, courseID, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrCourseStaffNotFound
+	}
+
+	return nil
+}
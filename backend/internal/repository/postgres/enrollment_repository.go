@@ -13,7 +13,7 @@ import (
 
 // enrollmentRepository implements repository.EnrollmentRepository
 type enrollmentRepository struct {
-	db *pgxpool.Pool
+	db dbtx
 }
 
 // NewEnrollmentRepository creates a new enrollment repository
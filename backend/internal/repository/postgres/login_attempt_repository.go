@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// loginAttemptRepository implements repository.LoginAttemptRepository
+type loginAttemptRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewLoginAttemptRepository creates a new PostgreSQL login attempt repository
+func NewLoginAttemptRepository(db *pgxpool.Pool) repository.LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+// Get retrieves the attempts for a key
+func (r *loginAttemptRepository) Get(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	query := `
+		SELECT attempt_key, failed_count, last_failed_at, locked_until
+		FROM login_attempts
+		WHERE attempt_key = $1
+	`
+
+	attempt := &domain.LoginAttempt{}
+	err := r.db.QueryRow(ctx, query, key).Scan(
+		&attempt.Key,
+		&attempt.FailedCount,
+		&attempt.LastFailedAt,
+		&attempt.LockedUntil,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &domain.LoginAttempt{Key: key}, nil
+	}
+
+	return attempt, err
+}
+
+// RegisterFailure atomically counts a failed login and returns the updated record
+func (r *loginAttemptRepository) RegisterFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempt, error) {
+	query := `
+		INSERT INTO login_attempts (attempt_key, failed_count, last_failed_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (attempt_key) DO UPDATE SET
+			failed_count = CASE
+				WHEN login_attempts.last_failed_at < $3
+					AND (login_attempts.locked_until IS NULL OR login_attempts.locked_until < $2)
+				THEN 1
+				ELSE login_attempts.failed_count + 1
+			END,
+			last_failed_at = $2
+		RETURNING attempt_key, failed_count, last_failed_at, locked_until
+	`
+
+	now := time.Now()
+	attempt := &domain.LoginAttempt{}
+	err := r.db.QueryRow(ctx, query, key, now, now.Add(-window)).Scan(
+		&attempt.Key,
+		&attempt.FailedCount,
+		&attempt.LastFailedAt,
+		&attempt.LockedUntil,
+	)
+
+	return attempt, err
+}
+
+// RemoveFailure takes back one failure counted by RegisterFailure
+func (r *loginAttemptRepository) RemoveFailure(ctx context.Context, key string) error {
+	query := `
+		UPDATE login_attempts
+		SET failed_count = failed_count - 1
+		WHERE attempt_key = $1 AND failed_count > 0
+	`
+
+	_, err := r.db.Exec(ctx, query, key)
+	return err
+}
+
+// Lock blocks logins for a key until the given time
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	query := `
+		UPDATE login_attempts
+		SET locked_until = $2
+		WHERE attempt_key = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, key, until)
+	return err
+}
+
+// Reset clears the failures and lock of a key
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM login_attempts
+		WHERE attempt_key = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, key)
+	return err
+}
+
+// ListLocked retrieves the keys starting with prefix that are locked now, latest lock end first
+func (r *loginAttemptRepository) ListLocked(ctx context.Context, prefix string, limit, offset int) ([]*domain.LoginAttempt, int, error) {
+	now := time.Now()
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM login_attempts WHERE attempt_key LIKE $1 || '%' AND locked_until > $2`
+	if err := r.db.QueryRow(ctx, countQuery, prefix, now).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT attempt_key, failed_count, last_failed_at, locked_until
+		FROM login_attempts
+		WHERE attempt_key LIKE $1 || '%' AND locked_until > $2
+		ORDER BY locked_until DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(ctx, query, prefix, now, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	attempts := []*domain.LoginAttempt{}
+	for rows.Next() {
+		attempt := &domain.LoginAttempt{}
+		if err := rows.Scan(
+			&attempt.Key,
+			&attempt.FailedCount,
+			&attempt.LastFailedAt,
+			&attempt.LockedUntil,
+		); err != nil {
+			return nil, 0, err
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, total, rows.Err()
+}
+
+// DeleteStale deletes unlocked records whose last failure is before the given time
+func (r *loginAttemptRepository) DeleteStale(ctx context.Context, before time.Time) error {
+	query := `
+		DELETE FROM login_attempts
+		WHERE last_failed_at < $1 AND (locked_until IS NULL OR locked_until < $2)
+	`
+
+	_, err := r.db.Exec(ctx, query, before, time.Now())
+	return err
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// loginOTPRepository implements repository.LoginOTPRepository
+type loginOTPRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewLoginOTPRepository creates a new PostgreSQL passwordless login code repository
+func NewLoginOTPRepository(db *pgxpool.Pool) repository.LoginOTPRepository {
+	return &loginOTPRepository{db: db}
+}
+
+// Create creates a new login code in the database
+func (r *loginOTPRepository) Create(ctx context.Context, otp *domain.LoginOTP) error {
+	query := `
+		INSERT INTO login_otps (id, phone_number, user_id, code_hash, attempts, expires_at, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	if otp.ID == uuid.Nil {
+		otp.ID = uuid.New()
+	}
+	if otp.CreatedAt.IsZero() {
+		otp.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		otp.ID,
+		otp.PhoneNumber,
+		otp.UserID,
+		otp.CodeHash,
+		otp.Attempts,
+		otp.ExpiresAt,
+		otp.UsedAt,
+		otp.CreatedAt,
+	)
+
+	return err
+}
+
+// GetLatestByPhone retrieves the most recently issued code for a phone number
+func (r *loginOTPRepository) GetLatestByPhone(ctx context.Context, phoneNumber string) (*domain.LoginOTP, error) {
+	query := `
+		SELECT id, phone_number, user_id, code_hash, attempts, expires_at, used_at, created_at
+		FROM login_otps
+		WHERE phone_number = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	otp := &domain.LoginOTP{}
+	err := r.db.QueryRow(ctx, query, phoneNumber).Scan(
+		&otp.ID,
+		&otp.PhoneNumber,
+		&otp.UserID,
+		&otp.CodeHash,
+		&otp.Attempts,
+		&otp.ExpiresAt,
+		&otp.UsedAt,
+		&otp.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInvalidLoginOTP
+	}
+
+	return otp, err
+}
+
+// CountSince counts the codes issued for a phone number since a point in time
+func (r *loginOTPRepository) CountSince(ctx context.Context, phoneNumber string, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM login_otps WHERE phone_number = $1 AND created_at >= $2`
+
+	var count int
+	err := r.db.QueryRow(ctx, query, phoneNumber, since).Scan(&count)
+	return count, err
+}
+
+// IncrementAttempts counts one guess of a code and returns the new attempt count.
+// The limit check and the increment are one statement, so concurrent guesses cannot
+// all read the old count and exceed the limit.
+func (r *loginOTPRepository) IncrementAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error) {
+	query := `
+		UPDATE login_otps
+		SET attempts = attempts + 1
+		WHERE id = $1 AND attempts < $2 AND used_at IS NULL
+		RETURNING attempts
+	`
+
+	var attempts int
+	err := r.db.QueryRow(ctx, query, id, maxAttempts).Scan(&attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrLoginOTPTooManyAttempts
+	}
+
+	return attempts, err
+}
+
+// MarkUsed marks a code as used, failing if it was already used
+func (r *loginOTPRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE login_otps
+		SET used_at = $2
+		WHERE id = $1 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrInvalidLoginOTP
+	}
+
+	return nil
+}
+
+// InvalidateAllForPhone marks all unused codes of a phone number as used
+func (r *loginOTPRepository) InvalidateAllForPhone(ctx context.Context, phoneNumber string) error {
+	query := `
+		UPDATE login_otps
+		SET used_at = $2
+		WHERE phone_number = $1 AND used_at IS NULL
+	`
+
+	_, err := r.db.Exec(ctx, query, phoneNumber, time.Now())
+	return err
+}
+
+// DeleteCreatedBefore deletes codes issued before a point in time
+func (r *loginOTPRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) error {
+	query := `
+		DELETE FROM login_otps
+		WHERE created_at < $1
+	`
+
+	_, err := r.db.Exec(ctx, query, before)
+	return err
+}
@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// parentLinkRepository implements repository.ParentLinkRepository
+type parentLinkRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewParentLinkRepository creates a new PostgreSQL parent link repository
+func NewParentLinkRepository(db *pgxpool.Pool) repository.ParentLinkRepository {
+	return &parentLinkRepository{db: db}
+}
+
+// Create creates a pending link
+func (r *parentLinkRepository) Create(ctx context.Context, link *domain.ParentLink) error {
+	query := `
+		INSERT INTO parent_links (id, parent_id, student_id, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (parent_id, student_id) DO NOTHING
+	`
+
+	link.ID = uuid.New()
+	now := time.Now()
+	link.CreatedAt = now
+	link.UpdatedAt = now
+	link.Status = domain.ParentLinkPending
+
+	result, err := r.db.Exec(ctx, query,
+		link.ID,
+		link.ParentID,
+		link.StudentID,
+		link.Status,
+		link.CreatedAt,
+		link.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrParentLinkExists
+	}
+
+	return nil
+}
+
+// GetByID retrieves a link by ID
+func (r *parentLinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ParentLink, error) {
+	query := `
+		SELECT id, parent_id, student_id, status, accepted_at, created_at, updated_at
+		FROM parent_links
+		WHERE id = $1
+	`
+
+	link := &domain.ParentLink{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&link.ID,
+		&link.ParentID,
+		&link.StudentID,
+		&link.Status,
+		&link.AcceptedAt,
+		&link.CreatedAt,
+		&link.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrParentLinkNotFound
+		}
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// GetByParentAndStudent retrieves the link between a parent and a student
+func (r *parentLinkRepository) GetByParentAndStudent(ctx context.Context, parentID, studentID uuid.UUID) (*domain.ParentLink, error) {
+	query := `
+		SELECT id, parent_id, student_id, status, accepted_at, created_at, updated_at
+		FROM parent_links
+		WHERE parent_id = $1 AND student_id = $2
+	`
+
+	link := &domain.ParentLink{}
+	err := r.db.QueryRow(ctx, query, parentID, studentID).Scan(
+		&link.ID,
+		&link.ParentID,
+		&link.StudentID,
+		&link.Status,
+		&link.AcceptedAt,
+		&link.CreatedAt,
+		&link.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrParentLinkNotFound
+		}
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// ListAcceptedByParent retrieves the accepted links of a parent with the student profiles
+func (r *parentLinkRepository) ListAcceptedByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.ParentLink, error) {
+	query := `
+		SELECT pl.id, pl.parent_id, pl.student_id, pl.status, pl.accepted_at, pl.created_at, pl.updated_at,
+		       u.id, u.full_name, u.email, u.avatar
+		FROM parent_links pl
+		JOIN users u ON u.id = pl.student_id
+		WHERE pl.parent_id = $1 AND pl.status = 'accepted'
+		ORDER BY pl.accepted_at
+	`
+
+	rows, err := r.db.Query(ctx, query, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*domain.ParentLink{}
+	for rows.Next() {
+		link := &domain.ParentLink{Student: &domain.LinkedUser{}}
+		if err := rows.Scan(
+			&link.ID,
+			&link.ParentID,
+			&link.StudentID,
+			&link.Status,
+			&link.AcceptedAt,
+			&link.CreatedAt,
+			&link.UpdatedAt,
+			&link.Student.ID,
+			&link.Student.FullName,
+			&link.Student.Email,
+			&link.Student.Avatar,
+		); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// ListByStudent retrieves the links of a student with the parent profiles
+func (r *parentLinkRepository) ListByStudent(ctx context.Context, studentID uuid.UUID) ([]*domain.ParentLink, error) {
+	query := `
+		SELECT pl.id, pl.parent_id, pl.student_id, pl.status, pl.accepted_at, pl.created_at, pl.updated_at,
+		       u.id, u.full_name, u.email, u.avatar
+		FROM parent_links pl
+		JOIN users u ON u.id = pl.parent_id
+		WHERE pl.student_id = $1
+		ORDER BY pl.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*domain.ParentLink{}
+	for rows.Next() {
+		link := &domain.ParentLink{Parent: &domain.LinkedUser{}}
+		if err := rows.Scan(
+			&link.ID,
+			&link.ParentID,
+			&link.StudentID,
+			&link.Status,
+			&link.AcceptedAt,
+			&link.CreatedAt,
+			&link.UpdatedAt,
+			&link.Parent.ID,
+			&link.Parent.FullName,
+			&link.Parent.Email,
+			&link.Parent.Avatar,
+		); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// Accept marks a pending link as accepted
+func (r *parentLinkRepository) Accept(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE parent_links
+		SET status = 'accepted', accepted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrParentLinkNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a link
+func (r *parentLinkRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM parent_links WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrParentLinkNotFound
+	}
+
+	return nil
+}
+
+// DeleteByUserID removes every link a user is the parent or the student of
+func (r *parentLinkRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM parent_links WHERE parent_id = $1 OR student_id = $1`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
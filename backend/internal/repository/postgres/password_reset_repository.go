@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// passwordResetTokenRepository implements repository.PasswordResetTokenRepository
+type passwordResetTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPasswordResetTokenRepository creates a new PostgreSQL password reset token repository
+func NewPasswordResetTokenRepository(db *pgxpool.Pool) repository.PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{db: db}
+}
+
+// Create creates a new password reset token in the database
+func (r *passwordResetTokenRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (id, user_id, channel, token_hash, attempts, expires_at, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Channel,
+		token.TokenHash,
+		token.Attempts,
+		token.ExpiresAt,
+		token.UsedAt,
+		token.CreatedAt,
+	)
+
+	return err
+}
+
+// GetByTokenHash retrieves a password reset token by its hash
+func (r *passwordResetTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, channel, token_hash, attempts, expires_at, used_at, created_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1
+	`
+
+	token := &domain.PasswordResetToken{}
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Channel,
+		&token.TokenHash,
+		&token.Attempts,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInvalidResetToken
+	}
+
+	return token, err
+}
+
+// GetLatestByUserID retrieves the most recently issued token for a user
+func (r *passwordResetTokenRepository) GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, channel, token_hash, attempts, expires_at, used_at, created_at
+		FROM password_reset_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	token := &domain.PasswordResetToken{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Channel,
+		&token.TokenHash,
+		&token.Attempts,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInvalidResetToken
+	}
+
+	return token, err
+}
+
+// IncrementAttempts counts one guess of a token and returns the new attempt count.
+// The limit check and the increment are one statement, so concurrent guesses cannot
+// all read the old count and exceed the limit.
+func (r *passwordResetTokenRepository) IncrementAttempts(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error) {
+	query := `
+		UPDATE password_reset_tokens
+		SET attempts = attempts + 1
+		WHERE id = $1 AND attempts < $2 AND used_at IS NULL
+		RETURNING attempts
+	`
+
+	var attempts int
+	err := r.db.QueryRow(ctx, query, id, maxAttempts).Scan(&attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrResetTooManyAttempts
+	}
+
+	return attempts, err
+}
+
+// MarkUsed marks a token as used, failing if it was already used
+func (r *passwordResetTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = $2
+		WHERE id = $1 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrInvalidResetToken
+	}
+
+	return nil
+}
+
+// InvalidateAllForUser marks all unused tokens of a user as used
+func (r *passwordResetTokenRepository) InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = $2
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, time.Now())
+	return err
+}
+
+// DeleteExpired deletes expired password reset tokens
+func (r *passwordResetTokenRepository) DeleteExpired(ctx context.Context) error {
+	query := `
+		DELETE FROM password_reset_tokens
+		WHERE expires_at < $1
+	`
+
+	_, err := r.db.Exec(ctx, query, time.Now())
+	return err
+}
@@ -94,6 +94,46 @@ func (r *progressRepository) GetByUserAndCourse(ctx context.Context, userID, cou
 	return progressList, nil
 }
 
+// GetByUserID gets the progress of a user in every course
+func (r *progressRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.LessonProgress, error) {
+	query := `
+		SELECT id, user_id, course_id, lesson_id, is_completed, completed_at,
+		       last_watched_at, watch_duration_seconds, created_at, updated_at
+		FROM lesson_progress
+		WHERE user_id = $1
+		ORDER BY course_id, last_watched_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progressList := []*domain.LessonProgress{}
+	for rows.Next() {
+		progress := &domain.LessonProgress{}
+		err := rows.Scan(
+			&progress.ID,
+			&progress.UserID,
+			&progress.CourseID,
+			&progress.LessonID,
+			&progress.IsCompleted,
+			&progress.CompletedAt,
+			&progress.LastWatchedAt,
+			&progress.WatchDurationSeconds,
+			&progress.CreatedAt,
+			&progress.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		progressList = append(progressList, progress)
+	}
+
+	return progressList, rows.Err()
+}
+
 // GetCourseProgress gets aggregated progress stats for a course
 func (r *progressRepository) GetCourseProgress(ctx context.Context, userID, courseID uuid.UUID) (*domain.CourseProgress, error) {
 	// Get total lessons count
@@ -212,3 +252,56 @@ func (r *progressRepository) GetLastLesson(ctx context.Context, userID, courseID
 	}
 	return lastLessonID, nil
 }
+
+// ListStudentProgress gets the progress of every student enrolled in a course with pagination
+func (r *progressRepository) ListStudentProgress(ctx context.Context, courseID uuid.UUID, limit, offset int) ([]*domain.StudentProgress, int, error) {
+	countQuery := `SELECT COUNT(*) FROM course_enrollments WHERE course_id = $1`
+	var total int
+	if err := r.db.QueryRow(ctx, countQuery, courseID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT u.id, u.full_name, u.email, e.status, e.enrolled_at, e.expires_at, c.total_lessons,
+			COUNT(lp.id) FILTER (WHERE lp.is_completed) AS completed_lessons,
+			MAX(lp.last_watched_at) AS last_watched_at
+		FROM course_enrollments e
+		JOIN users u ON u.id = e.user_id
+		JOIN courses c ON c.id = e.course_id
+		LEFT JOIN lesson_progress lp ON lp.user_id = e.user_id AND lp.course_id = e.course_id
+		WHERE e.course_id = $1
+		GROUP BY u.id, e.id, c.id
+		ORDER BY e.enrolled_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, courseID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	students := []*domain.StudentProgress{}
+	for rows.Next() {
+		student := &domain.StudentProgress{}
+		if err := rows.Scan(
+			&student.UserID,
+			&student.FullName,
+			&student.Email,
+			&student.EnrollmentStatus,
+			&student.EnrolledAt,
+			&student.ExpiresAt,
+			&student.TotalLessons,
+			&student.CompletedLessons,
+			&student.LastWatchedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		if student.TotalLessons > 0 {
+			student.ProgressPercent = student.CompletedLessons * 100 / student.TotalLessons
+		}
+		students = append(students, student)
+	}
+
+	return students, total, rows.Err()
+}
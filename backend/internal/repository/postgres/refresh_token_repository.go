@@ -25,25 +25,38 @@ func NewRefreshTokenRepository(db *pgxpool.Pool) repository.RefreshTokenReposito
 // Create creates a new refresh token in the database
 func (r *refreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
 	query := `
-		INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at, is_revoked, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO refresh_tokens (id, user_id, token_hash, family_id, replaced_by, device_name, user_agent, ip_address, last_used_at, mfa_verified, expires_at, is_revoked, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
 	now := time.Now()
 	if token.ID == uuid.Nil {
 		token.ID = uuid.New()
 	}
+	if token.FamilyID == uuid.Nil {
+		token.FamilyID = token.ID
+	}
 	if token.CreatedAt.IsZero() {
 		token.CreatedAt = now
 	}
 	if token.UpdatedAt.IsZero() {
 		token.UpdatedAt = now
 	}
+	if token.LastUsedAt.IsZero() {
+		token.LastUsedAt = now
+	}
 
 	_, err := r.db.Exec(ctx, query,
 		token.ID,
 		token.UserID,
 		token.TokenHash,
+		token.FamilyID,
+		token.ReplacedBy,
+		token.DeviceName,
+		token.UserAgent,
+		token.IPAddress,
+		token.LastUsedAt,
+		token.MFAVerified,
 		token.ExpiresAt,
 		token.IsRevoked,
 		token.CreatedAt,
@@ -53,10 +66,98 @@ func (r *refreshTokenRepository) Create(ctx context.Context, token *domain.Refre
 	return err
 }
 
+// CreateWithinLimit creates a refresh token for a new session while enforcing the session limit
+func (r *refreshTokenRepository) CreateWithinLimit(ctx context.Context, token *domain.RefreshToken, maxSessions int, evictOldest bool) error {
+	if maxSessions <= 0 {
+		return r.Create(ctx, token)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the user so concurrent logins are counted one after another
+	if _, err := tx.Exec(ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, token.UserID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	var active int
+	countQuery := `
+		SELECT COUNT(*)
+		FROM refresh_tokens
+		WHERE user_id = $1 AND is_revoked = false AND expires_at > $2
+	`
+	if err := tx.QueryRow(ctx, countQuery, token.UserID, now).Scan(&active); err != nil {
+		return err
+	}
+
+	if active >= maxSessions {
+		if !evictOldest {
+			return domain.ErrSessionLimitReached
+		}
+
+		// Keep the most recently used sessions and leave room for the new one
+		evictQuery := `
+			UPDATE refresh_tokens
+			SET is_revoked = true, updated_at = $3
+			WHERE user_id = $1 AND is_revoked = false AND expires_at > $3
+				AND family_id NOT IN (
+					SELECT family_id
+					FROM refresh_tokens
+					WHERE user_id = $1 AND is_revoked = false AND expires_at > $3
+					ORDER BY last_used_at DESC
+					LIMIT $2
+				)
+		`
+		if _, err := tx.Exec(ctx, evictQuery, token.UserID, maxSessions-1, now); err != nil {
+			return err
+		}
+	}
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	if token.FamilyID == uuid.Nil {
+		token.FamilyID = token.ID
+	}
+	token.CreatedAt = now
+	token.UpdatedAt = now
+	token.LastUsedAt = now
+
+	insertQuery := `
+		INSERT INTO refresh_tokens (id, user_id, token_hash, family_id, replaced_by, device_name, user_agent, ip_address, last_used_at, mfa_verified, expires_at, is_revoked, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	if _, err := tx.Exec(ctx, insertQuery,
+		token.ID,
+		token.UserID,
+		token.TokenHash,
+		token.FamilyID,
+		token.ReplacedBy,
+		token.DeviceName,
+		token.UserAgent,
+		token.IPAddress,
+		token.LastUsedAt,
+		token.MFAVerified,
+		token.ExpiresAt,
+		token.IsRevoked,
+		token.CreatedAt,
+		token.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
 // GetByTokenHash retrieves a refresh token by its hash
 func (r *refreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
 	query := `
-		SELECT id, user_id, token_hash, expires_at, is_revoked, created_at, updated_at
+		SELECT id, user_id, token_hash, family_id, replaced_by, device_name, user_agent, ip_address, last_used_at, mfa_verified, expires_at, is_revoked, created_at, updated_at
 		FROM refresh_tokens
 		WHERE token_hash = $1
 	`
@@ -66,6 +167,13 @@ func (r *refreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash s
 		&token.ID,
 		&token.UserID,
 		&token.TokenHash,
+		&token.FamilyID,
+		&token.ReplacedBy,
+		&token.DeviceName,
+		&token.UserAgent,
+		&token.IPAddress,
+		&token.LastUsedAt,
+		&token.MFAVerified,
 		&token.ExpiresAt,
 		&token.IsRevoked,
 		&token.CreatedAt,
@@ -82,7 +190,7 @@ func (r *refreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash s
 // GetByUserID retrieves all refresh tokens for a user
 func (r *refreshTokenRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.RefreshToken, error) {
 	query := `
-		SELECT id, user_id, token_hash, expires_at, is_revoked, created_at, updated_at
+		SELECT id, user_id, token_hash, family_id, replaced_by, device_name, user_agent, ip_address, last_used_at, mfa_verified, expires_at, is_revoked, created_at, updated_at
 		FROM refresh_tokens
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -101,6 +209,13 @@ func (r *refreshTokenRepository) GetByUserID(ctx context.Context, userID uuid.UU
 			&token.ID,
 			&token.UserID,
 			&token.TokenHash,
+			&token.FamilyID,
+			&token.ReplacedBy,
+			&token.DeviceName,
+			&token.UserAgent,
+			&token.IPAddress,
+			&token.LastUsedAt,
+			&token.MFAVerified,
 			&token.ExpiresAt,
 			&token.IsRevoked,
 			&token.CreatedAt,
@@ -134,6 +249,133 @@ func (r *refreshTokenRepository) Revoke(ctx context.Context, tokenHash string) e
 	return nil
 }
 
+// Rotate revokes the current token and stores its replacement in one transaction
+func (r *refreshTokenRepository) Rotate(ctx context.Context, currentID uuid.UUID, next *domain.RefreshToken) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	if next.ID == uuid.Nil {
+		next.ID = uuid.New()
+	}
+	next.CreatedAt = now
+	next.UpdatedAt = now
+	next.LastUsedAt = now
+
+	insertQuery := `
+		INSERT INTO refresh_tokens (id, user_id, token_hash, family_id, replaced_by, device_name, user_agent, ip_address, last_used_at, mfa_verified, expires_at, is_revoked, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	if _, err := tx.Exec(ctx, insertQuery,
+		next.ID,
+		next.UserID,
+		next.TokenHash,
+		next.FamilyID,
+		next.ReplacedBy,
+		next.DeviceName,
+		next.UserAgent,
+		next.IPAddress,
+		next.LastUsedAt,
+		next.MFAVerified,
+		next.ExpiresAt,
+		next.IsRevoked,
+		next.CreatedAt,
+		next.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	// Only a token that is still active can be rotated, a concurrent rotation loses here
+	revokeQuery := `
+		UPDATE refresh_tokens
+		SET is_revoked = true, replaced_by = $2, updated_at = $3
+		WHERE id = $1 AND is_revoked = false
+	`
+	result, err := tx.Exec(ctx, revokeQuery, currentID, next.ID, now)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrRefreshTokenRevoked
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RevokeFamily revokes all refresh tokens of a rotation family
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET is_revoked = true, updated_at = $2
+		WHERE family_id = $1 AND is_revoked = false
+	`
+
+	_, err := r.db.Exec(ctx, query, familyID, time.Now())
+	return err
+}
+
+// ListActiveSessions lists the active sessions of a user, most recently used first
+func (r *refreshTokenRepository) ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	// Each family has at most one active token, the session started with the first token of the family
+	query := `
+		SELECT rt.family_id, rt.user_id, rt.device_name, rt.user_agent, rt.ip_address,
+			(SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = rt.family_id),
+			rt.last_used_at, rt.expires_at
+		FROM refresh_tokens rt
+		WHERE rt.user_id = $1 AND rt.is_revoked = false AND rt.expires_at > $2
+		ORDER BY rt.last_used_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*domain.Session{}
+	for rows.Next() {
+		session := &domain.Session{}
+		if err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.DeviceName,
+			&session.UserAgent,
+			&session.IPAddress,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+			&session.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+// RevokeSession revokes a session of a user
+func (r *refreshTokenRepository) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET is_revoked = true, updated_at = $3
+		WHERE family_id = $1 AND user_id = $2 AND is_revoked = false
+	`
+
+	result, err := r.db.Exec(ctx, query, sessionID, userID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
 // RevokeAllForUser revokes all refresh tokens for a user
 func (r *refreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
 	query := `
@@ -156,3 +398,11 @@ func (r *refreshTokenRepository) DeleteExpired(ctx context.Context) error {
 	_, err := r.db.Exec(ctx, query, time.Now())
 	return err
 }
+
+// DeleteAllForUser deletes every refresh token of a user, with its device details
+func (r *refreshTokenRepository) DeleteAllForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM refresh_tokens WHERE user_id = $1`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// rolePermissionRepository implements repository.RolePermissionRepository
+type rolePermissionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRolePermissionRepository creates a new PostgreSQL role permission repository
+func NewRolePermissionRepository(db *pgxpool.Pool) repository.RolePermissionRepository {
+	return &rolePermissionRepository{db: db}
+}
+
+// ListByRole retrieves the permissions granted to a role
+func (r *rolePermissionRepository) ListByRole(ctx context.Context, role domain.UserRole) ([]domain.Permission, error) {
+	query := `
+		SELECT permission
+		FROM role_permissions
+		WHERE role = $1
+		ORDER BY permission
+	`
+
+	rows, err := r.db.Query(ctx, query, string(role))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := []domain.Permission{}
+	for rows.Next() {
+		var permission domain.Permission
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, rows.Err()
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// securityEventRepository implements repository.SecurityEventRepository
+type securityEventRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSecurityEventRepository creates a new PostgreSQL security event repository
+func NewSecurityEventRepository(db *pgxpool.Pool) repository.SecurityEventRepository {
+	return &securityEventRepository{db: db}
+}
+
+// Create records a new security event
+func (r *securityEventRepository) Create(ctx context.Context, event *domain.SecurityEvent) error {
+	query := `
+		INSERT INTO security_events (id, user_id, event_type, ip_address, user_agent, metadata, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	if event.Metadata == nil {
+		event.Metadata = map[string]interface{}{}
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		event.ID,
+		event.UserID,
+		event.EventType,
+		event.IPAddress,
+		event.UserAgent,
+		event.Metadata,
+		event.CreatedAt,
+	)
+
+	return err
+}
+
+// ListByUserID retrieves security events of a user with pagination, newest first
+func (r *securityEventRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.SecurityEvent, int, error) {
+	countQuery := `SELECT COUNT(*) FROM security_events WHERE user_id = $1`
+	var total int
+	if err := r.db.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, user_id, event_type, ip_address, user_agent, metadata, created_at
+		FROM security_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events := []*domain.SecurityEvent{}
+	for rows.Next() {
+		event := &domain.SecurityEvent{}
+		if err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.EventType,
+			&event.IPAddress,
+			&event.UserAgent,
+			&event.Metadata,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+
+	return events, total, rows.Err()
+}
+
+// AnonymizeByUserID removes the IP address and user agent from the events of a user
+func (r *securityEventRepository) AnonymizeByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE security_events SET ip_address = NULL, user_agent = NULL WHERE user_id = $1`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// dbtx is what a repository needs from the database. Both the pool and a transaction
+// satisfy it, so the same repository code runs inside or outside a unit of work.
+// Begin on a transaction starts a savepoint.
+type dbtx interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// unitOfWork implements repository.UnitOfWork
+type unitOfWork struct {
+	db *pgxpool.Pool
+}
+
+// NewUnitOfWork creates a new unit of work
+func NewUnitOfWork(db *pgxpool.Pool) repository.UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do runs fn in a transaction, committing when fn returns nil
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos repository.TxRepositories) error) error {
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(&txRepositories{tx: tx}); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// txRepositories implements repository.TxRepositories for one transaction
+type txRepositories struct {
+	tx pgx.Tx
+}
+
+// Courses returns the course repository of the transaction
+func (r *txRepositories) Courses() repository.CourseRepository {
+	return &courseRepository{db: r.tx}
+}
+
+// CourseStaff returns the course staff repository of the transaction
+func (r *txRepositories) CourseStaff() repository.CourseStaffRepository {
+	return &courseStaffRepository{db: r.tx}
+}
+
+// ActivationCodes returns the activation code repository of the transaction
+func (r *txRepositories) ActivationCodes() repository.ActivationCodeRepository {
+	return &activationCodeRepository{db: r.tx}
+}
+
+// Enrollments returns the enrollment repository of the transaction
+func (r *txRepositories) Enrollments() repository.EnrollmentRepository {
+	return &enrollmentRepository{db: r.tx}
+}
+
+// CodeRedemptions returns the code redemption repository of the transaction
+func (r *txRepositories) CodeRedemptions() repository.CodeRedemptionRepository {
+	return &codeRedemptionRepository{db: r.tx}
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// userIdentityRepository implements repository.UserIdentityRepository
+type userIdentityRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewUserIdentityRepository creates a new PostgreSQL social login identity repository
+func NewUserIdentityRepository(db *pgxpool.Pool) repository.UserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+// GetByProviderSubject retrieves the identity of a provider account
+func (r *userIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, provider_subject, email, created_at, last_login_at
+		FROM user_identities
+		WHERE provider = $1 AND provider_subject = $2
+	`
+
+	identity := &domain.UserIdentity{}
+	err := r.db.QueryRow(ctx, query, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.ProviderSubject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrIdentityNotFound
+	}
+
+	return identity, err
+}
+
+// Create links a provider account to a user
+func (r *userIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, provider_subject, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (provider, provider_subject) DO NOTHING
+	`
+
+	now := time.Now()
+	identity.ID = uuid.New()
+	identity.CreatedAt = now
+	identity.LastLoginAt = now
+
+	result, err := r.db.Exec(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.ProviderSubject,
+		identity.Email,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrIdentityAlreadyLinked
+	}
+
+	return nil
+}
+
+// UpdateLastLogin records a login with the identity
+func (r *userIdentityRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE user_identities SET last_login_at = $2 WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, query, id, time.Now())
+	return err
+}
+
+// DeleteByUserID unlinks every social login of a user
+func (r *userIdentityRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM user_identities WHERE user_id = $1`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
@@ -54,7 +54,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 // GetByID retrieves a user by ID
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, full_name, avatar, phone_number, role, is_active, is_verified, created_at, updated_at
+		SELECT id, email, password_hash, full_name, avatar, phone_number, role, is_active, is_verified, max_sessions, token_version, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -70,6 +70,8 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 		&user.Role,
 		&user.IsActive,
 		&user.IsVerified,
+		&user.MaxSessions,
+		&user.TokenVersion,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -84,7 +86,7 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 // GetByEmail retrieves a user by email
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, full_name, avatar, phone_number, role, is_active, is_verified, created_at, updated_at
+		SELECT id, email, password_hash, full_name, avatar, phone_number, role, is_active, is_verified, max_sessions, token_version, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -100,6 +102,8 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 		&user.Role,
 		&user.IsActive,
 		&user.IsVerified,
+		&user.MaxSessions,
+		&user.TokenVersion,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -114,7 +118,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 // GetByPhoneNumber retrieves a user by phone number
 func (r *userRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, full_name, avatar, phone_number, role, is_active, is_verified, created_at, updated_at
+		SELECT id, email, password_hash, full_name, avatar, phone_number, role, is_active, is_verified, max_sessions, token_version, created_at, updated_at
 		FROM users
 		WHERE phone_number = $1
 	`
@@ -130,6 +134,8 @@ func (r *userRepository) GetByPhoneNumber(ctx context.Context, phoneNumber strin
 		&user.Role,
 		&user.IsActive,
 		&user.IsVerified,
+		&user.MaxSessions,
+		&user.TokenVersion,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
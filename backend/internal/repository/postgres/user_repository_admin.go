@@ -2,9 +2,11 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/mathvn/backend/internal/domain"
 )
 
@@ -14,7 +16,10 @@ func (r *userRepository) ListUsersWithPagination(ctx context.Context, page, limi
 
 	// Build query with optional filters
 	query := `
-		SELECT id, email, full_name, phone_number, role, is_active, is_verified, created_at, updated_at
+		SELECT id, email, full_name, phone_number, role, is_active, is_verified, max_sessions,
+			(SELECT COUNT(*) FROM refresh_tokens rt
+				WHERE rt.user_id = users.id AND rt.is_revoked = false AND rt.expires_at > NOW()) AS active_sessions,
+			created_at, updated_at
 		FROM users
 		WHERE 1=1
 	`
@@ -68,6 +73,8 @@ func (r *userRepository) ListUsersWithPagination(ctx context.Context, page, limi
 			&user.Role,
 			&user.IsActive,
 			&user.IsVerified,
+			&user.MaxSessions,
+			&user.ActiveSessions,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
@@ -93,7 +100,7 @@ func (r *userRepository) ListUsersWithPagination(ctx context.Context, page, limi
 
 // UpdateUserRole updates a user's role
 func (r *userRepository) UpdateUserRole(ctx context.Context, userID uuid.UUID, role domain.UserRole) error {
-	query := `UPDATE users SET role = $1, updated_at = NOW() WHERE id = $2`
+	query := `UPDATE users SET role = $1, token_version = token_version + 1, updated_at = NOW() WHERE id = $2`
 	_, err := r.db.Exec(ctx, query, string(role), userID)
 	return err
 }
@@ -102,7 +109,9 @@ func (r *userRepository) UpdateUserRole(ctx context.Context, userID uuid.UUID, r
 func (r *userRepository) AdminUpdateUser(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users 
-		SET full_name = $1, phone_number = $2, role = $3, is_active = $4, is_verified = $5, updated_at = NOW() 
+		SET full_name = $1, phone_number = $2, role = $3, is_active = $4, is_verified = $5,
+			token_version = CASE WHEN role <> $3::user_role OR is_active <> $4 THEN token_version + 1 ELSE token_version END,
+			updated_at = NOW()
 		WHERE id = $6
 	`
 	_, err := r.db.Exec(ctx, query,
@@ -118,7 +127,42 @@ func (r *userRepository) AdminUpdateUser(ctx context.Context, user *domain.User)
 
 // ToggleUserStatus activates or deactivates a user
 func (r *userRepository) ToggleUserStatus(ctx context.Context, userID uuid.UUID, isActive bool) error {
-	query := `UPDATE users SET is_active = $1, updated_at = NOW() WHERE id = $2`
+	query := `UPDATE users SET is_active = $1, token_version = token_version + 1, updated_at = NOW() WHERE id = $2`
 	_, err := r.db.Exec(ctx, query, isActive, userID)
 	return err
 }
+
+// UpdateMaxSessions sets or clears a user's concurrent device limit
+func (r *userRepository) UpdateMaxSessions(ctx context.Context, userID uuid.UUID, maxSessions *int) error {
+	query := `UPDATE users SET max_sessions = $1, updated_at = NOW() WHERE id = $2`
+	result, err := r.db.Exec(ctx, query, maxSessions, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// GetTokenVersion retrieves the current access token version of a user
+func (r *userRepository) GetTokenVersion(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT token_version FROM users WHERE id = $1`
+
+	var version int
+	err := r.db.QueryRow(ctx, query, userID).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrUserNotFound
+	}
+
+	return version, err
+}
+
+// IncrementTokenVersion invalidates every access token issued to a user
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE users SET token_version = token_version + 1, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
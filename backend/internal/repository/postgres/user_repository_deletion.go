@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// ScheduleDeletion marks the account for anonymization at the given time
+func (r *userRepository) ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	query := `
+		UPDATE users
+		SET deletion_scheduled_at = $2, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, at)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// CancelDeletion clears a pending deletion, reporting whether one was pending
+func (r *userRepository) CancelDeletion(ctx context.Context, userID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE users
+		SET deletion_scheduled_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deletion_scheduled_at IS NOT NULL AND deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ListDueForDeletion returns accounts whose deletion is scheduled at or before the given time
+func (r *userRepository) ListDueForDeletion(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT id FROM users
+		WHERE deletion_scheduled_at <= $1 AND deleted_at IS NULL
+		ORDER BY deletion_scheduled_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// Anonymize replaces the personal data of an account and deactivates it, keeping the row
+// so enrollments, progress and statistics stay intact
+func (r *userRepository) Anonymize(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET email = 'deleted-' || id::text || '@deleted.invalid',
+			full_name = 'Người dùng đã xóa',
+			phone_number = '',
+			avatar = NULL,
+			password_hash = '',
+			is_active = false,
+			is_verified = false,
+			max_sessions = NULL,
+			token_version = token_version + 1,
+			deletion_scheduled_at = NULL,
+			deleted_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// emailVerificationTokenRepository implements repository.EmailVerificationTokenRepository
+type emailVerificationTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewEmailVerificationTokenRepository creates a new PostgreSQL email verification token repository
+func NewEmailVerificationTokenRepository(db *pgxpool.Pool) repository.EmailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{db: db}
+}
+
+// Create creates a new verification token in the database
+func (r *emailVerificationTokenRepository) Create(ctx context.Context, token *domain.EmailVerificationToken) error {
+	query := `
+		INSERT INTO email_verification_tokens (id, user_id, email, token_hash, expires_at, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Email,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.UsedAt,
+		token.CreatedAt,
+	)
+
+	return err
+}
+
+// GetByTokenHash retrieves a verification token by its hash
+func (r *emailVerificationTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error) {
+	query := `
+		SELECT id, user_id, email, token_hash, expires_at, used_at, created_at
+		FROM email_verification_tokens
+		WHERE token_hash = $1
+	`
+
+	token := &domain.EmailVerificationToken{}
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Email,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInvalidVerificationToken
+	}
+
+	return token, err
+}
+
+// GetLatestByUserID retrieves the most recently issued token for a user
+func (r *emailVerificationTokenRepository) GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerificationToken, error) {
+	query := `
+		SELECT id, user_id, email, token_hash, expires_at, used_at, created_at
+		FROM email_verification_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	token := &domain.EmailVerificationToken{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Email,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInvalidVerificationToken
+	}
+
+	return token, err
+}
+
+// MarkUsed marks a token as used, failing if it was already used
+func (r *emailVerificationTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE email_verification_tokens
+		SET used_at = $2
+		WHERE id = $1 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrInvalidVerificationToken
+	}
+
+	return nil
+}
+
+// InvalidateAllForUser marks all unused tokens of a user as used
+func (r *emailVerificationTokenRepository) InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE email_verification_tokens
+		SET used_at = $2
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, time.Now())
+	return err
+}
+
+// DeleteExpired deletes expired verification tokens
+func (r *emailVerificationTokenRepository) DeleteExpired(ctx context.Context) error {
+	query := `
+		DELETE FROM email_verification_tokens
+		WHERE expires_at < $1
+	`
+
+	_, err := r.db.Exec(ctx, query, time.Now())
+	return err
+}
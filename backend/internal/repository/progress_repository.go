@@ -29,4 +29,10 @@ type ProgressRepository interface {
 
 	// GetLastLesson gets the last lesson the user was watching
 	GetLastLesson(ctx context.Context, userID, courseID uuid.UUID) (*uuid.UUID, error)
+
+	// GetByUserID gets the progress of a user in every course
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.LessonProgress, error)
+
+	// ListStudentProgress gets the progress of every student enrolled in a course with pagination
+	ListStudentProgress(ctx context.Context, courseID uuid.UUID, limit, offset int) ([]*domain.StudentProgress, int, error)
 }
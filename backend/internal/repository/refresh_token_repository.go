@@ -12,6 +12,11 @@ type RefreshTokenRepository interface {
 	// Create creates a new refresh token
 	Create(ctx context.Context, token *domain.RefreshToken) error
 
+	// CreateWithinLimit creates a refresh token for a new session while keeping the user
+	// under maxSessions active sessions (0 for unlimited). When the limit is reached it evicts
+	// the least recently used sessions if evictOldest is set, or fails with ErrSessionLimitReached.
+	CreateWithinLimit(ctx context.Context, token *domain.RefreshToken, maxSessions int, evictOldest bool) error
+
 	// GetByTokenHash retrieves a refresh token by its hash
 	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
 
@@ -21,9 +26,26 @@ type RefreshTokenRepository interface {
 	// Revoke revokes a refresh token by its hash
 	Revoke(ctx context.Context, tokenHash string) error
 
+	// Rotate revokes the current token and stores its replacement in one transaction.
+	// It fails with ErrRefreshTokenRevoked if the current token was already revoked.
+	Rotate(ctx context.Context, currentID uuid.UUID, next *domain.RefreshToken) error
+
+	// RevokeFamily revokes all refresh tokens of a rotation family
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+
+	// ListActiveSessions lists the active sessions of a user, most recently used first
+	ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error)
+
+	// RevokeSession revokes a session of a user. It fails with ErrSessionNotFound
+	// if the user has no active session with that ID.
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+
 	// RevokeAllForUser revokes all refresh tokens for a user
 	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
 
 	// DeleteExpired deletes expired refresh tokens
 	DeleteExpired(ctx context.Context) error
+
+	// DeleteAllForUser deletes every refresh token of a user, with its device details
+	DeleteAllForUser(ctx context.Context, userID uuid.UUID) error
 }
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// RolePermissionRepository defines the interface for role permission data operations
+type RolePermissionRepository interface {
+	// ListByRole retrieves the permissions granted to a role
+	ListByRole(ctx context.Context, role domain.UserRole) ([]domain.Permission, error)
+}
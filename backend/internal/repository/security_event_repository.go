@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// SecurityEventRepository defines the interface for security event data operations
+type SecurityEventRepository interface {
+	// Create records a new security event
+	Create(ctx context.Context, event *domain.SecurityEvent) error
+
+	// ListByUserID retrieves security events of a user with pagination, newest first
+	ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.SecurityEvent, int, error)
+
+	// AnonymizeByUserID removes the IP address and user agent from the events of a user
+	AnonymizeByUserID(ctx context.Context, userID uuid.UUID) error
+}
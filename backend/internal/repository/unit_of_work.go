@@ -0,0 +1,28 @@
+package repository
+
+import "context"
+
+// UnitOfWork runs repository operations in one database transaction
+type UnitOfWork interface {
+	// Do runs fn in a transaction. The transaction is committed when fn returns nil
+	// and rolled back when it returns an error.
+	Do(ctx context.Context, fn func(repos TxRepositories) error) error
+}
+
+// TxRepositories gives the repositories bound to the transaction of a UnitOfWork
+type TxRepositories interface {
+	// Courses returns the course repository of the transaction
+	Courses() CourseRepository
+
+	// CourseStaff returns the course staff repository of the transaction
+	CourseStaff() CourseStaffRepository
+
+	// ActivationCodes returns the activation code repository of the transaction
+	ActivationCodes() ActivationCodeRepository
+
+	// Enrollments returns the enrollment repository of the transaction
+	Enrollments() EnrollmentRepository
+
+	// CodeRedemptions returns the code redemption repository of the transaction
+	CodeRedemptions() CodeRedemptionRepository
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// UserIdentityRepository defines the interface for social login identity data operations
+type UserIdentityRepository interface {
+	// GetByProviderSubject retrieves the identity of a provider account, ErrIdentityNotFound if it is not linked
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error)
+
+	// Create links a provider account to a user, ErrIdentityAlreadyLinked if it is linked already
+	Create(ctx context.Context, identity *domain.UserIdentity) error
+
+	// UpdateLastLogin records a login with the identity
+	UpdateLastLogin(ctx context.Context, id uuid.UUID) error
+
+	// DeleteByUserID unlinks every social login of a user
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
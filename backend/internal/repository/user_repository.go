@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mathvn/backend/internal/domain"
@@ -45,4 +46,25 @@ type UserRepository interface {
 
 	// ToggleUserStatus activates or deactivates a user
 	ToggleUserStatus(ctx context.Context, userID uuid.UUID, isActive bool) error
+
+	// UpdateMaxSessions sets a user's concurrent device limit, nil restores the role default
+	UpdateMaxSessions(ctx context.Context, userID uuid.UUID, maxSessions *int) error
+
+	// GetTokenVersion retrieves the current access token version of a user
+	GetTokenVersion(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// IncrementTokenVersion invalidates every access token issued to a user
+	IncrementTokenVersion(ctx context.Context, userID uuid.UUID) error
+
+	// ScheduleDeletion marks the account for anonymization at the given time
+	ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) error
+
+	// CancelDeletion clears a pending deletion, reporting whether one was pending
+	CancelDeletion(ctx context.Context, userID uuid.UUID) (bool, error)
+
+	// ListDueForDeletion returns accounts whose deletion is scheduled at or before the given time
+	ListDueForDeletion(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+
+	// Anonymize replaces the personal data of an account and deactivates it, keeping the row
+	Anonymize(ctx context.Context, userID uuid.UUID) error
 }
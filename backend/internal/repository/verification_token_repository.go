@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// EmailVerificationTokenRepository defines the interface for email verification token data operations
+type EmailVerificationTokenRepository interface {
+	// Create creates a new verification token
+	Create(ctx context.Context, token *domain.EmailVerificationToken) error
+
+	// GetByTokenHash retrieves a verification token by its hash
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error)
+
+	// GetLatestByUserID retrieves the most recently issued token for a user
+	GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerificationToken, error)
+
+	// MarkUsed marks a token as used, failing if it was already used
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+
+	// InvalidateAllForUser marks all unused tokens of a user as used
+	InvalidateAllForUser(ctx context.Context, userID uuid.UUID) error
+
+	// DeleteExpired deletes expired verification tokens
+	DeleteExpired(ctx context.Context) error
+}
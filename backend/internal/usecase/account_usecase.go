@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// DeleteAccountInput represents the input for deleting the current account.
+// Accounts created through a social login have no known password and confirm with an emailed code instead.
+type DeleteAccountInput struct {
+	Password string `json:"password"`
+	Code     string `json:"code"` // Code sent by SendDeletionCode
+}
+
+// SendDeletionCodeOutput tells the client how long the emailed code stays valid
+type SendDeletionCodeOutput struct {
+	ExpiresIn int64 `json:"expires_in"` // Seconds
+}
+
+// DeleteAccountOutput tells the client when the account will be anonymized
+type DeleteAccountOutput struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+// AccountUseCase defines the interface for the personal data use cases of an account
+type AccountUseCase interface {
+	// ExportData collects the personal data stored for a user
+	ExportData(ctx context.Context, userID uuid.UUID) (*domain.AccountExport, error)
+
+	// SendDeletionCode emails a code confirming the deletion of the account
+	SendDeletionCode(ctx context.Context, userID uuid.UUID) (*SendDeletionCodeOutput, error)
+
+	// RequestDeletion schedules the account for anonymization after the grace period and logs out every device.
+	// Logging in again before then cancels the deletion.
+	RequestDeletion(ctx context.Context, userID uuid.UUID, input *DeleteAccountInput, client *ClientInfo) (*DeleteAccountOutput, error)
+
+	// PurgeDeletedAccounts anonymizes the accounts whose grace period has ended and returns how many were purged
+	PurgeDeletedAccounts(ctx context.Context) (int, error)
+}
@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/config"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+	"github.com/mathvn/backend/pkg/mailer"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// purgeBatchSize bounds how many accounts one purge run anonymizes
+const purgeBatchSize = 100
+
+type accountUseCase struct {
+	userRepo          repository.UserRepository
+	enrollmentRepo    repository.EnrollmentRepository
+	progressRepo      repository.ProgressRepository
+	refreshTokenRepo  repository.RefreshTokenRepository
+	securityEventRepo repository.SecurityEventRepository
+	mfaRepo           repository.MFARepository
+	identityRepo      repository.UserIdentityRepository
+	consultationRepo  domain.ConsultationRepository
+	parentLinkRepo    repository.ParentLinkRepository
+	redemptionRepo    repository.CodeRedemptionRepository
+	deletionCodeRepo  repository.AccountDeletionCodeRepository
+	authUseCase       AuthUseCase
+	mailer            mailer.Mailer
+	accountConfig     config.AccountConfig
+}
+
+// NewAccountUseCase creates a new account use case
+func NewAccountUseCase(
+	userRepo repository.UserRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	progressRepo repository.ProgressRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	securityEventRepo repository.SecurityEventRepository,
+	mfaRepo repository.MFARepository,
+	identityRepo repository.UserIdentityRepository,
+	consultationRepo domain.ConsultationRepository,
+	parentLinkRepo repository.ParentLinkRepository,
+	redemptionRepo repository.CodeRedemptionRepository,
+	deletionCodeRepo repository.AccountDeletionCodeRepository,
+	authUseCase AuthUseCase,
+	mailSender mailer.Mailer,
+	accountConfig config.AccountConfig,
+) AccountUseCase {
+	return &accountUseCase{
+		userRepo:          userRepo,
+		enrollmentRepo:    enrollmentRepo,
+		progressRepo:      progressRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		securityEventRepo: securityEventRepo,
+		mfaRepo:           mfaRepo,
+		identityRepo:      identityRepo,
+		consultationRepo:  consultationRepo,
+		parentLinkRepo:    parentLinkRepo,
+		redemptionRepo:    redemptionRepo,
+		deletionCodeRepo:  deletionCodeRepo,
+		authUseCase:       authUseCase,
+		mailer:            mailSender,
+		accountConfig:     accountConfig,
+	}
+}
+
+// ExportData collects the personal data stored for a user
+func (uc *accountUseCase) ExportData(ctx context.Context, userID uuid.UUID) (*domain.AccountExport, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollments, err := uc.enrollmentRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := uc.progressRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := uc.refreshTokenRepo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Consultation requests are not linked to accounts, they are matched by phone number
+	consultations := []*domain.ConsultationRequest{}
+	if user.PhoneNumber != "" {
+		consultations, err = uc.consultationRepo.ListByPhone(ctx, user.PhoneNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.AccountExport{
+		ExportedAt:           time.Now(),
+		Profile:              user,
+		Enrollments:          enrollments,
+		LessonProgress:       progress,
+		Sessions:             sessions,
+		ConsultationRequests: consultations,
+	}, nil
+}
+
+// RequestDeletion schedules the account for anonymization after the grace period
+func (uc *accountUseCase) RequestDeletion(ctx context.Context, userID uuid.UUID, input *DeleteAccountInput, client *ClientInfo) (*DeleteAccountOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Admins are removed by another admin so the platform always keeps one
+	if user.Role == domain.RoleAdmin {
+		return nil, domain.ErrAccountDeletionNotAllowed
+	}
+
+	if err := uc.confirmDeletion(ctx, user, input); err != nil {
+		return nil, err
+	}
+
+	scheduledAt := time.Now().Add(uc.accountConfig.DeletionGracePeriod)
+	if err := uc.userRepo.ScheduleDeletion(ctx, userID, scheduledAt); err != nil {
+		return nil, err
+	}
+
+	if err := uc.authUseCase.LogoutAll(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	event := &domain.SecurityEvent{
+		UserID:    &userID,
+		EventType: domain.SecurityEventDeletionRequested,
+		IPAddress: optionalString(client.IPAddress),
+		UserAgent: optionalString(client.UserAgent),
+		Metadata: map[string]interface{}{
+			"deletion_scheduled_at": scheduledAt,
+		},
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record security event for user %s: %v", userID, err)
+	}
+
+	// The deletion is already scheduled, a lost notice does not undo it
+	if err := uc.sendDeletionNotice(ctx, user, scheduledAt); err != nil {
+		log.Printf("failed to send deletion notice to %s: %v", user.Email, err)
+	}
+
+	return &DeleteAccountOutput{DeletionScheduledAt: scheduledAt}, nil
+}
+
+// SendDeletionCode emails a code that confirms the deletion in place of the password
+func (uc *accountUseCase) SendDeletionCode(ctx context.Context, userID uuid.UUID) (*SendDeletionCodeOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == domain.RoleAdmin {
+		return nil, domain.ErrAccountDeletionNotAllowed
+	}
+
+	// Throttle requests to avoid flooding the user's inbox
+	latest, err := uc.deletionCodeRepo.GetLatestByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrInvalidDeletionCode) {
+		return nil, err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < uc.accountConfig.DeletionCodeInterval {
+		return nil, domain.ErrDeletionCodeTooSoon
+	}
+
+	// Only the latest code is valid
+	if err := uc.deletionCodeRepo.InvalidateAllForUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	code, err := generateNumericOTP(otpLength)
+	if err != nil {
+		return nil, err
+	}
+
+	// The code ID salts the hash so equal codes never collide
+	deletionCode := &domain.AccountDeletionCode{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(uc.accountConfig.DeletionCodeExpiryTime),
+	}
+	deletionCode.CodeHash = hashOTP(deletionCode.ID, code)
+
+	if err := uc.deletionCodeRepo.Create(ctx, deletionCode); err != nil {
+		return nil, err
+	}
+
+	minutes := int(uc.accountConfig.DeletionCodeExpiryTime.Minutes())
+	err = uc.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Mã xác nhận xóa tài khoản MathVN",
+		Body: fmt.Sprintf(
+			"Xin chào %s,\n\nMã xác nhận xóa tài khoản của bạn là %s, có hiệu lực trong %d phút.\n\nNếu bạn không yêu cầu xóa tài khoản, hãy bỏ qua email này và đổi mật khẩu.",
+			user.FullName,
+			code,
+			minutes,
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendDeletionCodeOutput{ExpiresIn: int64(uc.accountConfig.DeletionCodeExpiryTime.Seconds())}, nil
+}
+
+// confirmDeletion checks the password or, for accounts created through a social login
+// whose password is unknown, the emailed deletion code
+func (uc *accountUseCase) confirmDeletion(ctx context.Context, user *domain.User, input *DeleteAccountInput) error {
+	switch {
+	case input.Code != "":
+		return uc.useDeletionCode(ctx, user.ID, strings.TrimSpace(input.Code))
+	case input.Password != "":
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
+			return domain.ErrInvalidCredentials
+		}
+		return nil
+	default:
+		return domain.ErrDeletionConfirmationMissing
+	}
+}
+
+// useDeletionCode checks a deletion code and consumes it
+func (uc *accountUseCase) useDeletionCode(ctx context.Context, userID uuid.UUID, code string) error {
+	deletionCode, err := uc.deletionCodeRepo.GetLatestByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if deletionCode.IsUsed() {
+		return domain.ErrInvalidDeletionCode
+	}
+	if deletionCode.IsExpired() {
+		return domain.ErrDeletionCodeExpired
+	}
+
+	// Count the guess before comparing so parallel guesses cannot exceed the limit
+	attempts, err := uc.deletionCodeRepo.IncrementAttempts(ctx, deletionCode.ID, uc.accountConfig.DeletionCodeMaxAttempts)
+	if err != nil {
+		return err
+	}
+
+	expected := hashOTP(deletionCode.ID, code)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(deletionCode.CodeHash)) != 1 {
+		if attempts >= uc.accountConfig.DeletionCodeMaxAttempts {
+			return domain.ErrDeletionCodeTooManyAttempts
+		}
+		return domain.ErrInvalidDeletionCode
+	}
+
+	// Consume the code first so it cannot be used twice concurrently
+	return uc.deletionCodeRepo.MarkUsed(ctx, deletionCode.ID)
+}
+
+// PurgeDeletedAccounts anonymizes the accounts whose grace period has ended
+func (uc *accountUseCase) PurgeDeletedAccounts(ctx context.Context) (int, error) {
+	userIDs, err := uc.userRepo.ListDueForDeletion(ctx, time.Now(), purgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, userID := range userIDs {
+		if err := uc.purgeAccount(ctx, userID); err != nil {
+			log.Printf("failed to purge account %s: %v", userID, err)
+			continue
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// purgeAccount removes the personal data of one account. The user row is anonymized last,
+// so an account that fails halfway is still due and is retried on the next run.
+func (uc *accountUseCase) purgeAccount(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.identityRepo.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.mfaRepo.Delete(ctx, userID); err != nil && !errors.Is(err, domain.ErrMFANotEnabled) {
+		return err
+	}
+
+	if err := uc.refreshTokenRepo.DeleteAllForUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.parentLinkRepo.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.securityEventRepo.AnonymizeByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.redemptionRepo.AnonymizeByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	if user.PhoneNumber != "" {
+		if err := uc.consultationRepo.AnonymizeByPhone(ctx, user.PhoneNumber); err != nil {
+			return err
+		}
+	}
+
+	// Enrollments and progress stay, they no longer point to a person once the user row is anonymized
+	return uc.userRepo.Anonymize(ctx, userID)
+}
+
+// sendDeletionNotice tells the user when the account will be deleted and how to keep it
+func (uc *accountUseCase) sendDeletionNotice(ctx context.Context, user *domain.User, scheduledAt time.Time) error {
+	return uc.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Yêu cầu xóa tài khoản MathVN",
+		Body: fmt.Sprintf(
+			"Xin chào %s,\n\nChúng tôi đã nhận được yêu cầu xóa tài khoản của bạn. Tài khoản và dữ liệu cá nhân sẽ bị xóa vĩnh viễn vào %s.\n\nNếu bạn đổi ý, chỉ cần đăng nhập lại trước thời điểm đó để hủy yêu cầu.",
+			user.FullName,
+			scheduledAt.Format("15:04 02/01/2006"),
+		),
+	})
+}
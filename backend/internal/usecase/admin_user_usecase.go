@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,12 +13,41 @@ import (
 )
 
 type adminUserUseCase struct {
-	userRepo repository.UserRepository
+	userRepo          repository.UserRepository
+	refreshTokenRepo  repository.RefreshTokenRepository
+	loginAttemptRepo  repository.LoginAttemptRepository
+	securityEventRepo repository.SecurityEventRepository
+	mfaRepo           repository.MFARepository
+	courseRepo        repository.CourseRepository
+	enrollmentRepo    repository.EnrollmentRepository
+	tokenVersions     *TokenVersionCache
+	audit             auditRecorder
+	bcryptCost        int
 }
 
-func NewAdminUserUseCase(userRepo repository.UserRepository) domain.AdminUserUseCase {
+func NewAdminUserUseCase(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	securityEventRepo repository.SecurityEventRepository,
+	mfaRepo repository.MFARepository,
+	courseRepo repository.CourseRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	tokenVersions *TokenVersionCache,
+	auditLogRepo repository.AuditLogRepository,
+	bcryptCost int,
+) domain.AdminUserUseCase {
 	return &adminUserUseCase{
-		userRepo: userRepo,
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		loginAttemptRepo:  loginAttemptRepo,
+		securityEventRepo: securityEventRepo,
+		mfaRepo:           mfaRepo,
+		courseRepo:        courseRepo,
+		enrollmentRepo:    enrollmentRepo,
+		tokenVersions:     tokenVersions,
+		audit:             auditRecorder{auditLogRepo: auditLogRepo},
+		bcryptCost:        bcryptCost,
 	}
 }
 
@@ -35,7 +66,7 @@ func (uc *adminUserUseCase) CreateUser(ctx context.Context, user *domain.User, p
 	}
 
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), uc.bcryptCost)
 	if err != nil {
 		return err
 	}
@@ -50,7 +81,12 @@ func (uc *adminUserUseCase) CreateUser(ctx context.Context, user *domain.User, p
 		user.Role = domain.RoleStudent
 	}
 
-	return uc.userRepo.Create(ctx, user)
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionUserCreate, domain.AuditTargetUser, user.ID, nil, user)
+	return nil
 }
 
 func (uc *adminUserUseCase) UpdateUser(ctx context.Context, user *domain.User) error {
@@ -63,11 +99,29 @@ func (uc *adminUserUseCase) UpdateUser(ctx context.Context, user *domain.User) e
 	// If we want to allow email update, need to handle uniqueness check.
 	// Based on repo implementation, email is NOT updated.
 
-	return uc.userRepo.AdminUpdateUser(ctx, user)
+	// A role or status change bumps the token version
+	return uc.auditUserChange(ctx, domain.AuditActionUserUpdate, user.ID, func() error {
+		if err := uc.userRepo.AdminUpdateUser(ctx, user); err != nil {
+			return err
+		}
+		uc.tokenVersions.Invalidate(user.ID)
+		return nil
+	})
 }
 
 func (uc *adminUserUseCase) DeleteUser(ctx context.Context, userID uuid.UUID) error {
-	return uc.userRepo.Delete(ctx, userID)
+	before, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	uc.tokenVersions.Invalidate(userID)
+
+	uc.audit.record(ctx, domain.AuditActionUserDelete, domain.AuditTargetUser, userID, before, nil)
+	return nil
 }
 
 func (uc *adminUserUseCase) UpdateUserRole(ctx context.Context, userID uuid.UUID, role domain.UserRole) error {
@@ -76,9 +130,149 @@ func (uc *adminUserUseCase) UpdateUserRole(ctx context.Context, userID uuid.UUID
 		return domain.ErrInvalidCredentials // Or create ErrInvalidRole
 	}
 
-	return uc.userRepo.UpdateUserRole(ctx, userID, role)
+	return uc.auditUserChange(ctx, domain.AuditActionUserUpdateRole, userID, func() error {
+		if err := uc.userRepo.UpdateUserRole(ctx, userID, role); err != nil {
+			return err
+		}
+		uc.tokenVersions.Invalidate(userID)
+		return nil
+	})
 }
 
 func (uc *adminUserUseCase) ToggleUserStatus(ctx context.Context, userID uuid.UUID, isActive bool) error {
-	return uc.userRepo.ToggleUserStatus(ctx, userID, isActive)
+	return uc.auditUserChange(ctx, domain.AuditActionUserToggleStatus, userID, func() error {
+		if err := uc.userRepo.ToggleUserStatus(ctx, userID, isActive); err != nil {
+			return err
+		}
+		uc.tokenVersions.Invalidate(userID)
+		return nil
+	})
+}
+
+func (uc *adminUserUseCase) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	// Make sure the user exists so an unknown ID is not reported as an empty list
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.refreshTokenRepo.ListActiveSessions(ctx, userID)
+}
+
+func (uc *adminUserUseCase) SetSessionLimit(ctx context.Context, userID uuid.UUID, maxSessions *int) error {
+	// nil restores the role default, 0 lifts the limit
+	if maxSessions != nil && *maxSessions < 0 {
+		return domain.ErrInvalidSessionLimit
+	}
+
+	return uc.auditUserChange(ctx, domain.AuditActionUserSetSessionLimit, userID, func() error {
+		return uc.userRepo.UpdateMaxSessions(ctx, userID, maxSessions)
+	})
+}
+
+func (uc *adminUserUseCase) UnlockUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// The user may have been locked out by email or by phone number
+	keys := []string{loginAccountKey(user.Email)}
+	if user.PhoneNumber != "" {
+		keys = append(keys, loginAccountKey(user.PhoneNumber))
+	}
+	keys = append(keys, mfaAttemptKey(user.ID))
+
+	// The audit entry keeps the failed attempts and lockouts that were cleared
+	before := map[string]*domain.LoginAttempt{}
+	for _, key := range keys {
+		attempt, err := uc.loginAttemptRepo.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if attempt.FailedCount > 0 || attempt.LockedUntil != nil {
+			before[key] = attempt
+		}
+	}
+
+	for _, key := range keys {
+		if err := uc.loginAttemptRepo.Reset(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	event := &domain.SecurityEvent{
+		UserID:    &user.ID,
+		EventType: domain.SecurityEventLoginUnlocked,
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record security event for user %s: %v", user.ID, err)
+	}
+
+	uc.audit.record(ctx, domain.AuditActionUserUnlock, domain.AuditTargetUser, user.ID, before, map[string]*domain.LoginAttempt{})
+	return nil
+}
+
+func (uc *adminUserUseCase) ResetUserMFA(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Snapshot the second factor before it is deleted, so the audit entry shows what was reset
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrMFANotEnabled) {
+		return err
+	}
+	before := userMFAState{User: user, MFA: mfa}
+
+	if err := uc.mfaRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	// Sessions opened with the old second factor end, the user enrolls again at the next login
+	if err := uc.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.userRepo.IncrementTokenVersion(ctx, userID); err != nil {
+		return err
+	}
+	uc.tokenVersions.Invalidate(userID)
+
+	event := &domain.SecurityEvent{
+		UserID:    &userID,
+		EventType: domain.SecurityEventMFAReset,
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record security event for user %s: %v", userID, err)
+	}
+
+	after := userMFAState{User: user}
+	uc.audit.record(ctx, domain.AuditActionUserResetMFA, domain.AuditTargetUser, userID, before, after)
+	return nil
+}
+
+// userMFAState is the audit snapshot of a user and their second factor, nil when not enrolled
+type userMFAState struct {
+	*domain.User
+	MFA *domain.UserMFA `json:"mfa"`
+}
+
+// auditUserChange applies a change to a user and records the user before and after it
+func (uc *adminUserUseCase) auditUserChange(ctx context.Context, action string, userID uuid.UUID, change func() error) error {
+	before, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := change(); err != nil {
+		return err
+	}
+
+	after, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, action, domain.AuditTargetUser, userID, before, after)
+	return nil
 }
@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// maxImportRows bounds one import, every created user costs a bcrypt hash
+	maxImportRows = 500
+
+	// initialPasswordLength is the length of generated passwords, above the 8 character minimum
+	initialPasswordLength = 10
+
+	// initialPasswordAlphabet leaves out characters that are easy to misread on a printed list
+	initialPasswordAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz23456789"
+)
+
+// importColumns maps the accepted header names to the import fields
+var importColumns = map[string]string{
+	"full_name":     "full_name",
+	"name":          "full_name",
+	"họ_tên":        "full_name",
+	"họ_và_tên":     "full_name",
+	"email":         "email",
+	"phone":         "phone_number",
+	"phone_number":  "phone_number",
+	"số_điện_thoại": "phone_number",
+	"sđt":           "phone_number",
+	"course_ids":    "course_ids",
+	"courses":       "course_ids",
+	"khóa_học":      "course_ids",
+}
+
+// courseIDSeparator splits the course IDs of one cell
+var courseIDSeparator = regexp.MustCompile(`[\s,;]+`)
+
+// ImportUsers validates the rows of an import file and, unless it is a dry run, creates the valid ones.
+// The first row is the header. Rows that fail are reported and do not stop the others.
+func (uc *adminUserUseCase) ImportUsers(ctx context.Context, rows [][]string, opts domain.UserImportOptions) (*domain.UserImportResult, error) {
+	if len(rows) < 2 {
+		return nil, domain.ErrImportEmpty
+	}
+	if len(rows)-1 > maxImportRows {
+		return nil, domain.ErrImportTooManyRows
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		if field, ok := importColumns[key]; ok {
+			columns[field] = i
+		}
+	}
+	for _, field := range []string{"full_name", "email", "phone_number"} {
+		if _, ok := columns[field]; !ok {
+			return nil, domain.ErrImportMissingColumns
+		}
+	}
+
+	result := &domain.UserImportResult{
+		DryRun: opts.DryRun,
+		Rows:   []*domain.UserImportRow{},
+	}
+	seenEmails := map[string]int{}
+	seenPhones := map[string]int{}
+	knownCourses := map[uuid.UUID]bool{}
+
+	for i, cells := range rows[1:] {
+		cell := func(field string) string {
+			idx, ok := columns[field]
+			if !ok || idx >= len(cells) {
+				return ""
+			}
+			return cells[idx]
+		}
+
+		row := &domain.UserImportRow{
+			Row:         i + 2,
+			FullName:    cell("full_name"),
+			Email:       cell("email"),
+			PhoneNumber: normalizeImportedPhone(cell("phone_number")),
+		}
+		if row.FullName == "" && row.Email == "" && row.PhoneNumber == "" {
+			continue // Blank line inside the file
+		}
+		result.TotalRows++
+
+		if err := uc.validateImportRow(ctx, row, cell("course_ids"), seenEmails, seenPhones, knownCourses); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case len(row.Errors) > 0:
+			row.Status = domain.UserImportRowFailed
+		case opts.DryRun:
+			row.Status = domain.UserImportRowValid
+		default:
+			switch err := uc.createImportedUser(ctx, row, opts); {
+			case err != nil:
+				log.Printf("failed to import user on row %d: %v", row.Row, err)
+				row.Errors = append(row.Errors, "Không thể tạo tài khoản")
+				row.Status = domain.UserImportRowFailed
+			case len(row.Errors) > 0:
+				row.Status = domain.UserImportRowPartial
+			default:
+				row.Status = domain.UserImportRowCreated
+			}
+		}
+
+		switch row.Status {
+		case domain.UserImportRowValid:
+			result.ValidRows++
+		case domain.UserImportRowCreated:
+			result.ValidRows++
+			result.CreatedRows++
+		case domain.UserImportRowPartial:
+			result.ValidRows++
+			result.CreatedRows++
+			result.PartialRows++
+		default:
+			result.FailedRows++
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	if result.TotalRows == 0 {
+		return nil, domain.ErrImportEmpty
+	}
+
+	return result, nil
+}
+
+// validateImportRow records every problem of a row in row.Errors.
+// Only database failures are returned as errors, they abort the import.
+func (uc *adminUserUseCase) validateImportRow(
+	ctx context.Context,
+	row *domain.UserImportRow,
+	courseIDs string,
+	seenEmails, seenPhones map[string]int,
+	knownCourses map[uuid.UUID]bool,
+) error {
+	if len([]rune(row.FullName)) < 2 {
+		row.Errors = append(row.Errors, "Họ tên phải có ít nhất 2 ký tự")
+	}
+
+	switch {
+	case !isValidEmail(row.Email):
+		row.Errors = append(row.Errors, "Email không hợp lệ")
+	case seenEmails[strings.ToLower(row.Email)] != 0:
+		row.Errors = append(row.Errors, "Email trùng với một dòng khác")
+	default:
+		seenEmails[strings.ToLower(row.Email)] = row.Row
+		exists, err := uc.userRepo.ExistsByEmail(ctx, row.Email)
+		if err != nil {
+			return err
+		}
+		if exists {
+			row.Errors = append(row.Errors, "Email đã được sử dụng")
+		}
+	}
+
+	switch {
+	case !isValidPhoneNumber(row.PhoneNumber):
+		row.Errors = append(row.Errors, "Số điện thoại không hợp lệ")
+	case seenPhones[row.PhoneNumber] != 0:
+		row.Errors = append(row.Errors, "Số điện thoại trùng với một dòng khác")
+	default:
+		seenPhones[row.PhoneNumber] = row.Row
+		exists, err := uc.userRepo.ExistsByPhoneNumber(ctx, row.PhoneNumber)
+		if err != nil {
+			return err
+		}
+		if exists {
+			row.Errors = append(row.Errors, "Số điện thoại đã được sử dụng")
+		}
+	}
+
+	for _, value := range courseIDSeparator.Split(courseIDs, -1) {
+		if value == "" {
+			continue
+		}
+		courseID, err := uuid.Parse(value)
+		if err != nil {
+			row.Errors = append(row.Errors, "Mã khóa học không hợp lệ: "+value)
+			continue
+		}
+
+		found, checked := knownCourses[courseID]
+		if !checked {
+			_, err := uc.courseRepo.GetByID(ctx, courseID)
+			if err != nil && !errors.Is(err, domain.ErrCourseNotFound) {
+				return err
+			}
+			found = err == nil
+			knownCourses[courseID] = found
+		}
+		if !found {
+			row.Errors = append(row.Errors, "Không tìm thấy khóa học: "+value)
+			continue
+		}
+		row.CourseIDs = append(row.CourseIDs, courseID)
+	}
+
+	return nil
+}
+
+// createImportedUser creates the user of a valid row with a generated password and enrolls it.
+// Failed enrollments are added to row.Errors, the user is kept.
+func (uc *adminUserUseCase) createImportedUser(ctx context.Context, row *domain.UserImportRow, opts domain.UserImportOptions) error {
+	password, err := generateInitialPassword()
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), uc.bcryptCost)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user := &domain.User{
+		ID:           uuid.New(),
+		Email:        row.Email,
+		PasswordHash: string(hashedPassword),
+		FullName:     row.FullName,
+		PhoneNumber:  row.PhoneNumber,
+		Role:         domain.RoleStudent,
+		IsActive:     true,
+		IsVerified:   true, // Admin created users are verified by default
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return err
+	}
+	row.UserID = &user.ID
+	row.Password = password
+	uc.audit.record(ctx, domain.AuditActionUserImport, domain.AuditTargetUser, user.ID, nil, user)
+
+	if !opts.Enroll {
+		return nil
+	}
+
+	// Imported users get the same access as an activation code gives unless the import sets it
+	accessDays := opts.AccessDays
+	if accessDays <= 0 {
+		accessDays = domain.DefaultAccessDays
+	}
+	expiresAt := now.AddDate(0, 0, accessDays)
+	for _, courseID := range row.CourseIDs {
+		enrollment := &domain.Enrollment{
+			ID:         uuid.New(),
+			UserID:     user.ID,
+			CourseID:   courseID,
+			EnrolledAt: now,
+			ExpiresAt:  &expiresAt,
+			Status:     domain.EnrollmentStatusActive,
+		}
+		if err := uc.enrollmentRepo.Create(ctx, enrollment); err != nil {
+			log.Printf("failed to enroll imported user %s in course %s: %v", user.ID, courseID, err)
+			row.Errors = append(row.Errors, "Không thể ghi danh vào khóa học: "+courseID.String())
+			continue
+		}
+		row.Enrolled++
+	}
+
+	return nil
+}
+
+// normalizeImportedPhone restores the leading zero spreadsheet apps drop from phone numbers stored as numbers
+func normalizeImportedPhone(phone string) string {
+	phone = strings.NewReplacer(" ", "", ".", "", "-", "").Replace(phone)
+	if len(phone) == 9 && phone[0] != '0' && strings.Trim(phone, "0123456789") == "" {
+		return "0" + phone
+	}
+	return phone
+}
+
+// generateInitialPassword generates a random password for an imported user
+func generateInitialPassword() (string, error) {
+	max := big.NewInt(int64(len(initialPasswordAlphabet)))
+	var b strings.Builder
+	for i := 0; i < initialPasswordLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(initialPasswordAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
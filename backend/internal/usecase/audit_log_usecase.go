@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+type auditLogUseCase struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+// NewAuditLogUseCase creates a new audit log use case
+func NewAuditLogUseCase(auditLogRepo repository.AuditLogRepository) domain.AuditLogUseCase {
+	return &auditLogUseCase{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// ListAuditLogs returns a page of audit log entries matching the filter, newest first
+func (uc *auditLogUseCase) ListAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, page, limit int) (*domain.PaginatedAuditLogs, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	logs, total, err := uc.auditLogRepo.List(ctx, filter, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &domain.PaginatedAuditLogs{
+		Logs:       logs,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+	}, nil
+}
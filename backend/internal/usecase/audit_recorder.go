@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// AuditActor is who made a change and from where
+type AuditActor struct {
+	UserID    uuid.UUID
+	IPAddress string
+	UserAgent string
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor returns a context that attributes audited changes to the actor
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+func auditActorFromContext(ctx context.Context) (AuditActor, bool) {
+	actor, ok := ctx.Value(auditActorKey{}).(AuditActor)
+	return actor, ok
+}
+
+// auditIgnoredFields change on every write and would only add noise to the diff
+var auditIgnoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// auditRecorder writes audit log entries for the usecases that mutate admin-managed data
+type auditRecorder struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+// record stores an audit entry for a change from before to after. Either may be nil,
+// for creations and deletions. Failures are logged, they never fail the change itself.
+func (r auditRecorder) record(ctx context.Context, action, targetType string, targetID uuid.UUID, before, after interface{}) {
+	r.recordTarget(ctx, action, targetType, targetID.String(), before, after)
+}
+
+// recordTarget is record for targets not identified by a UUID, like IP addresses
+func (r auditRecorder) recordTarget(ctx context.Context, action, targetType, targetID string, before, after interface{}) {
+	entry := &domain.AuditLog{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     auditSnapshot(before),
+		After:      auditSnapshot(after),
+	}
+	entry.Changes = auditDiff(entry.Before, entry.After)
+
+	if actor, ok := auditActorFromContext(ctx); ok {
+		entry.ActorID = &actor.UserID
+		entry.IPAddress = optionalString(actor.IPAddress)
+		entry.UserAgent = optionalString(actor.UserAgent)
+	}
+
+	if err := r.auditLogRepo.Create(ctx, entry); err != nil {
+		log.Printf("failed to record audit log %s for %s %s: %v", action, targetType, targetID, err)
+	}
+}
+
+// auditSnapshot converts a value to its JSON object form, so that snapshots
+// contain exactly what the API exposes and never hidden fields like password hashes
+func auditSnapshot(v interface{}) map[string]interface{} {
+	if v == nil || reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("failed to snapshot %T for audit log: %v", v, err)
+		return nil
+	}
+
+	snapshot := map[string]interface{}{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		log.Printf("failed to snapshot %T for audit log: %v", v, err)
+		return nil
+	}
+
+	return snapshot
+}
+
+// auditDiff returns the fields whose value differs between two snapshots
+func auditDiff(before, after map[string]interface{}) map[string]domain.AuditChange {
+	changes := map[string]domain.AuditChange{}
+
+	for field, old := range before {
+		if auditIgnoredFields[field] {
+			continue
+		}
+		if value, ok := after[field]; !ok || !reflect.DeepEqual(old, value) {
+			changes[field] = domain.AuditChange{Old: old, New: after[field]}
+		}
+	}
+	for field, value := range after {
+		if auditIgnoredFields[field] {
+			continue
+		}
+		if _, ok := before[field]; !ok {
+			changes[field] = domain.AuditChange{Old: nil, New: value}
+		}
+	}
+
+	return changes
+}
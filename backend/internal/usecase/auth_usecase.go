@@ -2,23 +2,36 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/pkg/jwtkeys"
 )
 
+// ClientInfo describes the device an authentication request comes from.
+// IPAddress and UserAgent are filled in by the handler, DeviceName may be sent by the app.
+type ClientInfo struct {
+	IPAddress  string `json:"-"`
+	UserAgent  string `json:"-"`
+	DeviceName string `json:"device_name"`
+}
+
 // RegisterInput represents the input for user registration
 type RegisterInput struct {
-	Email       string `json:"email" binding:"required,email"`
-	Password    string `json:"password" binding:"required,min=8"`
-	FullName    string `json:"full_name" binding:"required,min=2"`
-	PhoneNumber string `json:"phone_number" binding:"required"`
+	Email       string          `json:"email" binding:"required,email"`
+	Password    string          `json:"password" binding:"required,min=8"`
+	FullName    string          `json:"full_name" binding:"required,min=2"`
+	PhoneNumber string          `json:"phone_number" binding:"required"`
+	Role        domain.UserRole `json:"role"` // student (default) or parent
+	ClientInfo
 }
 
 // LoginInput represents the input for user login
 type LoginInput struct {
 	EmailOrPhone string `json:"email_or_phone" binding:"required"`
 	Password     string `json:"password" binding:"required"`
+	ClientInfo
 }
 
 // UpdateProfileInput represents the input for updating user profile
@@ -40,6 +53,13 @@ type AuthOutput struct {
 	AccessToken  string       `json:"access_token"`
 	RefreshToken string       `json:"refresh_token"`
 	ExpiresIn    int64        `json:"expires_in"` // Access token expiry in seconds
+
+	// MFARequired is set instead of tokens when the user must enter a second factor with MFAToken
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+
+	// MFASetupRequired tells the client the role requires two-factor authentication that is not enabled yet
+	MFASetupRequired bool `json:"mfa_setup_required,omitempty"`
 }
 
 // UserOutput represents the output for user data
@@ -53,9 +73,130 @@ type UserOutput struct {
 	IsVerified  bool            `json:"is_verified"`
 }
 
+// AccessTokenClaims represents the verified claims of an access token
+type AccessTokenClaims struct {
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	TokenVersion int
+	MFAVerified  bool // The session was opened with a second factor
+	ExpiresAt    time.Time
+
+	// ActorID is the admin acting as the user, nil unless the token came from Impersonate
+	ActorID           *uuid.UUID
+	ActorTokenVersion int
+}
+
+// ImpersonationOutput carries a short-lived access token for acting as another user.
+// There is no refresh token, the admin starts a new impersonation once it expires.
+type ImpersonationOutput struct {
+	User        *domain.User `json:"user"`
+	AccessToken string       `json:"access_token"`
+	ExpiresIn   int64        `json:"expires_in"` // Access token expiry in seconds
+	ActorID     uuid.UUID    `json:"actor_id"`
+}
+
 // RefreshTokenInput represents the input for refresh token request
 type RefreshTokenInput struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
+	ClientInfo
+}
+
+// VerifyEmailInput represents the input for verifying an email address
+type VerifyEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ResendVerificationInput represents the input for resending the verification email
+type ResendVerificationInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ForgotPasswordInput represents the input for requesting a password reset
+type ForgotPasswordInput struct {
+	EmailOrPhone string `json:"email_or_phone" binding:"required"`
+}
+
+// ForgotPasswordOutput tells the client how the reset code was delivered
+type ForgotPasswordOutput struct {
+	Channel domain.PasswordResetChannel `json:"channel"`
+}
+
+// ResetPasswordInput represents the input for resetting a forgotten password.
+// Use Token for the link sent by email, or EmailOrPhone with OTP for the code sent by SMS.
+type ResetPasswordInput struct {
+	Token        string `json:"token"`
+	EmailOrPhone string `json:"email_or_phone"`
+	OTP          string `json:"otp"`
+	NewPassword  string `json:"new_password" binding:"required,min=8"`
+}
+
+// RequestLoginOTPInput represents the input for requesting a login code by SMS
+type RequestLoginOTPInput struct {
+	PhoneNumber string `json:"phone_number" binding:"required"`
+}
+
+// RequestLoginOTPOutput tells the client how long the login code is valid
+type RequestLoginOTPOutput struct {
+	ExpiresIn int64 `json:"expires_in"` // Code expiry in seconds
+}
+
+// VerifyLoginOTPInput represents the input for logging in with a code sent by SMS
+type VerifyLoginOTPInput struct {
+	PhoneNumber string `json:"phone_number" binding:"required"`
+	Code        string `json:"code" binding:"required"`
+	ClientInfo
+}
+
+// MFACodeInput represents a two-factor code, either a TOTP code or a recovery code
+type MFACodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// VerifyMFAInput represents the second step of a login with two-factor authentication
+type VerifyMFAInput struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"` // TOTP code or recovery code
+	ClientInfo
+}
+
+// DisableMFAInput represents the input for turning off two-factor authentication
+type DisableMFAInput struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"` // TOTP code or recovery code
+}
+
+// MFAStatusOutput represents the two-factor authentication state of the current user
+type MFAStatusOutput struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// TOTPSetupOutput carries the new secret for the authenticator app
+type TOTPSetupOutput struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"` // Render as a QR code
+}
+
+// RecoveryCodesOutput carries recovery codes, they are only shown once
+type RecoveryCodesOutput struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// OAuthStartOutput carries the provider sign-in URL of a social login
+type OAuthStartOutput struct {
+	AuthURL   string `json:"auth_url"`
+	State     string `json:"state"` // Keep in the browser and compare with the state of the callback
+	Nonce     string `json:"-"`     // Set as an HttpOnly cookie, the state only completes with it
+	ExpiresIn int64  `json:"-"`     // Seconds until the state expires
+}
+
+// OAuthCallbackInput represents the callback of a social login
+type OAuthCallbackInput struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+	Nonce string `json:"-"` // From the cookie set when the login started
+	ClientInfo
 }
 
 // AuthUseCase defines the interface for authentication use cases
@@ -66,6 +207,12 @@ type AuthUseCase interface {
 	// Login authenticates a user and returns tokens
 	Login(ctx context.Context, input *LoginInput) (*AuthOutput, error)
 
+	// RequestLoginOTP sends a login code to a phone number
+	RequestLoginOTP(ctx context.Context, input *RequestLoginOTPInput) (*RequestLoginOTPOutput, error)
+
+	// VerifyLoginOTP logs a user in with a code sent by SMS
+	VerifyLoginOTP(ctx context.Context, input *VerifyLoginOTPInput) (*AuthOutput, error)
+
 	// GetProfile retrieves the current user's profile
 	GetProfile(ctx context.Context, userID uuid.UUID) (*UserOutput, error)
 
@@ -75,8 +222,17 @@ type AuthUseCase interface {
 	// ChangePassword changes the current user's password
 	ChangePassword(ctx context.Context, userID uuid.UUID, input *ChangePasswordInput) error
 
-	// ValidateToken validates a JWT access token and returns the user ID
-	ValidateToken(token string) (uuid.UUID, error)
+	// ValidateToken validates a JWT access token and returns its claims
+	ValidateToken(token string) (*AccessTokenClaims, error)
+
+	// CheckTokenVersion rejects access tokens that were revoked by a token version bump
+	CheckTokenVersion(ctx context.Context, claims *AccessTokenClaims) error
+
+	// GetJWKS returns the public keys that verify access tokens
+	GetJWKS() *jwtkeys.JWKS
+
+	// Impersonate issues an access token that lets a user with the impersonate permission act as another user
+	Impersonate(ctx context.Context, actorID, userID uuid.UUID, client *ClientInfo) (*ImpersonationOutput, error)
 
 	// RefreshToken generates a new access token using a refresh token
 	RefreshToken(ctx context.Context, input *RefreshTokenInput) (*AuthOutput, error)
@@ -86,5 +242,58 @@ type AuthUseCase interface {
 
 	// LogoutAll revokes all refresh tokens for a user
 	LogoutAll(ctx context.Context, userID uuid.UUID) error
-}
 
+	// ListSessions lists the devices the user is logged in on
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error)
+
+	// RevokeSession logs the user out of one device
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+
+	// VerifyEmail marks the user's email as verified using a token sent by email
+	VerifyEmail(ctx context.Context, input *VerifyEmailInput) error
+
+	// ResendVerification sends a new verification email
+	ResendVerification(ctx context.Context, input *ResendVerificationInput) error
+
+	// ForgotPassword sends a reset link by email or an OTP by SMS
+	ForgotPassword(ctx context.Context, input *ForgotPasswordInput) (*ForgotPasswordOutput, error)
+
+	// ResetPassword sets a new password using a reset link token or OTP
+	ResetPassword(ctx context.Context, input *ResetPasswordInput) error
+
+	// GetMFAStatus retrieves the two-factor authentication state of a user
+	GetMFAStatus(ctx context.Context, userID uuid.UUID) (*MFAStatusOutput, error)
+
+	// SetupTOTP generates a new TOTP secret that must be confirmed with ConfirmTOTP
+	SetupTOTP(ctx context.Context, userID uuid.UUID) (*TOTPSetupOutput, error)
+
+	// ConfirmTOTP enables two-factor authentication and returns the recovery codes
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, input *MFACodeInput) (*RecoveryCodesOutput, error)
+
+	// DisableMFA turns off two-factor authentication
+	DisableMFA(ctx context.Context, userID uuid.UUID, input *DisableMFAInput) error
+
+	// RegenerateRecoveryCodes replaces the recovery codes of a user
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, input *MFACodeInput) (*RecoveryCodesOutput, error)
+
+	// VerifyMFA completes a login that returned an MFA challenge
+	VerifyMFA(ctx context.Context, input *VerifyMFAInput) (*AuthOutput, error)
+
+	// IsMFARequired checks if the role must use two-factor authentication
+	IsMFARequired(role domain.UserRole) bool
+
+	// OAuthProviders lists the enabled social login providers
+	OAuthProviders() []string
+
+	// StartOAuthLogin returns the sign-in URL of a social login provider
+	StartOAuthLogin(provider string) (*OAuthStartOutput, error)
+
+	// OAuthLogin signs a user in with a social login provider, linking or creating the account
+	OAuthLogin(ctx context.Context, provider string, input *OAuthCallbackInput) (*AuthOutput, error)
+
+	// StartOAuthLink returns the sign-in URL of a provider for linking it to the signed-in user
+	StartOAuthLink(userID uuid.UUID, provider string) (*OAuthStartOutput, error)
+
+	// LinkOAuthIdentity links the provider account from the callback to the signed-in user
+	LinkOAuthIdentity(ctx context.Context, userID uuid.UUID, provider string, input *OAuthCallbackInput) (*domain.UserIdentity, error)
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// Impersonate issues an access token that lets a user with the impersonate permission act as another user
+func (uc *authUseCase) Impersonate(ctx context.Context, actorID, userID uuid.UUID, client *ClientInfo) (*ImpersonationOutput, error) {
+	if actorID == userID {
+		return nil, domain.ErrCannotImpersonate
+	}
+
+	actor, err := uc.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.IsActive {
+		return nil, domain.ErrCannotImpersonate
+	}
+	canImpersonate, err := uc.permissionUseCase.HasPermission(ctx, actor.Role, domain.PermissionUsersImpersonate)
+	if err != nil {
+		return nil, err
+	}
+	if !canImpersonate {
+		return nil, domain.ErrCannotImpersonate
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Acting as someone who manages users would hand out those rights without their second factor
+	for _, permission := range []domain.Permission{domain.PermissionUsersImpersonate, domain.PermissionUsersManage} {
+		privileged, err := uc.permissionUseCase.HasPermission(ctx, user.Role, permission)
+		if err != nil {
+			return nil, err
+		}
+		if privileged {
+			return nil, domain.ErrCannotImpersonate
+		}
+	}
+	if !user.IsActive {
+		return nil, domain.ErrUserNotActive
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(uc.jwtConfig.ImpersonationExpiry)
+
+	claims := &accessTokenClaims{
+		Role:         user.Role,
+		TokenVersion: user.TokenVersion,
+		Type:         "access",
+		Actor: &actorClaim{
+			Subject:      actor.ID.String(),
+			TokenVersion: actor.TokenVersion,
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.String(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	tokenString, err := uc.accessTokenKeys.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// Recorded on the impersonated user so it shows up in their security history
+	event := &domain.SecurityEvent{
+		UserID:    &user.ID,
+		EventType: domain.SecurityEventImpersonation,
+		IPAddress: optionalString(client.IPAddress),
+		UserAgent: optionalString(client.UserAgent),
+		Metadata: map[string]interface{}{
+			"actor_id":    actor.ID,
+			"actor_email": actor.Email,
+			"expires_at":  expiresAt,
+		},
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		// An impersonation that cannot be audited is not allowed
+		return nil, err
+	}
+
+	return &ImpersonationOutput{
+		User:        user,
+		AccessToken: tokenString,
+		ExpiresIn:   int64(uc.jwtConfig.ImpersonationExpiry.Seconds()),
+		ActorID:     actor.ID,
+	}, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"log"
 	"regexp"
 	"time"
 
@@ -12,29 +14,93 @@ import (
 	"github.com/mathvn/backend/config"
 	"github.com/mathvn/backend/internal/domain"
 	"github.com/mathvn/backend/internal/repository"
+	"github.com/mathvn/backend/pkg/jwtkeys"
+	"github.com/mathvn/backend/pkg/mailer"
+	"github.com/mathvn/backend/pkg/oauth"
+	"github.com/mathvn/backend/pkg/sms"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // authUseCase implements AuthUseCase
 type authUseCase struct {
-	userRepo         repository.UserRepository
-	refreshTokenRepo repository.RefreshTokenRepository
-	jwtConfig        config.JWTConfig
-	bcryptCost       int
+	userRepo              repository.UserRepository
+	refreshTokenRepo      repository.RefreshTokenRepository
+	verificationTokenRepo repository.EmailVerificationTokenRepository
+	passwordResetRepo     repository.PasswordResetTokenRepository
+	securityEventRepo     repository.SecurityEventRepository
+	loginAttemptRepo      repository.LoginAttemptRepository
+	mfaRepo               repository.MFARepository
+	identityRepo          repository.UserIdentityRepository
+	loginOTPRepo          repository.LoginOTPRepository
+	mailer                mailer.Mailer
+	smsSender             sms.Sender
+	oauthProviders        oauth.Registry
+	permissionUseCase     PermissionUseCase
+	jwtConfig             config.JWTConfig
+	accessTokenKeys       *jwtkeys.KeySet
+	verificationConfig    config.VerificationConfig
+	passwordResetConfig   config.PasswordResetConfig
+	sessionConfig         config.SessionConfig
+	loginLimitConfig      config.LoginLimitConfig
+	mfaConfig             config.MFAConfig
+	oauthConfig           config.OAuthConfig
+	otpLoginConfig        config.OTPLoginConfig
+	bcryptCost            int
+	tokenVersions         *TokenVersionCache
 }
 
 // NewAuthUseCase creates a new auth use case
 func NewAuthUseCase(
 	userRepo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	verificationTokenRepo repository.EmailVerificationTokenRepository,
+	passwordResetRepo repository.PasswordResetTokenRepository,
+	securityEventRepo repository.SecurityEventRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	mfaRepo repository.MFARepository,
+	identityRepo repository.UserIdentityRepository,
+	loginOTPRepo repository.LoginOTPRepository,
+	mailSender mailer.Mailer,
+	smsSender sms.Sender,
+	oauthProviders oauth.Registry,
+	permissionUseCase PermissionUseCase,
+	tokenVersions *TokenVersionCache,
 	jwtConfig config.JWTConfig,
+	accessTokenKeys *jwtkeys.KeySet,
+	verificationConfig config.VerificationConfig,
+	passwordResetConfig config.PasswordResetConfig,
+	sessionConfig config.SessionConfig,
+	loginLimitConfig config.LoginLimitConfig,
+	mfaConfig config.MFAConfig,
+	oauthConfig config.OAuthConfig,
+	otpLoginConfig config.OTPLoginConfig,
 	bcryptCost int,
 ) AuthUseCase {
 	return &authUseCase{
-		userRepo:         userRepo,
-		refreshTokenRepo: refreshTokenRepo,
-		jwtConfig:        jwtConfig,
-		bcryptCost:       bcryptCost,
+		userRepo:              userRepo,
+		refreshTokenRepo:      refreshTokenRepo,
+		verificationTokenRepo: verificationTokenRepo,
+		passwordResetRepo:     passwordResetRepo,
+		securityEventRepo:     securityEventRepo,
+		loginAttemptRepo:      loginAttemptRepo,
+		mfaRepo:               mfaRepo,
+		identityRepo:          identityRepo,
+		loginOTPRepo:          loginOTPRepo,
+		mailer:                mailSender,
+		smsSender:             smsSender,
+		oauthProviders:        oauthProviders,
+		permissionUseCase:     permissionUseCase,
+		jwtConfig:             jwtConfig,
+		accessTokenKeys:       accessTokenKeys,
+		verificationConfig:    verificationConfig,
+		passwordResetConfig:   passwordResetConfig,
+		sessionConfig:         sessionConfig,
+		loginLimitConfig:      loginLimitConfig,
+		mfaConfig:             mfaConfig,
+		oauthConfig:           oauthConfig,
+		otpLoginConfig:        otpLoginConfig,
+		bcryptCost:            bcryptCost,
+		tokenVersions:         tokenVersions,
 	}
 }
 
@@ -50,6 +116,15 @@ func (uc *authUseCase) Register(ctx context.Context, input *RegisterInput) (*Aut
 		return nil, domain.ErrInvalidPassword
 	}
 
+	// Only student and parent accounts can sign up, staff accounts are created by an admin
+	role := domain.RoleStudent
+	if input.Role != "" {
+		if input.Role != domain.RoleStudent && input.Role != domain.RoleParent {
+			return nil, domain.ErrInvalidSignupRole
+		}
+		role = input.Role
+	}
+
 	// Validate phone number (required)
 	if !isValidPhoneNumber(input.PhoneNumber) {
 		return nil, domain.ErrInvalidPhoneNumber
@@ -83,7 +158,7 @@ func (uc *authUseCase) Register(ctx context.Context, input *RegisterInput) (*Aut
 		PasswordHash: string(hashedPassword),
 		FullName:     input.FullName,
 		PhoneNumber:  input.PhoneNumber,
-		Role:         domain.RoleStudent, // Default role
+		Role:         role,
 		IsActive:     true,
 		IsVerified:   false, // Require email verification
 	}
@@ -92,50 +167,40 @@ func (uc *authUseCase) Register(ctx context.Context, input *RegisterInput) (*Aut
 		return nil, err
 	}
 
-	// Generate access and refresh tokens
-	accessToken, expiresIn, err := uc.generateAccessToken(user.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	refreshToken, err := uc.generateRefreshToken(ctx, user.ID)
-	if err != nil {
-		return nil, err
+	// Send verification email, the user can request a new one if this fails
+	if err := uc.sendVerificationEmail(ctx, user); err != nil {
+		log.Printf("failed to send verification email to %s: %v", user.Email, err)
 	}
 
-	return &AuthOutput{
-		User:         user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    expiresIn,
-	}, nil
+	return uc.issueTokens(ctx, user, &input.ClientInfo, false)
 }
 
 // Login authenticates a user and returns tokens
 func (uc *authUseCase) Login(ctx context.Context, input *LoginInput) (*AuthOutput, error) {
-	var user *domain.User
-	var err error
-
-	// Determine if input is email or phone number
-	if isValidEmail(input.EmailOrPhone) {
-		// Login with email
-		user, err = uc.userRepo.GetByEmail(ctx, input.EmailOrPhone)
-	} else if isValidPhoneNumber(input.EmailOrPhone) {
-		// Login with phone number
-		user, err = uc.userRepo.GetByPhoneNumber(ctx, input.EmailOrPhone)
-	} else {
-		return nil, domain.ErrInvalidCredentials
+	user, err := uc.findUserByEmailOrPhone(ctx, input.EmailOrPhone)
+	if err != nil && err != domain.ErrUserNotFound && err != domain.ErrInvalidEmailOrPhone {
+		return nil, err
+	}
+	var userID *uuid.UUID
+	if user != nil {
+		userID = &user.ID
 	}
 
-	if err != nil {
-		if err == domain.ErrUserNotFound {
-			return nil, domain.ErrInvalidCredentials
-		}
+	// Count the attempt against the account and the client IP before the password is checked,
+	// so concurrent guesses cannot all pass before a lockout is written
+	limits := uc.loginLimits(input)
+	if err := uc.takeAttempt(ctx, limits, userID, &input.ClientInfo); err != nil {
 		return nil, err
 	}
 
+	// Unknown accounts count as failed logins, like wrong passwords
+	if user == nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+
 	// Check if user is active
 	if !user.IsActive {
+		uc.releaseAttempts(ctx, limits)
 		return nil, domain.ErrUserNotActive
 	}
 
@@ -143,18 +208,46 @@ func (uc *authUseCase) Login(ctx context.Context, input *LoginInput) (*AuthOutpu
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
 		return nil, domain.ErrInvalidCredentials
 	}
+	uc.releaseAttempts(ctx, limits)
+	uc.resetLoginFailures(ctx, input)
+
+	return uc.completeLogin(ctx, user, &input.ClientInfo)
+}
 
-	// Generate access and refresh tokens
-	accessToken, expiresIn, err := uc.generateAccessToken(user.ID)
+// completeLogin finishes a login after the first factor was checked
+func (uc *authUseCase) completeLogin(ctx context.Context, user *domain.User, client *ClientInfo) (*AuthOutput, error) {
+	// Accounts with two-factor authentication get a challenge instead of tokens
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrMFANotEnabled) {
+		return nil, err
+	}
+	if mfa != nil && mfa.IsEnabled() {
+		return uc.newMFAChallenge(user)
+	}
+
+	output, err := uc.issueTokens(ctx, user, client, false)
 	if err != nil {
 		return nil, err
 	}
+	output.MFASetupRequired = uc.IsMFARequired(user.Role)
 
-	refreshToken, err := uc.generateRefreshToken(ctx, user.ID)
+	return output, nil
+}
+
+// issueTokens generates the access and refresh tokens of a new session
+func (uc *authUseCase) issueTokens(ctx context.Context, user *domain.User, client *ClientInfo, mfaVerified bool) (*AuthOutput, error) {
+	accessToken, expiresIn, err := uc.generateAccessToken(user, mfaVerified)
 	if err != nil {
 		return nil, err
 	}
 
+	refreshToken, err := uc.generateRefreshToken(ctx, user, client, mfaVerified)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.cancelPendingDeletion(ctx, user, client)
+
 	return &AuthOutput{
 		User:         user,
 		AccessToken:  accessToken,
@@ -163,6 +256,29 @@ func (uc *authUseCase) Login(ctx context.Context, input *LoginInput) (*AuthOutpu
 	}, nil
 }
 
+// cancelPendingDeletion keeps an account whose owner logs in again during the deletion grace period.
+// Failures are only logged, the login itself succeeded.
+func (uc *authUseCase) cancelPendingDeletion(ctx context.Context, user *domain.User, client *ClientInfo) {
+	cancelled, err := uc.userRepo.CancelDeletion(ctx, user.ID)
+	if err != nil {
+		log.Printf("failed to cancel deletion of user %s: %v", user.ID, err)
+		return
+	}
+	if !cancelled {
+		return
+	}
+
+	event := &domain.SecurityEvent{
+		UserID:    &user.ID,
+		EventType: domain.SecurityEventDeletionCancelled,
+		IPAddress: optionalString(client.IPAddress),
+		UserAgent: optionalString(client.UserAgent),
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record security event for user %s: %v", user.ID, err)
+	}
+}
+
 // GetProfile retrieves the current user's profile
 func (uc *authUseCase) GetProfile(ctx context.Context, userID uuid.UUID) (*UserOutput, error) {
 	user, err := uc.userRepo.GetByID(ctx, userID)
@@ -233,6 +349,13 @@ func (uc *authUseCase) UpdateProfile(ctx context.Context, userID uuid.UUID, inpu
 		return nil, err
 	}
 
+	// Ask the user to verify the new email address
+	if !user.IsVerified {
+		if err := uc.sendVerificationEmail(ctx, user); err != nil {
+			log.Printf("failed to send verification email to %s: %v", user.Email, err)
+		}
+	}
+
 	return &UserOutput{
 		ID:          user.ID,
 		Email:       user.Email,
@@ -279,58 +402,121 @@ func (uc *authUseCase) ChangePassword(ctx context.Context, userID uuid.UUID, inp
 	return nil
 }
 
-// ValidateToken validates a JWT access token and returns the user ID
-func (uc *authUseCase) ValidateToken(tokenString string) (uuid.UUID, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, domain.ErrInvalidToken
-		}
-		return []byte(uc.jwtConfig.AccessTokenSecret), nil
-	})
+// accessTokenClaims is the JWT payload of an access token
+type accessTokenClaims struct {
+	Role         domain.UserRole `json:"role"`
+	TokenVersion int             `json:"token_version"`
+	MFAVerified  bool            `json:"mfa,omitempty"`
+	Type         string          `json:"type"`
+	Actor        *actorClaim     `json:"act,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// actorClaim is the RFC 8693 actor of an impersonation token
+type actorClaim struct {
+	Subject      string `json:"sub"`
+	TokenVersion int    `json:"token_version"`
+}
+
+// ValidateToken validates a JWT access token and returns its claims
+func (uc *authUseCase) ValidateToken(tokenString string) (*AccessTokenClaims, error) {
+	claims := &accessTokenClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, uc.accessTokenKeys.Keyfunc,
+		jwt.WithValidMethods(uc.accessTokenKeys.Algorithms()),
+	)
 
 	if err != nil {
-		return uuid.Nil, domain.ErrInvalidToken
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, domain.ErrTokenExpired
+		}
+		return nil, domain.ErrInvalidToken
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return uuid.Nil, domain.ErrInvalidToken
+	if !token.Valid || claims.Type != "access" || claims.ExpiresAt == nil {
+		return nil, domain.ErrInvalidToken
 	}
 
-	// Check expiration
-	exp, ok := claims["exp"].(float64)
-	if !ok || time.Now().Unix() > int64(exp) {
-		return uuid.Nil, domain.ErrTokenExpired
+	// Get user ID from claims
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
 	}
 
-	// Get user ID from claims
-	userIDStr, ok := claims["sub"].(string)
-	if !ok {
-		return uuid.Nil, domain.ErrInvalidToken
+	result := &AccessTokenClaims{
+		UserID:       userID,
+		Role:         claims.Role,
+		TokenVersion: claims.TokenVersion,
+		MFAVerified:  claims.MFAVerified,
+		ExpiresAt:    claims.ExpiresAt.Time,
 	}
 
-	userID, err := uuid.Parse(userIDStr)
+	if claims.Actor != nil {
+		actorID, err := uuid.Parse(claims.Actor.Subject)
+		if err != nil {
+			return nil, domain.ErrInvalidToken
+		}
+		result.ActorID = &actorID
+		result.ActorTokenVersion = claims.Actor.TokenVersion
+	}
+
+	return result, nil
+}
+
+// CheckTokenVersion rejects access tokens issued before the user's token version was bumped
+func (uc *authUseCase) CheckTokenVersion(ctx context.Context, claims *AccessTokenClaims) error {
+	version, err := uc.tokenVersions.get(ctx, claims.UserID)
 	if err != nil {
-		return uuid.Nil, domain.ErrInvalidToken
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrTokenRevoked
+		}
+		return err
 	}
 
-	return userID, nil
+	if version != claims.TokenVersion {
+		return domain.ErrTokenRevoked
+	}
+
+	// Impersonation ends as soon as the admin is demoted, deactivated or logs out everywhere
+	if claims.ActorID != nil {
+		actorVersion, err := uc.tokenVersions.get(ctx, *claims.ActorID)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return domain.ErrTokenRevoked
+			}
+			return err
+		}
+		if actorVersion != claims.ActorTokenVersion {
+			return domain.ErrTokenRevoked
+		}
+	}
+
+	return nil
+}
+
+// GetJWKS returns the public keys that verify access tokens
+func (uc *authUseCase) GetJWKS() *jwtkeys.JWKS {
+	return uc.accessTokenKeys.JWKS()
 }
 
 // generateAccessToken generates a JWT access token for the user
-func (uc *authUseCase) generateAccessToken(userID uuid.UUID) (string, int64, error) {
-	expiresAt := time.Now().Add(uc.jwtConfig.AccessTokenExpiryTime)
+func (uc *authUseCase) generateAccessToken(user *domain.User, mfaVerified bool) (string, int64, error) {
+	now := time.Now()
+	expiresAt := now.Add(uc.jwtConfig.AccessTokenExpiryTime)
 	expiresIn := int64(uc.jwtConfig.AccessTokenExpiryTime.Seconds())
 
-	claims := jwt.MapClaims{
-		"sub":  userID.String(),
-		"exp":  expiresAt.Unix(),
-		"iat":  time.Now().Unix(),
-		"type": "access",
+	claims := &accessTokenClaims{
+		Role:         user.Role,
+		TokenVersion: user.TokenVersion,
+		MFAVerified:  mfaVerified,
+		Type:         "access",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.String(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(uc.jwtConfig.AccessTokenSecret))
+	tokenString, err := uc.accessTokenKeys.Sign(claims)
 	if err != nil {
 		return "", 0, err
 	}
@@ -338,8 +524,30 @@ func (uc *authUseCase) generateAccessToken(userID uuid.UUID) (string, int64, err
 	return tokenString, expiresIn, nil
 }
 
-// generateRefreshToken generates a refresh token and stores it in the database
-func (uc *authUseCase) generateRefreshToken(ctx context.Context, userID uuid.UUID) (string, error) {
+// generateRefreshToken generates a refresh token for a new login and stores it in the database.
+// The user's concurrent device limit is enforced here.
+func (uc *authUseCase) generateRefreshToken(ctx context.Context, user *domain.User, client *ClientInfo, mfaVerified bool) (string, error) {
+	tokenString, refreshToken, err := uc.newRefreshToken(user.ID, uuid.Nil)
+	if err != nil {
+		return "", err
+	}
+	refreshToken.DeviceName = deviceName(client.DeviceName, client.UserAgent)
+	refreshToken.UserAgent = client.UserAgent
+	refreshToken.IPAddress = client.IPAddress
+	refreshToken.MFAVerified = mfaVerified
+
+	// Store refresh token in database
+	evictOldest := uc.sessionConfig.LimitPolicy != config.SessionLimitPolicyReject
+	if err := uc.refreshTokenRepo.CreateWithinLimit(ctx, refreshToken, uc.sessionLimit(user), evictOldest); err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// newRefreshToken signs a refresh token and builds its database record without storing it.
+// A nil familyID starts a new rotation family.
+func (uc *authUseCase) newRefreshToken(userID, familyID uuid.UUID) (string, *domain.RefreshToken, error) {
 	// Generate JWT refresh token
 	expiresAt := time.Now().Add(uc.jwtConfig.RefreshTokenExpiryTime)
 
@@ -354,29 +562,31 @@ func (uc *authUseCase) generateRefreshToken(ctx context.Context, userID uuid.UUI
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(uc.jwtConfig.RefreshTokenSecret))
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	// Hash the token before storing
-	tokenHash := uc.hashRefreshToken(tokenString)
-
-	// Store refresh token in database
 	refreshToken := &domain.RefreshToken{
+		ID:        uuid.New(),
 		UserID:    userID,
-		TokenHash: tokenHash,
+		TokenHash: uc.hashRefreshToken(tokenString), // Hash the token before storing
+		FamilyID:  familyID,
 		ExpiresAt: expiresAt,
 		IsRevoked: false,
 	}
-
-	if err := uc.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
-		return "", err
+	if familyID == uuid.Nil {
+		refreshToken.FamilyID = refreshToken.ID
 	}
 
-	return tokenString, nil
+	return tokenString, refreshToken, nil
 }
 
 // hashRefreshToken hashes a refresh token using SHA256
 func (uc *authUseCase) hashRefreshToken(token string) string {
+	return hashToken(token)
+}
+
+// hashToken hashes an opaque token using SHA256 so only the hash is stored
+func hashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(hash[:])
 }
@@ -432,6 +642,11 @@ func (uc *authUseCase) RefreshToken(ctx context.Context, input *RefreshTokenInpu
 
 	// Check if token is revoked or expired
 	if !storedToken.IsValid() {
+		if storedToken.IsRotated() {
+			// A rotated token presented again means it leaked, end the whole login
+			uc.handleRefreshTokenReuse(ctx, storedToken, &input.ClientInfo)
+			return nil, domain.ErrRefreshTokenReused
+		}
 		if storedToken.IsRevoked {
 			return nil, domain.ErrRefreshTokenRevoked
 		}
@@ -455,22 +670,39 @@ func (uc *authUseCase) RefreshToken(ctx context.Context, input *RefreshTokenInpu
 	}
 
 	// Generate new access token
-	accessToken, expiresIn, err := uc.generateAccessToken(userID)
+	accessToken, expiresIn, err := uc.generateAccessToken(user, storedToken.MFAVerified)
 	if err != nil {
 		return nil, err
 	}
 
-	// Optionally rotate refresh token (best practice: generate new refresh token)
-	// For now, we'll keep the same refresh token, but you can implement rotation if needed
-	newRefreshToken, err := uc.generateRefreshToken(ctx, userID)
+	// Rotate the refresh token, the new one stays in the same family
+	newRefreshToken, nextToken, err := uc.newRefreshToken(userID, storedToken.FamilyID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Revoke old refresh token
-	if err := uc.refreshTokenRepo.Revoke(ctx, tokenHash); err != nil {
-		// Log error but don't fail the request
-		_ = err
+	// Keep the session's device details, the client address may have changed since the last refresh
+	nextToken.MFAVerified = storedToken.MFAVerified
+	nextToken.DeviceName = storedToken.DeviceName
+	if input.DeviceName != "" {
+		nextToken.DeviceName = input.DeviceName
+	}
+	nextToken.UserAgent = input.UserAgent
+	if nextToken.UserAgent == "" {
+		nextToken.UserAgent = storedToken.UserAgent
+	}
+	nextToken.IPAddress = input.IPAddress
+	if nextToken.IPAddress == "" {
+		nextToken.IPAddress = storedToken.IPAddress
+	}
+
+	if err := uc.refreshTokenRepo.Rotate(ctx, storedToken.ID, nextToken); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenRevoked) {
+			// Another request rotated the same token first
+			uc.handleRefreshTokenReuse(ctx, storedToken, &input.ClientInfo)
+			return nil, domain.ErrRefreshTokenReused
+		}
+		return nil, err
 	}
 
 	return &AuthOutput{
@@ -481,6 +713,28 @@ func (uc *authUseCase) RefreshToken(ctx context.Context, input *RefreshTokenInpu
 	}, nil
 }
 
+// handleRefreshTokenReuse revokes every token of the family and records a security event.
+// Failures are only logged because the request is rejected anyway.
+func (uc *authUseCase) handleRefreshTokenReuse(ctx context.Context, token *domain.RefreshToken, client *ClientInfo) {
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+		log.Printf("failed to revoke refresh token family %s: %v", token.FamilyID, err)
+	}
+
+	event := &domain.SecurityEvent{
+		UserID:    &token.UserID,
+		EventType: domain.SecurityEventRefreshTokenReuse,
+		IPAddress: optionalString(client.IPAddress),
+		UserAgent: optionalString(client.UserAgent),
+		Metadata: map[string]interface{}{
+			"family_id": token.FamilyID.String(),
+			"token_id":  token.ID.String(),
+		},
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record security event for user %s: %v", token.UserID, err)
+	}
+}
+
 // Logout revokes a refresh token
 func (uc *authUseCase) Logout(ctx context.Context, refreshToken string) error {
 	tokenHash := uc.hashRefreshToken(refreshToken)
@@ -489,7 +743,29 @@ func (uc *authUseCase) Logout(ctx context.Context, refreshToken string) error {
 
 // LogoutAll revokes all refresh tokens for a user
 func (uc *authUseCase) LogoutAll(ctx context.Context, userID uuid.UUID) error {
-	return uc.refreshTokenRepo.RevokeAllForUser(ctx, userID)
+	if err := uc.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return err
+	}
+
+	// Also end access tokens that are still within their lifetime
+	if err := uc.userRepo.IncrementTokenVersion(ctx, userID); err != nil {
+		return err
+	}
+	uc.tokenVersions.Invalidate(userID)
+
+	return nil
+}
+
+// findUserByEmailOrPhone looks up a user by an identifier that is either an email or a phone number
+func (uc *authUseCase) findUserByEmailOrPhone(ctx context.Context, emailOrPhone string) (*domain.User, error) {
+	// Determine if input is email or phone number
+	if isValidEmail(emailOrPhone) {
+		return uc.userRepo.GetByEmail(ctx, emailOrPhone)
+	}
+	if isValidPhoneNumber(emailOrPhone) {
+		return uc.userRepo.GetByPhoneNumber(ctx, emailOrPhone)
+	}
+	return nil, domain.ErrInvalidEmailOrPhone
 }
 
 // isValidEmail validates email format
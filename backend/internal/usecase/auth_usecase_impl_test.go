@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/config"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+	"github.com/mathvn/backend/internal/repository/memory"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeUserRepository finds one user by email
+type fakeUserRepository struct {
+	repository.UserRepository
+	user    *domain.User
+	readers *sync.WaitGroup // When set, lookups wait until every reader has read the user
+}
+
+func (r *fakeUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if r.readers != nil {
+		r.readers.Done()
+		r.readers.Wait()
+	}
+	if r.user.Email != email {
+		return nil, domain.ErrUserNotFound
+	}
+	copied := *r.user
+	return &copied, nil
+}
+
+func TestLoginConcurrentWrongPasswordsStopAtLimit(t *testing.T) {
+	const (
+		guessers    = 20
+		maxAttempts = 3
+	)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &domain.User{
+		ID:           uuid.New(),
+		Email:        "student@example.com",
+		PasswordHash: string(hash),
+		Role:         domain.RoleStudent,
+		IsActive:     true,
+	}
+
+	// Every guesser looks the account up before any of them checks a password
+	var readers sync.WaitGroup
+	readers.Add(guessers)
+
+	attempts := memory.NewLoginAttemptRepository()
+	uc := &authUseCase{
+		userRepo:          &fakeUserRepository{user: user, readers: &readers},
+		loginAttemptRepo:  attempts,
+		securityEventRepo: &fakeSecurityEventRepository{},
+		loginLimitConfig: config.LoginLimitConfig{
+			MaxAccountAttempts:  maxAttempts,
+			AttemptWindow:       time.Hour,
+			BaseLockoutDuration: time.Minute,
+			MaxLockoutDuration:  time.Hour,
+		},
+	}
+
+	start := make(chan struct{})
+	errs := make([]error, guessers)
+	var wg sync.WaitGroup
+	for i := 0; i < guessers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = uc.Login(context.Background(), &LoginInput{EmailOrPhone: user.Email, Password: "wrong-password"})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	invalid, locked := 0, 0
+	for _, err := range errs {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCredentials):
+			invalid++
+		case errors.Is(err, domain.ErrAccountLocked):
+			locked++
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if invalid != maxAttempts {
+		t.Errorf("got %d passwords checked, want %d", invalid, maxAttempts)
+	}
+	if locked != guessers-maxAttempts {
+		t.Errorf("got %d locked errors, want %d", locked, guessers-maxAttempts)
+	}
+
+	attempt, err := attempts.Get(context.Background(), loginAccountKey(user.Email))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !attempt.IsLocked() {
+		t.Error("account is not locked out")
+	}
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// loginAccountKey returns the failed login key of an email or phone number
+func loginAccountKey(identifier string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(identifier))
+}
+
+// mfaAttemptKey returns the failed two-factor code key of a user
+func mfaAttemptKey(userID uuid.UUID) string {
+	return "mfa:" + userID.String()
+}
+
+// loginIPKey returns the failed login key of a client IP
+func loginIPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// attemptLimit is a failed login key and the number of failures it may reach
+type attemptLimit struct {
+	key         string
+	maxAttempts int
+}
+
+// loginLimits returns the failed login keys of a login, the client IP is skipped when unknown
+func (uc *authUseCase) loginLimits(input *LoginInput) []attemptLimit {
+	limits := []attemptLimit{{loginAccountKey(input.EmailOrPhone), uc.loginLimitConfig.MaxAccountAttempts}}
+	if input.IPAddress != "" {
+		limits = append(limits, attemptLimit{loginIPKey(input.IPAddress), uc.loginLimitConfig.MaxIPAttempts})
+	}
+	return limits
+}
+
+// checkLocked returns ErrAccountLocked if any of the keys is locked out
+func (uc *authUseCase) checkLocked(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		attempt, err := uc.loginAttemptRepo.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if attempt.IsLocked() {
+			return domain.ErrAccountLocked
+		}
+	}
+
+	return nil
+}
+
+// takeAttempt counts a try against every key before the password or code is checked, and returns
+// ErrAccountLocked if any key is locked or has no attempts left. Counting first makes the check
+// atomic: concurrent guesses each get their own count, so no more than the limit are checked
+// before the lockout is written. Tries that succeed, or fail for another reason than a wrong
+// secret, give their attempt back with releaseAttempts.
+func (uc *authUseCase) takeAttempt(ctx context.Context, limits []attemptLimit, userID *uuid.UUID, client *ClientInfo) error {
+	locked := false
+	var taken []string
+	for _, limit := range limits {
+		attempt, err := uc.loginAttemptRepo.RegisterFailure(ctx, limit.key, uc.loginLimitConfig.AttemptWindow)
+		if err != nil {
+			uc.removeFailures(ctx, taken...)
+			return err
+		}
+
+		switch {
+		case attempt.IsLocked():
+			// Requests refused during a lockout do not lengthen the next one
+			uc.removeFailures(ctx, limit.key)
+			locked = true
+		case limit.maxAttempts > 0 && attempt.FailedCount > limit.maxAttempts:
+			// The attempt stays counted, so each lockout after this one is longer
+			uc.lockLogins(ctx, attempt, limit.maxAttempts, userID, client)
+			locked = true
+		default:
+			taken = append(taken, limit.key)
+		}
+	}
+
+	if locked {
+		uc.removeFailures(ctx, taken...)
+		return domain.ErrAccountLocked
+	}
+
+	return nil
+}
+
+// releaseAttempts gives back the attempt taken for each key
+func (uc *authUseCase) releaseAttempts(ctx context.Context, limits []attemptLimit) {
+	for _, limit := range limits {
+		uc.removeFailures(ctx, limit.key)
+	}
+}
+
+// removeFailures takes back one counted attempt of each key.
+// Errors are only logged, the key then keeps one attempt too many until its window ends.
+func (uc *authUseCase) removeFailures(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := uc.loginAttemptRepo.RemoveFailure(ctx, key); err != nil {
+			log.Printf("failed to release login attempt for %s: %v", key, err)
+		}
+	}
+}
+
+// lockLogins locks a key out once it has used up its attempts, longer for each attempt
+// past the limit. Errors are only logged because the request is rejected anyway.
+func (uc *authUseCase) lockLogins(ctx context.Context, attempt *domain.LoginAttempt, maxAttempts int, userID *uuid.UUID, client *ClientInfo) {
+	key := attempt.Key
+	lockedUntil := time.Now().Add(uc.lockoutDuration(attempt.FailedCount - maxAttempts - 1))
+	if err := uc.loginAttemptRepo.Lock(ctx, key, lockedUntil); err != nil {
+		log.Printf("failed to lock logins for %s: %v", key, err)
+		return
+	}
+
+	event := &domain.SecurityEvent{
+		UserID:    userID,
+		EventType: domain.SecurityEventLoginLocked,
+		IPAddress: optionalString(client.IPAddress),
+		UserAgent: optionalString(client.UserAgent),
+		Metadata: map[string]interface{}{
+			"key":          key,
+			"failed_count": attempt.FailedCount,
+			"locked_until": lockedUntil,
+		},
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record security event for %s: %v", key, err)
+	}
+}
+
+// resetLoginFailures clears the failed logins of an account after a successful login.
+// The client IP keeps its count so one valid account cannot hide guessing on others.
+func (uc *authUseCase) resetLoginFailures(ctx context.Context, input *LoginInput) {
+	if err := uc.loginAttemptRepo.Reset(ctx, loginAccountKey(input.EmailOrPhone)); err != nil {
+		log.Printf("failed to reset failed logins: %v", err)
+	}
+}
+
+// lockoutDuration returns how long a login key stays locked once it is excess failures past the limit
+func (uc *authUseCase) lockoutDuration(excess int) time.Duration {
+	return progressiveLockout(uc.loginLimitConfig.BaseLockoutDuration, uc.loginLimitConfig.MaxLockoutDuration, excess)
+}
+
+// progressiveLockout doubles the base lockout for every failure past the limit, up to the maximum
+func progressiveLockout(base, max time.Duration, excess int) time.Duration {
+	duration := base
+	for i := 0; i < excess && duration < max; i++ {
+		duration *= 2
+	}
+	if duration > max {
+		duration = max
+	}
+	return duration
+}
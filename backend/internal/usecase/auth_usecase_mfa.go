@@ -0,0 +1,365 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/pkg/totp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// totpSkew accepts codes from one step before or after the current one for clock drift
+	totpSkew = 1
+
+	// recoveryCodeLength is the number of characters of a recovery code, shown in two halves
+	recoveryCodeLength = 10
+
+	// recoveryCodeAlphabet leaves out characters that are easy to confuse
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// GetMFAStatus retrieves the two-factor authentication state of a user
+func (uc *authUseCase) GetMFAStatus(ctx context.Context, userID uuid.UUID) (*MFAStatusOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MFAStatusOutput{Required: uc.IsMFARequired(user.Role)}
+
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMFANotEnabled) {
+			return status, nil
+		}
+		return nil, err
+	}
+
+	if mfa.IsEnabled() {
+		status.Enabled = true
+		status.RecoveryCodesRemaining, err = uc.mfaRepo.CountUnusedRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// SetupTOTP generates a new TOTP secret that must be confirmed with ConfirmTOTP
+func (uc *authUseCase) SetupTOTP(ctx context.Context, userID uuid.UUID) (*TOTPSetupOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// Fails with ErrMFAAlreadyEnabled, a confirmed secret is never replaced
+	if err := uc.mfaRepo.SavePending(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &TOTPSetupOutput{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(uc.mfaConfig.Issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication and returns the recovery codes
+func (uc *authUseCase) ConfirmTOTP(ctx context.Context, userID uuid.UUID, input *MFACodeInput) (*RecoveryCodesOutput, error) {
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMFANotEnabled) {
+			return nil, domain.ErrMFASetupNotStarted
+		}
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	// The attempt is counted before the code is validated, so parallel guesses cannot exceed the limit
+	limits := uc.mfaLimits(userID)
+	if err := uc.takeAttempt(ctx, limits, &userID, &ClientInfo{}); err != nil {
+		return nil, err
+	}
+
+	// Only a TOTP code proves the authenticator app was set up, there are no recovery codes yet
+	step, ok := totp.Validate(mfa.TOTPSecret, input.Code, time.Now(), totpSkew)
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+	if err := uc.loginAttemptRepo.Reset(ctx, mfaAttemptKey(userID)); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes(uc.mfaConfig.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.mfaRepo.Enable(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	// Sessions opened with only a password have to sign in again with the second factor
+	if err := uc.LogoutAll(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesOutput{RecoveryCodes: codes}, nil
+}
+
+// DisableMFA turns off two-factor authentication
+func (uc *authUseCase) DisableMFA(ctx context.Context, userID uuid.UUID, input *DisableMFAInput) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if uc.IsMFARequired(user.Role) {
+		return domain.ErrMFARequired
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
+		return domain.ErrInvalidCredentials
+	}
+
+	mfa, err := uc.getEnabledMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.checkMFACode(ctx, mfa, input.Code, &ClientInfo{}); err != nil {
+		return err
+	}
+
+	return uc.mfaRepo.Delete(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user
+func (uc *authUseCase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, input *MFACodeInput) (*RecoveryCodesOutput, error) {
+	mfa, err := uc.getEnabledMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkMFACode(ctx, mfa, input.Code, &ClientInfo{}); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes(uc.mfaConfig.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesOutput{RecoveryCodes: codes}, nil
+}
+
+// VerifyMFA completes a login that returned an MFA challenge
+func (uc *authUseCase) VerifyMFA(ctx context.Context, input *VerifyMFAInput) (*AuthOutput, error) {
+	userID, err := uc.parseMFAChallenge(input.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidMFAToken
+		}
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrUserNotActive
+	}
+
+	mfa, err := uc.getEnabledMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMFANotEnabled) {
+			return nil, domain.ErrInvalidMFAToken
+		}
+		return nil, err
+	}
+
+	if err := uc.checkMFACode(ctx, mfa, input.Code, &input.ClientInfo); err != nil {
+		return nil, err
+	}
+
+	return uc.issueTokens(ctx, user, &input.ClientInfo, true)
+}
+
+// IsMFARequired checks if the role must use two-factor authentication
+func (uc *authUseCase) IsMFARequired(role domain.UserRole) bool {
+	for _, required := range uc.mfaConfig.RequiredRoles {
+		if domain.UserRole(required) == role {
+			return true
+		}
+	}
+	return false
+}
+
+// getEnabledMFA retrieves a confirmed enrollment, ErrMFANotEnabled otherwise
+func (uc *authUseCase) getEnabledMFA(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error) {
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !mfa.IsEnabled() {
+		return nil, domain.ErrMFANotEnabled
+	}
+	return mfa, nil
+}
+
+// checkMFACode verifies a TOTP or recovery code. Wrong codes count towards
+// a lockout like failed logins so codes cannot be guessed. The attempt is counted
+// before the code is verified, so parallel guesses cannot exceed the limit.
+func (uc *authUseCase) checkMFACode(ctx context.Context, mfa *domain.UserMFA, code string, client *ClientInfo) error {
+	limits := uc.mfaLimits(mfa.UserID)
+	if err := uc.takeAttempt(ctx, limits, &mfa.UserID, client); err != nil {
+		return err
+	}
+
+	if err := uc.verifyMFACode(ctx, mfa, code); err != nil {
+		if !errors.Is(err, domain.ErrInvalidMFACode) && !errors.Is(err, domain.ErrMFACodeReused) {
+			uc.releaseAttempts(ctx, limits)
+		}
+		return err
+	}
+
+	return uc.loginAttemptRepo.Reset(ctx, mfaAttemptKey(mfa.UserID))
+}
+
+// mfaLimits returns the failed two-factor code key of a user, it shares the account limit
+func (uc *authUseCase) mfaLimits(userID uuid.UUID) []attemptLimit {
+	return []attemptLimit{{mfaAttemptKey(userID), uc.loginLimitConfig.MaxAccountAttempts}}
+}
+
+// verifyMFACode accepts a TOTP code once, or consumes a recovery code
+func (uc *authUseCase) verifyMFACode(ctx context.Context, mfa *domain.UserMFA, code string) error {
+	code = strings.TrimSpace(code)
+
+	if len(code) == totp.Digits && isDigits(code) {
+		step, ok := totp.Validate(mfa.TOTPSecret, code, time.Now(), totpSkew)
+		if !ok {
+			return domain.ErrInvalidMFACode
+		}
+		// A code seen by an attacker over the user's shoulder cannot be replayed
+		return uc.mfaRepo.UseStep(ctx, mfa.UserID, step)
+	}
+
+	return uc.mfaRepo.UseRecoveryCode(ctx, mfa.UserID, hashToken(normalizeRecoveryCode(code)))
+}
+
+// newMFAChallenge returns the output of a login that still needs the second factor
+func (uc *authUseCase) newMFAChallenge(user *domain.User) (*AuthOutput, error) {
+	expiresAt := time.Now().Add(uc.mfaConfig.ChallengeExpiryTime)
+
+	// Signed with the refresh secret so services that trust access tokens never accept it
+	claims := jwt.MapClaims{
+		"sub":  user.ID.String(),
+		"exp":  expiresAt.Unix(),
+		"iat":  time.Now().Unix(),
+		"type": "mfa_challenge",
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(uc.jwtConfig.RefreshTokenSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthOutput{
+		MFARequired: true,
+		MFAToken:    tokenString,
+		ExpiresIn:   int64(uc.mfaConfig.ChallengeExpiryTime.Seconds()),
+	}, nil
+}
+
+// parseMFAChallenge validates an MFA challenge token and returns its user ID
+func (uc *authUseCase) parseMFAChallenge(tokenString string) (uuid.UUID, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, domain.ErrInvalidMFAToken
+		}
+		return []byte(uc.jwtConfig.RefreshTokenSecret), nil
+	})
+	if err != nil {
+		return uuid.Nil, domain.ErrInvalidMFAToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return uuid.Nil, domain.ErrInvalidMFAToken
+	}
+
+	if tokenType, _ := claims["type"].(string); tokenType != "mfa_challenge" {
+		return uuid.Nil, domain.ErrInvalidMFAToken
+	}
+
+	userIDStr, _ := claims["sub"].(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, domain.ErrInvalidMFAToken
+	}
+
+	return userID, nil
+}
+
+// generateRecoveryCodes generates recovery codes formatted as xxxxx-xxxxx and their hashes
+func generateRecoveryCodes(count int) ([]string, []string, error) {
+	codes := make([]string, 0, count)
+	hashes := make([]string, 0, count)
+
+	alphabetSize := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := 0; i < count; i++ {
+		raw := make([]byte, recoveryCodeLength)
+		for j := range raw {
+			n, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			raw[j] = recoveryCodeAlphabet[n.Int64()]
+		}
+
+		half := recoveryCodeLength / 2
+		codes = append(codes, string(raw[:half])+"-"+string(raw[half:]))
+		hashes = append(hashes, hashToken(string(raw)))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode makes recovery codes case and separator insensitive
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+// isDigits checks if a string only contains ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
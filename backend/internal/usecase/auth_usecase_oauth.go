@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/pkg/oauth"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// OAuthProviders lists the enabled social login providers
+func (uc *authUseCase) OAuthProviders() []string {
+	return uc.oauthProviders.Names()
+}
+
+// StartOAuthLogin returns the sign-in URL of a social login provider
+func (uc *authUseCase) StartOAuthLogin(provider string) (*OAuthStartOutput, error) {
+	return uc.startOAuth(provider, oauthStateLogin, uuid.Nil)
+}
+
+// OAuthLogin signs a user in with a social login provider, linking or creating the account
+func (uc *authUseCase) OAuthLogin(ctx context.Context, provider string, input *OAuthCallbackInput) (*AuthOutput, error) {
+	identity, err := uc.exchangeOAuthCode(ctx, provider, oauthStateLogin, uuid.Nil, input)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.findOrCreateOAuthUser(ctx, provider, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrUserNotActive
+	}
+
+	// Social login replaces the password, two-factor authentication still applies
+	return uc.completeLogin(ctx, user, &input.ClientInfo)
+}
+
+// StartOAuthLink returns the sign-in URL of a provider for linking it to the signed-in user
+func (uc *authUseCase) StartOAuthLink(userID uuid.UUID, provider string) (*OAuthStartOutput, error) {
+	return uc.startOAuth(provider, oauthStateLink, userID)
+}
+
+// LinkOAuthIdentity links the provider account from the callback to the signed-in user
+func (uc *authUseCase) LinkOAuthIdentity(ctx context.Context, userID uuid.UUID, provider string, input *OAuthCallbackInput) (*domain.UserIdentity, error) {
+	identity, err := uc.exchangeOAuthCode(ctx, provider, oauthStateLink, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := uc.identityRepo.GetByProviderSubject(ctx, provider, identity.Subject)
+	switch {
+	case err == nil:
+		if linked.UserID != userID {
+			return nil, domain.ErrIdentityAlreadyLinked
+		}
+		return linked, nil
+	case !errors.Is(err, domain.ErrIdentityNotFound):
+		return nil, err
+	}
+
+	link := &domain.UserIdentity{
+		UserID:          userID,
+		Provider:        provider,
+		ProviderSubject: identity.Subject,
+		Email:           strings.TrimSpace(identity.Email),
+	}
+	if err := uc.identityRepo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// startOAuth creates the state and nonce of a login or link and returns the provider sign-in URL
+func (uc *authUseCase) startOAuth(provider, purpose string, userID uuid.UUID) (*OAuthStartOutput, error) {
+	p, ok := uc.oauthProviders.Get(provider)
+	if !ok {
+		return nil, domain.ErrOAuthProviderNotFound
+	}
+
+	nonce, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := uc.newOAuthState(provider, purpose, userID, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OAuthStartOutput{
+		AuthURL:   p.AuthCodeURL(state),
+		State:     state,
+		Nonce:     nonce,
+		ExpiresIn: int64(uc.oauthConfig.StateExpiryTime.Seconds()),
+	}, nil
+}
+
+// exchangeOAuthCode checks the state of a callback against the browser's nonce and trades the code for the identity
+func (uc *authUseCase) exchangeOAuthCode(ctx context.Context, provider, purpose string, userID uuid.UUID, input *OAuthCallbackInput) (*oauth.Identity, error) {
+	p, ok := uc.oauthProviders.Get(provider)
+	if !ok {
+		return nil, domain.ErrOAuthProviderNotFound
+	}
+
+	if err := uc.parseOAuthState(input.State, provider, purpose, userID, input.Nonce); err != nil {
+		return nil, err
+	}
+
+	identity, err := p.Exchange(ctx, input.Code)
+	if err != nil {
+		if errors.Is(err, oauth.ErrExchangeFailed) {
+			return nil, domain.ErrOAuthExchangeFailed
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// findOrCreateOAuthUser resolves the user of a provider identity. An identity that is
+// not linked yet is linked to the user with the same verified email, or to a new student.
+func (uc *authUseCase) findOrCreateOAuthUser(ctx context.Context, provider string, identity *oauth.Identity) (*domain.User, error) {
+	linked, err := uc.identityRepo.GetByProviderSubject(ctx, provider, identity.Subject)
+	if err == nil {
+		if err := uc.identityRepo.UpdateLastLogin(ctx, linked.ID); err != nil {
+			log.Printf("failed to update last login of identity %s: %v", linked.ID, err)
+		}
+		return uc.userRepo.GetByID(ctx, linked.UserID)
+	}
+	if !errors.Is(err, domain.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	// Only an address the provider has verified may take over an existing account or create one.
+	// Other identities have to be linked by the signed-in user with LinkOAuthIdentity.
+	email := strings.TrimSpace(identity.Email)
+	if !identity.EmailVerified || !isValidEmail(email) {
+		return nil, domain.ErrOAuthEmailNotVerified
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		if !user.IsVerified {
+			if err := uc.verifyOAuthUserEmail(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+	case errors.Is(err, domain.ErrUserNotFound):
+		user, err = uc.createOAuthUser(ctx, email, identity)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	link := &domain.UserIdentity{
+		UserID:          user.ID,
+		Provider:        provider,
+		ProviderSubject: identity.Subject,
+		Email:           email,
+	}
+	if err := uc.identityRepo.Create(ctx, link); err != nil && !errors.Is(err, domain.ErrIdentityAlreadyLinked) {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// verifyOAuthUserEmail marks the email of an existing account verified by the provider
+func (uc *authUseCase) verifyOAuthUserEmail(ctx context.Context, user *domain.User) error {
+	user.IsVerified = true
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	if err := uc.verificationTokenRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	// Whoever registered the address without proving it may still be signed in
+	return uc.LogoutAll(ctx, user.ID)
+}
+
+// createOAuthUser creates a student for a provider identity. The account gets an
+// unusable random password, the user can set one with forgot password.
+func (uc *authUseCase) createOAuthUser(ctx context.Context, email string, identity *oauth.Identity) (*domain.User, error) {
+	password, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), uc.bcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	fullName := strings.TrimSpace(identity.Name)
+	if fullName == "" {
+		fullName, _, _ = strings.Cut(email, "@")
+	}
+
+	user := &domain.User{
+		Email:        email,
+		PasswordHash: string(hashedPassword),
+		FullName:     fullName,
+		Avatar:       optionalString(identity.AvatarURL),
+		Role:         domain.RoleStudent,
+		IsActive:     true,
+		IsVerified:   true, // The provider verified the email
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// OAuth state purposes, a state started for one cannot finish the other
+const (
+	oauthStateLogin = "login"
+	oauthStateLink  = "link"
+)
+
+// newOAuthState creates the signed state sent through the provider. The nonce is also kept
+// in a browser cookie, so a state only completes in the browser that started it.
+func (uc *authUseCase) newOAuthState(provider, purpose string, userID uuid.UUID, nonce string) (string, error) {
+	claims := jwt.MapClaims{
+		"provider": provider,
+		"purpose":  purpose,
+		"nonce":    hashToken(nonce),
+		"exp":      time.Now().Add(uc.oauthConfig.StateExpiryTime).Unix(),
+		"iat":      time.Now().Unix(),
+		"type":     "oauth_state",
+	}
+	if userID != uuid.Nil {
+		claims["user_id"] = userID.String()
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(uc.jwtConfig.RefreshTokenSecret))
+}
+
+// parseOAuthState validates a state issued by startOAuth for the provider, purpose and user,
+// and checks it against the nonce from the browser cookie
+func (uc *authUseCase) parseOAuthState(state, provider, purpose string, userID uuid.UUID, nonce string) error {
+	if nonce == "" {
+		return domain.ErrInvalidOAuthState
+	}
+
+	token, err := jwt.Parse(state, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, domain.ErrInvalidOAuthState
+		}
+		return []byte(uc.jwtConfig.RefreshTokenSecret), nil
+	})
+	if err != nil {
+		return domain.ErrInvalidOAuthState
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return domain.ErrInvalidOAuthState
+	}
+
+	if tokenType, _ := claims["type"].(string); tokenType != "oauth_state" {
+		return domain.ErrInvalidOAuthState
+	}
+	if stateProvider, _ := claims["provider"].(string); stateProvider != provider {
+		return domain.ErrInvalidOAuthState
+	}
+	if statePurpose, _ := claims["purpose"].(string); statePurpose != purpose {
+		return domain.ErrInvalidOAuthState
+	}
+	if userID != uuid.Nil {
+		if stateUserID, _ := claims["user_id"].(string); stateUserID != userID.String() {
+			return domain.ErrInvalidOAuthState
+		}
+	}
+	stateNonce, _ := claims["nonce"].(string)
+	if subtle.ConstantTimeCompare([]byte(stateNonce), []byte(hashToken(nonce))) != 1 {
+		return domain.ErrInvalidOAuthState
+	}
+
+	return nil
+}
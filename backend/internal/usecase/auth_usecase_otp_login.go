@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// RequestLoginOTP sends a login code to a phone number
+func (uc *authUseCase) RequestLoginOTP(ctx context.Context, input *RequestLoginOTPInput) (*RequestLoginOTPOutput, error) {
+	phoneNumber := strings.TrimSpace(input.PhoneNumber)
+	if !isValidPhoneNumber(phoneNumber) {
+		return nil, domain.ErrInvalidPhoneNumber
+	}
+
+	// Limits apply per number whether or not it is registered, so they don't reveal accounts
+	latest, err := uc.loginOTPRepo.GetLatestByPhone(ctx, phoneNumber)
+	if err != nil && !errors.Is(err, domain.ErrInvalidLoginOTP) {
+		return nil, err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < uc.otpLoginConfig.RequestInterval {
+		return nil, domain.ErrLoginOTPTooSoon
+	}
+
+	count, err := uc.loginOTPRepo.CountSince(ctx, phoneNumber, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if uc.otpLoginConfig.MaxRequestsPerHour > 0 && count >= uc.otpLoginConfig.MaxRequestsPerHour {
+		return nil, domain.ErrLoginOTPLimitReached
+	}
+
+	// Only the latest code is valid
+	if err := uc.loginOTPRepo.InvalidateAllForPhone(ctx, phoneNumber); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByPhoneNumber(ctx, phoneNumber)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	code, err := generateNumericOTP(otpLength)
+	if err != nil {
+		return nil, err
+	}
+
+	// The code ID salts the hash so equal codes never collide
+	otp := &domain.LoginOTP{
+		ID:          uuid.New(),
+		PhoneNumber: phoneNumber,
+		ExpiresAt:   time.Now().Add(uc.otpLoginConfig.CodeExpiryTime),
+	}
+	otp.CodeHash = hashOTP(otp.ID, code)
+	if user != nil {
+		otp.UserID = &user.ID
+	}
+
+	if err := uc.loginOTPRepo.Create(ctx, otp); err != nil {
+		return nil, err
+	}
+
+	output := &RequestLoginOTPOutput{ExpiresIn: int64(uc.otpLoginConfig.CodeExpiryTime.Seconds())}
+
+	// Unregistered numbers get no message, their code can never be entered correctly
+	if user == nil {
+		return output, nil
+	}
+
+	message := fmt.Sprintf(
+		"MathVN: Ma dang nhap cua ban la %s, hieu luc trong %d phut. Khong chia se ma nay voi bat ky ai.",
+		code,
+		int(uc.otpLoginConfig.CodeExpiryTime.Minutes()),
+	)
+	if err := uc.smsSender.Send(ctx, phoneNumber, message); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// VerifyLoginOTP logs a user in with a code sent by SMS
+func (uc *authUseCase) VerifyLoginOTP(ctx context.Context, input *VerifyLoginOTPInput) (*AuthOutput, error) {
+	phoneNumber := strings.TrimSpace(input.PhoneNumber)
+
+	// An account locked out after wrong passwords cannot switch to codes
+	if err := uc.checkLocked(ctx, loginAccountKey(phoneNumber)); err != nil {
+		return nil, err
+	}
+
+	// Guessing across many numbers from one client locks the client out. The attempt is
+	// counted before the code is compared and given back unless the code was wrong.
+	var limits []attemptLimit
+	if input.IPAddress != "" {
+		limits = append(limits, attemptLimit{loginIPKey(input.IPAddress), uc.loginLimitConfig.MaxIPAttempts})
+	}
+	if err := uc.takeAttempt(ctx, limits, nil, &input.ClientInfo); err != nil {
+		return nil, err
+	}
+	wrongCode := false
+	defer func() {
+		if !wrongCode {
+			uc.releaseAttempts(ctx, limits)
+		}
+	}()
+
+	otp, err := uc.loginOTPRepo.GetLatestByPhone(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if otp.IsUsed() {
+		return nil, domain.ErrInvalidLoginOTP
+	}
+	if otp.IsExpired() {
+		return nil, domain.ErrLoginOTPExpired
+	}
+
+	// Count the guess before comparing so parallel guesses cannot exceed the limit
+	attempts, err := uc.loginOTPRepo.IncrementAttempts(ctx, otp.ID, uc.otpLoginConfig.MaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := hashOTP(otp.ID, strings.TrimSpace(input.Code))
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(otp.CodeHash)) != 1 {
+		wrongCode = true
+		if attempts >= uc.otpLoginConfig.MaxAttempts {
+			return nil, domain.ErrLoginOTPTooManyAttempts
+		}
+		return nil, domain.ErrInvalidLoginOTP
+	}
+
+	// Consume the code first so it cannot be used twice concurrently
+	if err := uc.loginOTPRepo.MarkUsed(ctx, otp.ID); err != nil {
+		return nil, err
+	}
+
+	if otp.UserID == nil {
+		return nil, domain.ErrInvalidLoginOTP
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, *otp.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrUserNotActive
+	}
+
+	return uc.completeLogin(ctx, user, &input.ClientInfo)
+}
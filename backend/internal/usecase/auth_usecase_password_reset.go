@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/pkg/mailer"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// otpLength is the number of digits of codes sent by SMS
+const otpLength = 6
+
+// ForgotPassword sends a reset link by email or an OTP by SMS
+func (uc *authUseCase) ForgotPassword(ctx context.Context, input *ForgotPasswordInput) (*ForgotPasswordOutput, error) {
+	identifier := strings.TrimSpace(input.EmailOrPhone)
+
+	// The channel depends only on the identifier so the response doesn't reveal whether it is registered
+	var channel domain.PasswordResetChannel
+	switch {
+	case isValidEmail(identifier):
+		channel = domain.PasswordResetChannelEmail
+	case isValidPhoneNumber(identifier):
+		channel = domain.PasswordResetChannelSMS
+	default:
+		return nil, domain.ErrInvalidEmailOrPhone
+	}
+	output := &ForgotPasswordOutput{Channel: channel}
+
+	user, err := uc.findUserByEmailOrPhone(ctx, identifier)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return output, nil
+		}
+		return nil, err
+	}
+
+	// Throttle requests to avoid flooding the user's inbox or phone. A throttled request
+	// answers like any other, an error would reveal that the identifier is registered.
+	latest, err := uc.passwordResetRepo.GetLatestByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrInvalidResetToken) {
+		return nil, err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < uc.passwordResetConfig.RequestInterval {
+		log.Printf("password reset for user %s throttled, last code sent at %s", user.ID, latest.CreatedAt.Format(time.RFC3339))
+		return output, nil
+	}
+
+	// Only the latest code is valid
+	if err := uc.passwordResetRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	if channel == domain.PasswordResetChannelEmail {
+		err = uc.sendPasswordResetLink(ctx, user)
+	} else {
+		err = uc.sendPasswordResetOTP(ctx, user)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// ResetPassword sets a new password using a reset link token or OTP
+func (uc *authUseCase) ResetPassword(ctx context.Context, input *ResetPasswordInput) error {
+	var token *domain.PasswordResetToken
+	var err error
+
+	switch {
+	case input.Token != "":
+		// Reset link sent by email
+		token, err = uc.passwordResetRepo.GetByTokenHash(ctx, hashToken(input.Token))
+		if err != nil {
+			return err
+		}
+		if token.Channel != domain.PasswordResetChannelEmail {
+			return domain.ErrInvalidResetToken
+		}
+	case input.EmailOrPhone != "" && input.OTP != "":
+		// OTP sent by SMS, only the latest code of the user is checked
+		user, err := uc.findUserByEmailOrPhone(ctx, strings.TrimSpace(input.EmailOrPhone))
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrInvalidEmailOrPhone) {
+				return domain.ErrInvalidResetToken
+			}
+			return err
+		}
+		token, err = uc.passwordResetRepo.GetLatestByUserID(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if token.Channel != domain.PasswordResetChannelSMS {
+			return domain.ErrInvalidResetToken
+		}
+	default:
+		return domain.ErrInvalidResetToken
+	}
+
+	if token.IsUsed() {
+		return domain.ErrInvalidResetToken
+	}
+	if token.IsExpired() {
+		return domain.ErrResetTokenExpired
+	}
+
+	// Validate new password length before a guess is spent on the code
+	if len(input.NewPassword) < 8 {
+		return domain.ErrInvalidPassword
+	}
+
+	if token.Channel == domain.PasswordResetChannelSMS {
+		// Count the guess before comparing so parallel guesses cannot exceed the limit
+		attempts, err := uc.passwordResetRepo.IncrementAttempts(ctx, token.ID, uc.passwordResetConfig.MaxAttempts)
+		if err != nil {
+			return err
+		}
+		expected := hashOTP(token.ID, strings.TrimSpace(input.OTP))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(token.TokenHash)) != 1 {
+			if attempts >= uc.passwordResetConfig.MaxAttempts {
+				return domain.ErrResetTooManyAttempts
+			}
+			return domain.ErrInvalidResetToken
+		}
+	}
+
+	// Consume the token first so it cannot be used twice concurrently
+	if err := uc.passwordResetRepo.MarkUsed(ctx, token.ID); err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.NewPassword), uc.bcryptCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(hashedPassword)
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	if err := uc.passwordResetRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	// Sign out every device, the old password may have been compromised
+	return uc.LogoutAll(ctx, user.ID)
+}
+
+// sendPasswordResetLink issues a reset token and emails the reset link to the user
+func (uc *authUseCase) sendPasswordResetLink(ctx context.Context, user *domain.User) error {
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	token := &domain.PasswordResetToken{
+		UserID:    user.ID,
+		Channel:   domain.PasswordResetChannelEmail,
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: time.Now().Add(uc.passwordResetConfig.LinkExpiryTime),
+	}
+
+	if err := uc.passwordResetRepo.Create(ctx, token); err != nil {
+		return err
+	}
+
+	link := uc.passwordResetConfig.ResetPasswordURL + "?token=" + url.QueryEscape(rawToken)
+
+	return uc.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Đặt lại mật khẩu MathVN",
+		Body: fmt.Sprintf(
+			"Xin chào %s,\n\nChúng tôi nhận được yêu cầu đặt lại mật khẩu cho tài khoản của bạn. Nhấn vào liên kết sau để đặt mật khẩu mới:\n%s\n\nLiên kết có hiệu lực trong %d phút.\nNếu bạn không yêu cầu, hãy bỏ qua email này.",
+			user.FullName,
+			link,
+			int(uc.passwordResetConfig.LinkExpiryTime.Minutes()),
+		),
+	})
+}
+
+// sendPasswordResetOTP issues a numeric OTP and sends it to the user's phone
+func (uc *authUseCase) sendPasswordResetOTP(ctx context.Context, user *domain.User) error {
+	otp, err := generateNumericOTP(otpLength)
+	if err != nil {
+		return err
+	}
+
+	// The token ID salts the hash so equal codes never collide
+	token := &domain.PasswordResetToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Channel:   domain.PasswordResetChannelSMS,
+		ExpiresAt: time.Now().Add(uc.passwordResetConfig.OTPExpiryTime),
+	}
+	token.TokenHash = hashOTP(token.ID, otp)
+
+	if err := uc.passwordResetRepo.Create(ctx, token); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf(
+		"MathVN: Ma dat lai mat khau cua ban la %s, hieu luc trong %d phut. Khong chia se ma nay voi bat ky ai.",
+		otp,
+		int(uc.passwordResetConfig.OTPExpiryTime.Minutes()),
+	)
+
+	return uc.smsSender.Send(ctx, user.PhoneNumber, message)
+}
+
+// hashOTP hashes a short numeric code salted with the ID of the record storing it
+func hashOTP(salt uuid.UUID, otp string) string {
+	return hashToken(salt.String() + ":" + otp)
+}
+
+// generateNumericOTP generates a random numeric code with the given number of digits
+func generateNumericOTP(digits int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < digits; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + n.Int64()))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// maxDeviceNameLength matches the refresh_tokens.device_name column
+const maxDeviceNameLength = 255
+
+// ListSessions lists the devices the user is logged in on
+func (uc *authUseCase) ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	return uc.refreshTokenRepo.ListActiveSessions(ctx, userID)
+}
+
+// RevokeSession logs the user out of one device
+func (uc *authUseCase) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return uc.refreshTokenRepo.RevokeSession(ctx, userID, sessionID)
+}
+
+// sessionLimit returns how many devices the user may be logged in on, 0 for unlimited
+func (uc *authUseCase) sessionLimit(user *domain.User) int {
+	if user.MaxSessions != nil {
+		return *user.MaxSessions
+	}
+
+	switch user.Role {
+	case domain.RoleStudent, domain.RoleParent:
+		return uc.sessionConfig.MaxStudentSessions
+	case domain.RoleTeacher, domain.RoleSales:
+		return uc.sessionConfig.MaxTeacherSessions
+	case domain.RoleAdmin:
+		return uc.sessionConfig.MaxAdminSessions
+	}
+	return 0
+}
+
+// deviceName returns the name the client chose for the device,
+// or a name like "Chrome trên Windows" derived from the user agent
+func deviceName(requested, userAgent string) string {
+	name := strings.TrimSpace(requested)
+	if name == "" {
+		name = describeUserAgent(userAgent)
+	}
+	if len([]rune(name)) > maxDeviceNameLength {
+		name = string([]rune(name)[:maxDeviceNameLength])
+	}
+	return name
+}
+
+// describeUserAgent derives a human readable device name from a user agent string
+func describeUserAgent(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	// Order matters, many browsers include the tokens of the ones they are based on
+	browser := ""
+	switch {
+	case strings.Contains(ua, "coc_coc_browser"):
+		browser = "Cốc Cốc"
+	case strings.Contains(ua, "edg/"), strings.Contains(ua, "edga/"), strings.Contains(ua, "edgios/"):
+		browser = "Edge"
+	case strings.Contains(ua, "opr/"), strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "firefox/"), strings.Contains(ua, "fxios/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome/"), strings.Contains(ua, "crios/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari/"):
+		browser = "Safari"
+	}
+
+	os := ""
+	switch {
+	case strings.Contains(ua, "iphone"):
+		os = "iPhone"
+	case strings.Contains(ua, "ipad"):
+		os = "iPad"
+	case strings.Contains(ua, "android"):
+		os = "Android"
+	case strings.Contains(ua, "windows"):
+		os = "Windows"
+	case strings.Contains(ua, "mac os x"), strings.Contains(ua, "macintosh"):
+		os = "macOS"
+	case strings.Contains(ua, "cros"):
+		os = "ChromeOS"
+	case strings.Contains(ua, "linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " trên " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	default:
+		return "Thiết bị không xác định"
+	}
+}
+
+// optionalString returns nil for an empty string so it is stored as NULL
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
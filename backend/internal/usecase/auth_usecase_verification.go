@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/pkg/mailer"
+)
+
+// VerifyEmail marks the user's email as verified using a token sent by email
+func (uc *authUseCase) VerifyEmail(ctx context.Context, input *VerifyEmailInput) error {
+	token, err := uc.verificationTokenRepo.GetByTokenHash(ctx, hashToken(input.Token))
+	if err != nil {
+		return err
+	}
+
+	if token.IsUsed() {
+		return domain.ErrInvalidVerificationToken
+	}
+	if token.IsExpired() {
+		return domain.ErrVerificationTokenExpired
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+
+	// The token is void if the user changed email after it was sent
+	if user.Email != token.Email {
+		return domain.ErrInvalidVerificationToken
+	}
+
+	// Consume the token first so it cannot be used twice concurrently
+	if err := uc.verificationTokenRepo.MarkUsed(ctx, token.ID); err != nil {
+		return err
+	}
+
+	if user.IsVerified {
+		return nil
+	}
+
+	user.IsVerified = true
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	return uc.verificationTokenRepo.InvalidateAllForUser(ctx, user.ID)
+}
+
+// ResendVerification sends a new verification email. Unknown, already verified and throttled
+// emails all succeed without sending anything, so the answer doesn't reveal whether the email is registered.
+func (uc *authUseCase) ResendVerification(ctx context.Context, input *ResendVerificationInput) error {
+	user, err := uc.userRepo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if user.IsVerified {
+		log.Printf("verification resend for user %s skipped, email already verified", user.ID)
+		return nil
+	}
+
+	// Throttle resends to avoid flooding the user's inbox
+	latest, err := uc.verificationTokenRepo.GetLatestByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrInvalidVerificationToken) {
+		return err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < uc.verificationConfig.EmailResendInterval {
+		log.Printf("verification resend for user %s throttled, last email sent at %s", user.ID, latest.CreatedAt.Format(time.RFC3339))
+		return nil
+	}
+
+	return uc.sendVerificationEmail(ctx, user)
+}
+
+// sendVerificationEmail issues a new verification token and emails it to the user.
+// Previously issued tokens are invalidated.
+func (uc *authUseCase) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	if err := uc.verificationTokenRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	token := &domain.EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: time.Now().Add(uc.verificationConfig.EmailTokenExpiryTime),
+	}
+
+	if err := uc.verificationTokenRepo.Create(ctx, token); err != nil {
+		return err
+	}
+
+	link := uc.verificationConfig.VerifyEmailURL + "?token=" + url.QueryEscape(rawToken)
+
+	return uc.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Xác thực email tài khoản MathVN",
+		Body: fmt.Sprintf(
+			"Xin chào %s,\n\nVui lòng nhấn vào liên kết sau để xác thực email của bạn:\n%s\n\nLiên kết có hiệu lực trong %d giờ.\nNếu bạn không đăng ký tài khoản MathVN, hãy bỏ qua email này.",
+			user.FullName,
+			link,
+			int(uc.verificationConfig.EmailTokenExpiryTime.Hours()),
+		),
+	})
+}
+
+// generateOpaqueToken generates a random URL-safe token
+func generateOpaqueToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
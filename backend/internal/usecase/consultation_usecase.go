@@ -6,17 +6,20 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
 )
 
 type consultationUseCase struct {
 	consultationRepo domain.ConsultationRepository
 	timeout          context.Context // In real app, we handle timeout properly or use config
+	audit            auditRecorder
 }
 
 // NewConsultationUseCase creates a new consultation use case
-func NewConsultationUseCase(consultationRepo domain.ConsultationRepository) domain.ConsultationUseCase {
+func NewConsultationUseCase(consultationRepo domain.ConsultationRepository, auditLogRepo repository.AuditLogRepository) domain.ConsultationUseCase {
 	return &consultationUseCase{
 		consultationRepo: consultationRepo,
+		audit:            auditRecorder{auditLogRepo: auditLogRepo},
 	}
 }
 
@@ -52,13 +55,29 @@ func (uc *consultationUseCase) UpdateRequest(ctx context.Context, id uuid.UUID,
 	if err != nil {
 		return err
 	}
+	before := *req
 	req.Status = status
 	req.Note = note
 	req.UpdatedAt = time.Now()
-	return uc.consultationRepo.Update(ctx, req)
+	if err := uc.consultationRepo.Update(ctx, req); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionConsultationUpdate, domain.AuditTargetConsultation, id, &before, req)
+	return nil
 }
 
 // DeleteRequest deletes a consultation request
 func (uc *consultationUseCase) DeleteRequest(ctx context.Context, id uuid.UUID) error {
-	return uc.consultationRepo.Delete(ctx, id)
+	req, err := uc.consultationRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.consultationRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionConsultationDelete, domain.AuditTargetConsultation, id, req, nil)
+	return nil
 }
@@ -7,6 +7,25 @@ import (
 	"github.com/mathvn/backend/internal/domain"
 )
 
+// LessonPlayback represents the media reference of a lesson the user is allowed to watch
+type LessonPlayback struct {
+	LessonID        uuid.UUID `json:"lesson_id"`
+	CourseID        uuid.UUID `json:"course_id"`
+	Title           string    `json:"title"`
+	VideoURL        *string   `json:"video_url,omitempty"`
+	YouTubeID       *string   `json:"youtube_id,omitempty"`
+	DurationMinutes int       `json:"duration_minutes"`
+	IsPreview       bool      `json:"is_preview"`
+}
+
+// InstructorCourse is a course seen by one of its teachers, with their staff role
+// and the active enrollment count
+type InstructorCourse struct {
+	*domain.Course
+	StaffRole       domain.CourseStaffRole `json:"staff_role"`
+	EnrollmentCount int                    `json:"enrollment_count"`
+}
+
 // CourseUseCase defines the interface for course use cases
 type CourseUseCase interface {
 	// GetCourse retrieves a course by ID or slug
@@ -15,8 +34,13 @@ type CourseUseCase interface {
 	// ListCourses retrieves courses with filters, pagination, and sorting
 	ListCourses(ctx context.Context, filter *domain.CourseFilter, sort domain.CourseSort, page, pageSize int) ([]*domain.Course, int, error)
 
-	// GetCourseWithDetails retrieves a course with instructor and sections/lessons
-	GetCourseWithDetails(ctx context.Context, idOrSlug string) (*domain.Course, error)
+	// GetCourseWithDetails retrieves a course with instructor and sections/lessons.
+	// Media of non-preview lessons is stripped unless the viewer has access to the course.
+	// viewerID is nil for anonymous callers.
+	GetCourseWithDetails(ctx context.Context, idOrSlug string, viewerID *uuid.UUID, viewerRole domain.UserRole) (*domain.Course, error)
+
+	// GetLessonPlayback returns the media reference of a lesson if the user may watch it
+	GetLessonPlayback(ctx context.Context, userID uuid.UUID, role domain.UserRole, lessonID uuid.UUID) (*LessonPlayback, error)
 
 	// Admin operations
 	CreateCourse(ctx context.Context, course *domain.Course) error
@@ -32,4 +56,23 @@ type CourseUseCase interface {
 	CreateLesson(ctx context.Context, lesson *domain.CourseLesson) error
 	UpdateLesson(ctx context.Context, lesson *domain.CourseLesson) error
 	DeleteLesson(ctx context.Context, id uuid.UUID) error
+
+	// Course staff operations
+	ListCourseStaff(ctx context.Context, courseID uuid.UUID) ([]*domain.CourseStaff, error)
+	SetCourseStaff(ctx context.Context, courseID, userID uuid.UUID, role domain.CourseStaffRole) (*domain.CourseStaff, error)
+	RemoveCourseStaff(ctx context.Context, courseID, userID uuid.UUID) error
+
+	// Instructor operations, each fails with ErrCourseAccessDenied unless the teacher
+	// is on the course staff with a role allowed to do it
+	ListInstructorCourses(ctx context.Context, instructorID uuid.UUID, page, pageSize int) ([]*InstructorCourse, int, error)
+	GetInstructorCourse(ctx context.Context, instructorID, courseID uuid.UUID) (*domain.Course, error)
+	UpdateInstructorCourse(ctx context.Context, instructorID uuid.UUID, course *domain.Course) error
+	CreateInstructorSection(ctx context.Context, instructorID uuid.UUID, section *domain.CourseSection) error
+	UpdateInstructorSection(ctx context.Context, instructorID uuid.UUID, section *domain.CourseSection) error
+	CreateInstructorLesson(ctx context.Context, instructorID uuid.UUID, lesson *domain.CourseLesson) error
+	UpdateInstructorLesson(ctx context.Context, instructorID uuid.UUID, lesson *domain.CourseLesson) error
+	ListCourseStudents(ctx context.Context, instructorID, courseID uuid.UUID, page, pageSize int) ([]*domain.StudentProgress, int, error)
+	ListInstructorCourseStaff(ctx context.Context, instructorID, courseID uuid.UUID) ([]*domain.CourseStaff, error)
+	SetInstructorCourseStaff(ctx context.Context, instructorID, courseID, userID uuid.UUID, role domain.CourseStaffRole) (*domain.CourseStaff, error)
+	RemoveInstructorCourseStaff(ctx context.Context, instructorID, courseID, userID uuid.UUID) error
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
@@ -12,15 +13,36 @@ import (
 
 // courseUseCase implements CourseUseCase
 type courseUseCase struct {
-	courseRepo repository.CourseRepository
-	userRepo   repository.UserRepository
+	courseRepo     repository.CourseRepository
+	userRepo       repository.UserRepository
+	enrollmentRepo repository.EnrollmentRepository
+	progressRepo   repository.ProgressRepository
+	staffRepo      repository.CourseStaffRepository
+	uow            repository.UnitOfWork
+	permissions    PermissionUseCase
+	audit          auditRecorder
 }
 
 // NewCourseUseCase creates a new course use case
-func NewCourseUseCase(courseRepo repository.CourseRepository, userRepo repository.UserRepository) CourseUseCase {
+func NewCourseUseCase(
+	courseRepo repository.CourseRepository,
+	userRepo repository.UserRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	progressRepo repository.ProgressRepository,
+	staffRepo repository.CourseStaffRepository,
+	uow repository.UnitOfWork,
+	permissionUseCase PermissionUseCase,
+	auditLogRepo repository.AuditLogRepository,
+) CourseUseCase {
 	return &courseUseCase{
-		courseRepo: courseRepo,
-		userRepo:   userRepo,
+		courseRepo:     courseRepo,
+		userRepo:       userRepo,
+		enrollmentRepo: enrollmentRepo,
+		progressRepo:   progressRepo,
+		staffRepo:      staffRepo,
+		uow:            uow,
+		permissions:    permissionUseCase,
+		audit:          auditRecorder{auditLogRepo: auditLogRepo},
 	}
 }
 
@@ -64,7 +86,7 @@ func (uc *courseUseCase) ListCourses(ctx context.Context, filter *domain.CourseF
 }
 
 // GetCourseWithDetails retrieves a course with instructor and sections/lessons
-func (uc *courseUseCase) GetCourseWithDetails(ctx context.Context, idOrSlug string) (*domain.Course, error) {
+func (uc *courseUseCase) GetCourseWithDetails(ctx context.Context, idOrSlug string, viewerID *uuid.UUID, viewerRole domain.UserRole) (*domain.Course, error) {
 	// Get course
 	course, err := uc.GetCourse(ctx, idOrSlug)
 	if err != nil {
@@ -85,20 +107,115 @@ func (uc *courseUseCase) GetCourseWithDetails(ctx context.Context, idOrSlug stri
 		return nil, err
 	}
 
+	// Only viewers with access to the course may see media of non-preview lessons
+	hasAccess, err := uc.canAccessCourseContent(ctx, course, viewerID, viewerRole)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get lessons for each section
 	for _, section := range sections {
 		lessons, err := uc.courseRepo.GetLessonsBySectionID(ctx, section.ID)
 		if err != nil {
 			return nil, err
 		}
+		if !hasAccess {
+			for _, lesson := range lessons {
+				if !lesson.IsPreview {
+					lesson.HideMedia()
+				}
+			}
+		}
 		section.Lessons = lessons
 	}
 
 	course.Sections = sections
 
+	// Get co-instructors and teaching assistants
+	staff, err := uc.staffRepo.ListByCourse(ctx, course.ID)
+	if err != nil {
+		return nil, err
+	}
+	course.Staff = staff
+
 	return course, nil
 }
 
+// GetLessonPlayback returns the media reference of a lesson if the user may watch it
+func (uc *courseUseCase) GetLessonPlayback(ctx context.Context, userID uuid.UUID, role domain.UserRole, lessonID uuid.UUID) (*LessonPlayback, error) {
+	lesson, err := uc.courseRepo.GetLessonByID(ctx, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lesson.IsPreview {
+		course, err := uc.courseRepo.GetByID(ctx, lesson.CourseID)
+		if err != nil {
+			return nil, err
+		}
+
+		hasAccess, err := uc.canAccessCourseContent(ctx, course, &userID, role)
+		if err != nil {
+			return nil, err
+		}
+		if !hasAccess {
+			return nil, domain.ErrLessonAccessDenied
+		}
+	}
+
+	return &LessonPlayback{
+		LessonID:        lesson.ID,
+		CourseID:        lesson.CourseID,
+		Title:           lesson.Title,
+		VideoURL:        lesson.VideoURL,
+		YouTubeID:       lesson.YouTubeID,
+		DurationMinutes: lesson.DurationMinutes,
+		IsPreview:       lesson.IsPreview,
+	}, nil
+}
+
+// canAccessCourseContent reports whether the viewer may watch every lesson of the course.
+// Roles that edit every course and the course staff always have access, students need an active enrollment.
+// Staff access follows the viewer's current role, a teacher demoted to another role loses it.
+func (uc *courseUseCase) canAccessCourseContent(ctx context.Context, course *domain.Course, viewerID *uuid.UUID, viewerRole domain.UserRole) (bool, error) {
+	if viewerID == nil {
+		return false, nil
+	}
+
+	canEditAll, err := uc.permissions.HasPermission(ctx, viewerRole, domain.PermissionCoursesWrite)
+	if err != nil {
+		return false, err
+	}
+	if canEditAll {
+		return true, nil
+	}
+
+	canTeach, err := uc.permissions.HasPermission(ctx, viewerRole, domain.PermissionCoursesTeach)
+	if err != nil {
+		return false, err
+	}
+	if canTeach {
+		if course.InstructorID == *viewerID {
+			return true, nil
+		}
+		if _, err := uc.staffRepo.GetRole(ctx, course.ID, *viewerID); err == nil {
+			return true, nil
+		} else if !errors.Is(err, domain.ErrCourseStaffNotFound) {
+			return false, err
+		}
+	}
+
+	enrollment, err := uc.enrollmentRepo.GetByUserAndCourse(ctx, *viewerID, course.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrEnrollmentNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return enrollment.IsActive(), nil
+}
+
 // Admin operations
 func (uc *courseUseCase) CreateCourse(ctx context.Context, course *domain.Course) error {
 	course.ID = uuid.New()
@@ -113,7 +230,24 @@ func (uc *courseUseCase) CreateCourse(ctx context.Context, course *domain.Course
 		course.Status = domain.StatusDraft
 	}
 
-	return uc.courseRepo.Create(ctx, course)
+	// The instructor owns the course, a course is never left without its owner row
+	err := uc.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		if err := repos.Courses().Create(ctx, course); err != nil {
+			return err
+		}
+
+		return repos.CourseStaff().Upsert(ctx, &domain.CourseStaff{
+			CourseID: course.ID,
+			UserID:   course.InstructorID,
+			Role:     domain.CourseStaffOwner,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionCourseCreate, domain.AuditTargetCourse, course.ID, nil, course)
+	return nil
 }
 
 func (uc *courseUseCase) UpdateCourse(ctx context.Context, course *domain.Course) error {
@@ -137,11 +271,26 @@ func (uc *courseUseCase) UpdateCourse(ctx context.Context, course *domain.Course
 	}
 
 	course.UpdatedAt = time.Now()
-	return uc.courseRepo.Update(ctx, course)
+	if err := uc.courseRepo.Update(ctx, course); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionCourseUpdate, domain.AuditTargetCourse, course.ID, existingCourse, course)
+	return nil
 }
 
 func (uc *courseUseCase) DeleteCourse(ctx context.Context, id uuid.UUID) error {
-	return uc.courseRepo.Delete(ctx, id)
+	existingCourse, err := uc.courseRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.courseRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionCourseDelete, domain.AuditTargetCourse, id, existingCourse, nil)
+	return nil
 }
 
 // Section operations
@@ -149,16 +298,41 @@ func (uc *courseUseCase) CreateSection(ctx context.Context, section *domain.Cour
 	section.ID = uuid.New()
 	section.CreatedAt = time.Now()
 	section.UpdatedAt = time.Now()
-	return uc.courseRepo.CreateSection(ctx, section)
+	if err := uc.courseRepo.CreateSection(ctx, section); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionSectionCreate, domain.AuditTargetSection, section.ID, nil, section)
+	return nil
 }
 
 func (uc *courseUseCase) UpdateSection(ctx context.Context, section *domain.CourseSection) error {
+	existingSection, err := uc.courseRepo.GetSectionByID(ctx, section.ID)
+	if err != nil {
+		return err
+	}
+
 	section.UpdatedAt = time.Now()
-	return uc.courseRepo.UpdateSection(ctx, section)
+	if err := uc.courseRepo.UpdateSection(ctx, section); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionSectionUpdate, domain.AuditTargetSection, section.ID, existingSection, section)
+	return nil
 }
 
 func (uc *courseUseCase) DeleteSection(ctx context.Context, id uuid.UUID) error {
-	return uc.courseRepo.DeleteSection(ctx, id)
+	existingSection, err := uc.courseRepo.GetSectionByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.courseRepo.DeleteSection(ctx, id); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionSectionDelete, domain.AuditTargetSection, id, existingSection, nil)
+	return nil
 }
 
 // Lesson operations
@@ -180,11 +354,18 @@ func (uc *courseUseCase) CreateLesson(ctx context.Context, lesson *domain.Course
 		return err
 	}
 
+	uc.audit.record(ctx, domain.AuditActionLessonCreate, domain.AuditTargetLesson, lesson.ID, nil, lesson)
+
 	// Recalculate course stats
 	return uc.courseRepo.RecalculateCourseStats(ctx, lesson.CourseID)
 }
 
 func (uc *courseUseCase) UpdateLesson(ctx context.Context, lesson *domain.CourseLesson) error {
+	existingLesson, err := uc.courseRepo.GetLessonByID(ctx, lesson.ID)
+	if err != nil {
+		return err
+	}
+
 	lesson.UpdatedAt = time.Now()
 
 	// Extract YouTube ID from URL if present
@@ -197,11 +378,13 @@ func (uc *courseUseCase) UpdateLesson(ctx context.Context, lesson *domain.Course
 		// If video url is cleared, clear youtube id too
 	}
 
-	err := uc.courseRepo.UpdateLesson(ctx, lesson)
+	err = uc.courseRepo.UpdateLesson(ctx, lesson)
 	if err != nil {
 		return err
 	}
 
+	uc.audit.record(ctx, domain.AuditActionLessonUpdate, domain.AuditTargetLesson, lesson.ID, existingLesson, lesson)
+
 	// Recalculate course stats
 	return uc.courseRepo.RecalculateCourseStats(ctx, lesson.CourseID)
 }
@@ -218,6 +401,8 @@ func (uc *courseUseCase) DeleteLesson(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
+	uc.audit.record(ctx, domain.AuditActionLessonDelete, domain.AuditTargetLesson, id, lesson, nil)
+
 	return uc.courseRepo.RecalculateCourseStats(ctx, lesson.CourseID)
 }
 
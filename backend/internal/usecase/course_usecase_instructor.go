@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// ListInstructorCourses lists the courses a teacher is on the staff of, in any role,
+// with their enrollment counts
+func (uc *courseUseCase) ListInstructorCourses(ctx context.Context, instructorID uuid.UUID, page, pageSize int) ([]*InstructorCourse, int, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	filter := &domain.CourseFilter{StaffUserID: &instructorID}
+	courses, total, err := uc.courseRepo.List(ctx, filter, domain.SortCreatedAtDesc, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*InstructorCourse, 0, len(courses))
+	for _, course := range courses {
+		role, err := uc.staffRepo.GetRole(ctx, course.ID, instructorID)
+		if err != nil {
+			return nil, 0, err
+		}
+		count, err := uc.enrollmentRepo.CountByCourseID(ctx, course.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		result = append(result, &InstructorCourse{Course: course, StaffRole: role, EnrollmentCount: count})
+	}
+
+	return result, total, nil
+}
+
+// GetInstructorCourse retrieves a course the teacher is on the staff of, with all sections and lessons, media included
+func (uc *courseUseCase) GetInstructorCourse(ctx context.Context, instructorID, courseID uuid.UUID) (*domain.Course, error) {
+	if _, err := uc.staffCourse(ctx, instructorID, courseID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.GetCourseWithDetails(ctx, courseID.String(), &instructorID, domain.RoleTeacher)
+}
+
+// UpdateInstructorCourse updates a course the teacher owns. Prices, the publication status,
+// ratings, student counts and the featured flag stay under admin control.
+func (uc *courseUseCase) UpdateInstructorCourse(ctx context.Context, instructorID uuid.UUID, course *domain.Course) error {
+	existingCourse, err := uc.staffCourse(ctx, instructorID, course.ID, domain.CourseStaffRole.IsOwner)
+	if err != nil {
+		return err
+	}
+
+	course.Price = existingCourse.Price
+	course.OriginalPrice = existingCourse.OriginalPrice
+	course.Status = existingCourse.Status
+	course.Rating = existingCourse.Rating
+	course.TotalReviews = existingCourse.TotalReviews
+	course.TotalStudents = existingCourse.TotalStudents
+	course.IsFeatured = existingCourse.IsFeatured
+
+	return uc.UpdateCourse(ctx, course)
+}
+
+// CreateInstructorSection adds a section to a course the teacher edits content of
+func (uc *courseUseCase) CreateInstructorSection(ctx context.Context, instructorID uuid.UUID, section *domain.CourseSection) error {
+	if _, err := uc.staffCourse(ctx, instructorID, section.CourseID, domain.CourseStaffRole.CanEditContent); err != nil {
+		return err
+	}
+
+	return uc.CreateSection(ctx, section)
+}
+
+// UpdateInstructorSection updates a section of a course the teacher edits content of, it cannot be moved to another course
+func (uc *courseUseCase) UpdateInstructorSection(ctx context.Context, instructorID uuid.UUID, section *domain.CourseSection) error {
+	existingSection, err := uc.courseRepo.GetSectionByID(ctx, section.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.staffCourse(ctx, instructorID, existingSection.CourseID, domain.CourseStaffRole.CanEditContent); err != nil {
+		return err
+	}
+
+	section.CourseID = existingSection.CourseID
+	return uc.UpdateSection(ctx, section)
+}
+
+// CreateInstructorLesson adds a lesson to a section of a course the teacher edits content of
+func (uc *courseUseCase) CreateInstructorLesson(ctx context.Context, instructorID uuid.UUID, lesson *domain.CourseLesson) error {
+	section, err := uc.courseRepo.GetSectionByID(ctx, lesson.SectionID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.staffCourse(ctx, instructorID, section.CourseID, domain.CourseStaffRole.CanEditContent); err != nil {
+		return err
+	}
+
+	lesson.CourseID = section.CourseID
+	return uc.CreateLesson(ctx, lesson)
+}
+
+// UpdateInstructorLesson updates a lesson of a course the teacher edits content of. It may move to another
+// section of the same course only.
+func (uc *courseUseCase) UpdateInstructorLesson(ctx context.Context, instructorID uuid.UUID, lesson *domain.CourseLesson) error {
+	existingLesson, err := uc.courseRepo.GetLessonByID(ctx, lesson.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.staffCourse(ctx, instructorID, existingLesson.CourseID, domain.CourseStaffRole.CanEditContent); err != nil {
+		return err
+	}
+
+	if lesson.SectionID == uuid.Nil {
+		lesson.SectionID = existingLesson.SectionID
+	} else if lesson.SectionID != existingLesson.SectionID {
+		section, err := uc.courseRepo.GetSectionByID(ctx, lesson.SectionID)
+		if err != nil {
+			return err
+		}
+		if section.CourseID != existingLesson.CourseID {
+			return domain.ErrCourseSectionNotFound
+		}
+	}
+
+	lesson.CourseID = existingLesson.CourseID
+	return uc.UpdateLesson(ctx, lesson)
+}
+
+// ListCourseStudents lists the students enrolled in a course the teacher is on the staff of, with their progress
+func (uc *courseUseCase) ListCourseStudents(ctx context.Context, instructorID, courseID uuid.UUID, page, pageSize int) ([]*domain.StudentProgress, int, error) {
+	if _, err := uc.staffCourse(ctx, instructorID, courseID, nil); err != nil {
+		return nil, 0, err
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	return uc.progressRepo.ListStudentProgress(ctx, courseID, pageSize, (page-1)*pageSize)
+}
+
+// ListInstructorCourseStaff lists the staff of a course the teacher is on the staff of
+func (uc *courseUseCase) ListInstructorCourseStaff(ctx context.Context, instructorID, courseID uuid.UUID) ([]*domain.CourseStaff, error) {
+	if _, err := uc.staffCourse(ctx, instructorID, courseID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.staffRepo.ListByCourse(ctx, courseID)
+}
+
+// SetInstructorCourseStaff adds a teacher to a course the caller owns, or changes their role
+func (uc *courseUseCase) SetInstructorCourseStaff(ctx context.Context, instructorID, courseID, userID uuid.UUID, role domain.CourseStaffRole) (*domain.CourseStaff, error) {
+	if _, err := uc.staffCourse(ctx, instructorID, courseID, domain.CourseStaffRole.IsOwner); err != nil {
+		return nil, err
+	}
+
+	return uc.SetCourseStaff(ctx, courseID, userID, role)
+}
+
+// RemoveInstructorCourseStaff removes a teacher from a course the caller owns
+func (uc *courseUseCase) RemoveInstructorCourseStaff(ctx context.Context, instructorID, courseID, userID uuid.UUID) error {
+	if _, err := uc.staffCourse(ctx, instructorID, courseID, domain.CourseStaffRole.IsOwner); err != nil {
+		return err
+	}
+
+	return uc.RemoveCourseStaff(ctx, courseID, userID)
+}
+
+// ListCourseStaff lists the staff of a course
+func (uc *courseUseCase) ListCourseStaff(ctx context.Context, courseID uuid.UUID) ([]*domain.CourseStaff, error) {
+	if _, err := uc.courseRepo.GetByID(ctx, courseID); err != nil {
+		return nil, err
+	}
+
+	return uc.staffRepo.ListByCourse(ctx, courseID)
+}
+
+// SetCourseStaff adds a teacher to a course as co-instructor or TA, or changes their role.
+// The owner is the course instructor and cannot be changed here.
+func (uc *courseUseCase) SetCourseStaff(ctx context.Context, courseID, userID uuid.UUID, role domain.CourseStaffRole) (*domain.CourseStaff, error) {
+	if !role.IsValid() || role == domain.CourseStaffOwner {
+		return nil, domain.ErrInvalidCourseStaffRole
+	}
+
+	course, err := uc.courseRepo.GetByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course.InstructorID == userID {
+		return nil, domain.ErrCourseOwnerImmutable
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Only roles granted the teach permission can be staff, as course content checks it too
+	canTeach, err := uc.permissions.HasPermission(ctx, user.Role, domain.PermissionCoursesTeach)
+	if err != nil {
+		return nil, err
+	}
+	if !canTeach {
+		return nil, domain.ErrUserCannotTeach
+	}
+
+	var before *domain.CourseStaff
+	if previousRole, err := uc.staffRepo.GetRole(ctx, courseID, userID); err == nil {
+		before = &domain.CourseStaff{CourseID: courseID, UserID: userID, Role: previousRole, FullName: user.FullName}
+	} else if !errors.Is(err, domain.ErrCourseStaffNotFound) {
+		return nil, err
+	}
+
+	staff := &domain.CourseStaff{
+		CourseID: courseID,
+		UserID:   userID,
+		Role:     role,
+		FullName: user.FullName,
+		Avatar:   user.Avatar,
+	}
+	if err := uc.staffRepo.Upsert(ctx, staff); err != nil {
+		return nil, err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionCourseStaffSet, domain.AuditTargetCourse, courseID, before, staff)
+	return staff, nil
+}
+
+// RemoveCourseStaff removes a co-instructor or TA from a course
+func (uc *courseUseCase) RemoveCourseStaff(ctx context.Context, courseID, userID uuid.UUID) error {
+	role, err := uc.staffRepo.GetRole(ctx, courseID, userID)
+	if err != nil {
+		return err
+	}
+	if role == domain.CourseStaffOwner {
+		return domain.ErrCourseOwnerImmutable
+	}
+
+	if err := uc.staffRepo.Delete(ctx, courseID, userID); err != nil {
+		return err
+	}
+
+	before := &domain.CourseStaff{CourseID: courseID, UserID: userID, Role: role}
+	uc.audit.record(ctx, domain.AuditActionCourseStaffRemove, domain.AuditTargetCourse, courseID, before, nil)
+	return nil
+}
+
+// staffCourse returns the course if the teacher is on its staff with a role allowed
+// accepts, a nil allowed accepts every staff role
+func (uc *courseUseCase) staffCourse(ctx context.Context, userID, courseID uuid.UUID, allowed func(domain.CourseStaffRole) bool) (*domain.Course, error) {
+	course, err := uc.courseRepo.GetByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := uc.staffRepo.GetRole(ctx, courseID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrCourseStaffNotFound) {
+			return nil, domain.ErrCourseAccessDenied
+		}
+		return nil, err
+	}
+
+	if allowed != nil && !allowed(role) {
+		return nil, domain.ErrCourseAccessDenied
+	}
+
+	return course, nil
+}
+
+// normalizePage applies the default page size of 20 and caps it at 100
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	return page, pageSize
+}
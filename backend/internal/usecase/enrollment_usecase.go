@@ -10,20 +10,32 @@ import (
 // ActivateCourseInput represents the input for course activation
 type ActivateCourseInput struct {
 	Code string `json:"code" binding:"required"`
+	ClientInfo
 }
 
 // ActivateCourseResult represents the result of course activation
 type ActivateCourseResult struct {
-	Enrollment *domain.Enrollment `json:"enrollment"`
-	Course     *domain.Course     `json:"course"`
+	Enrollment  *domain.Enrollment   `json:"enrollment"`  // First course unlocked, kept for older clients
+	Course      *domain.Course       `json:"course"`      // Course of Enrollment
+	Enrollments []*domain.Enrollment `json:"enrollments"` // Every course unlocked, with its course
+}
+
+// ActivationCodeAccessInput describes how long an activation code gives access and which other courses it unlocks
+type ActivationCodeAccessInput struct {
+	AccessType  domain.AccessType `json:"access_type"`  // Optional: days (default), until or lifetime
+	AccessDays  *int              `json:"access_days"`  // Optional for days: defaults to 365
+	AccessUntil *string           `json:"access_until"` // Required for until, format: RFC3339
+	CourseIDs   []uuid.UUID       `json:"course_ids"`   // Optional: courses unlocked besides course_id
+	BundleID    *uuid.UUID        `json:"bundle_id"`    // Optional: every course of the bundle is unlocked too
 }
 
 // CreateActivationCodeInput represents the input for creating an activation code
 type CreateActivationCodeInput struct {
-	CourseID  uuid.UUID `json:"course_id" binding:"required"`
+	CourseID  uuid.UUID `json:"course_id"`  // Optional with bundle_id: defaults to the first course of the bundle
 	MaxUses   *int      `json:"max_uses"`   // Optional: nil = unlimited
 	ExpiresAt *string   `json:"expires_at"` // Optional: nil = never expires, format: RFC3339
 	Note      *string   `json:"note"`       // Optional note
+	ActivationCodeAccessInput
 }
 
 // CreateActivationCodeResult represents the result of creating an activation code
@@ -31,6 +43,25 @@ type CreateActivationCodeResult struct {
 	ActivationCode *domain.ActivationCode `json:"activation_code"`
 }
 
+// CreateActivationCodeBatchInput represents the input for generating activation codes in bulk
+type CreateActivationCodeBatchInput struct {
+	CourseID  uuid.UUID                   `json:"course_id"` // Optional with bundle_id: defaults to the first course of the bundle
+	Quantity  int                         `json:"quantity" binding:"required,min=1,max=1000"`
+	MaxUses   *int                        `json:"max_uses"`   // Optional: nil = unlimited, applies to every code
+	ExpiresAt *string                     `json:"expires_at"` // Optional: nil = never expires, format: RFC3339
+	Prefix    string                      `json:"prefix"`     // Optional: up to 10 letters or digits, e.g. BOOK
+	Label     string                      `json:"label" binding:"required"`
+	Format    domain.ActivationCodeFormat `json:"format" binding:"omitempty,oneof=simple hex"` // Optional: simple (default) or hex
+	ActivationCodeAccessInput
+}
+
+// CourseBundleInput represents the input for creating or updating a course bundle
+type CourseBundleInput struct {
+	Name        string      `json:"name" binding:"required"`
+	Description *string     `json:"description"`
+	CourseIDs   []uuid.UUID `json:"course_ids" binding:"required,min=1"` // In display order
+}
+
 // EnrollmentUseCase defines the interface for enrollment use cases
 type EnrollmentUseCase interface {
 	// ActivateCourse redeems an activation code and enrolls the user in the course
@@ -56,4 +87,43 @@ type EnrollmentUseCase interface {
 
 	// UpdateActivationCode updates an activation code status (admin only)
 	UpdateActivationCode(ctx context.Context, id uuid.UUID, isActive bool) (*domain.ActivationCode, error)
+
+	// CreateActivationCodeBatch generates a batch of activation codes for a course (admin only)
+	CreateActivationCodeBatch(ctx context.Context, adminID uuid.UUID, input *CreateActivationCodeBatchInput) (*domain.ActivationCodeBatch, error)
+
+	// ListActivationCodeBatches lists activation code batches (admin only)
+	ListActivationCodeBatches(ctx context.Context, page, pageSize int, courseID *string) ([]*domain.ActivationCodeBatch, int, error)
+
+	// GetActivationCodeBatch retrieves a batch with its codes (admin only)
+	GetActivationCodeBatch(ctx context.Context, id uuid.UUID) (*domain.ActivationCodeBatch, error)
+
+	// ListCodeRedemptions lists who redeemed an activation code, when and from where (admin only)
+	ListCodeRedemptions(ctx context.Context, codeID uuid.UUID, page, pageSize int) ([]*domain.CodeRedemption, int, error)
+
+	// GetActivationCodeBatchStats shows the redemption rate of a batch over time and its remaining codes (admin only)
+	GetActivationCodeBatchStats(ctx context.Context, batchID uuid.UUID, interval domain.RedemptionInterval) (*domain.ActivationCodeBatchStats, error)
+
+	// ListActivationLockouts lists the users and client IPs blocked from redeeming codes after too many unknown codes (admin only)
+	ListActivationLockouts(ctx context.Context, page, pageSize int) ([]*domain.ActivationLockout, int, error)
+
+	// ClearUserActivationLockout lets a locked out user redeem codes again (admin only)
+	ClearUserActivationLockout(ctx context.Context, adminID, userID uuid.UUID) error
+
+	// ClearIPActivationLockout lets a locked out client IP redeem codes again (admin only)
+	ClearIPActivationLockout(ctx context.Context, adminID uuid.UUID, ip string) error
+
+	// CreateCourseBundle creates a package of courses that activation codes can unlock (admin only)
+	CreateCourseBundle(ctx context.Context, adminID uuid.UUID, input *CourseBundleInput) (*domain.CourseBundle, error)
+
+	// ListCourseBundles lists course bundles (admin only)
+	ListCourseBundles(ctx context.Context, page, pageSize int) ([]*domain.CourseBundle, int, error)
+
+	// GetCourseBundle retrieves a course bundle with its courses (admin only)
+	GetCourseBundle(ctx context.Context, id uuid.UUID) (*domain.CourseBundle, error)
+
+	// UpdateCourseBundle updates a course bundle, codes already sold unlock its new courses (admin only)
+	UpdateCourseBundle(ctx context.Context, id uuid.UUID, input *CourseBundleInput) (*domain.CourseBundle, error)
+
+	// DeleteCourseBundle deletes a course bundle no activation code uses (admin only)
+	DeleteCourseBundle(ctx context.Context, id uuid.UUID) error
 }
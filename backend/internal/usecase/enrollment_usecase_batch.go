@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// batchPrefixPattern keeps prefixes short enough for the code column and easy to type
+var batchPrefixPattern = regexp.MustCompile(`^[A-Z0-9]{1,10}$`)
+
+// CreateActivationCodeBatch generates a batch of activation codes for a course (admin only)
+func (uc *enrollmentUseCase) CreateActivationCodeBatch(ctx context.Context, adminID uuid.UUID, input *CreateActivationCodeBatchInput) (*domain.ActivationCodeBatch, error) {
+	grant, err := uc.resolveGrant(ctx, input.CourseID, &input.ActivationCodeAccessInput)
+	if err != nil {
+		return nil, err
+	}
+
+	label := strings.TrimSpace(input.Label)
+	if label == "" || input.Quantity < 1 {
+		return nil, domain.ErrInvalidActivationCodeBatch
+	}
+	if input.MaxUses != nil && *input.MaxUses < 1 {
+		return nil, domain.ErrInvalidActivationCodeBatch
+	}
+
+	format := input.Format
+	if format == "" {
+		format = domain.ActivationCodeFormatSimple
+	}
+	if !format.IsValid() {
+		return nil, domain.ErrInvalidActivationCodeBatch
+	}
+
+	var prefix *string
+	if p := strings.ToUpper(strings.TrimSpace(input.Prefix)); p != "" {
+		if !batchPrefixPattern.MatchString(p) {
+			return nil, domain.ErrInvalidActivationCodeBatch
+		}
+		prefix = &p
+	}
+
+	var expiresAt *time.Time
+	if input.ExpiresAt != nil && *input.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, *input.ExpiresAt)
+		if err != nil || !t.After(time.Now()) {
+			return nil, domain.ErrInvalidActivationCodeBatch
+		}
+		expiresAt = &t
+	}
+
+	batch := &domain.ActivationCodeBatch{
+		ID:          uuid.New(),
+		Label:       label,
+		CourseID:    grant.course.ID,
+		Prefix:      prefix,
+		Quantity:    input.Quantity,
+		MaxUses:     input.MaxUses,
+		ExpiresAt:   expiresAt,
+		AccessType:  grant.accessType,
+		AccessDays:  grant.accessDays,
+		AccessUntil: grant.accessUntil,
+		BundleID:    grant.bundleID,
+		CourseIDs:   grant.courseIDs,
+		CreatedBy:   adminID,
+	}
+
+	generate := func() (string, error) {
+		code, err := format.Generate()
+		if err != nil || prefix == nil {
+			return code, err
+		}
+		return *prefix + "-" + code, nil
+	}
+
+	if err := uc.batchRepo.CreateWithCodes(ctx, batch, generate); err != nil {
+		return nil, err
+	}
+	batch.Course = grant.course
+
+	// One entry for the batch rather than one per code
+	snapshot := *batch
+	snapshot.Course = nil
+	snapshot.Codes = nil
+	uc.audit.record(ctx, domain.AuditActionActivationCodeBatchCreate, domain.AuditTargetActivationCodeBatch, batch.ID, nil, &snapshot)
+
+	return batch, nil
+}
+
+// ListActivationCodeBatches lists activation code batches (admin only)
+func (uc *enrollmentUseCase) ListActivationCodeBatches(ctx context.Context, page, pageSize int, courseID *string) ([]*domain.ActivationCodeBatch, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	var cID *uuid.UUID
+	if courseID != nil && *courseID != "" {
+		id, err := uuid.Parse(*courseID)
+		if err == nil {
+			cID = &id
+		}
+	}
+
+	return uc.batchRepo.List(ctx, cID, pageSize, offset)
+}
+
+// GetActivationCodeBatch retrieves a batch with its codes (admin only)
+func (uc *enrollmentUseCase) GetActivationCodeBatch(ctx context.Context, id uuid.UUID) (*domain.ActivationCodeBatch, error) {
+	batch, err := uc.batchRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Codes, err = uc.batchRepo.ListCodes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every code of the batch unlocks the same extra courses
+	if len(batch.Codes) > 0 {
+		batch.CourseIDs = batch.Codes[0].CourseIDs
+	}
+
+	return batch, nil
+}
+
+// ListCodeRedemptions lists who redeemed an activation code, when and from where (admin only)
+func (uc *enrollmentUseCase) ListCodeRedemptions(ctx context.Context, codeID uuid.UUID, page, pageSize int) ([]*domain.CodeRedemption, int, error) {
+	if _, err := uc.activationCodeRepo.GetByID(ctx, codeID); err != nil {
+		return nil, 0, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	return uc.redemptionRepo.ListByCode(ctx, codeID, pageSize, offset)
+}
+
+// GetActivationCodeBatchStats shows the redemption rate of a batch over time and its remaining codes (admin only)
+func (uc *enrollmentUseCase) GetActivationCodeBatchStats(ctx context.Context, batchID uuid.UUID, interval domain.RedemptionInterval) (*domain.ActivationCodeBatchStats, error) {
+	if interval == "" {
+		interval = domain.RedemptionIntervalDay
+	}
+	if !interval.IsValid() {
+		return nil, domain.ErrInvalidRedemptionInterval
+	}
+
+	if _, err := uc.batchRepo.GetByID(ctx, batchID); err != nil {
+		return nil, err
+	}
+
+	return uc.redemptionRepo.GetBatchStats(ctx, batchID, interval)
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+const (
+	// maxAccessDays bounds the access length of a code to ten years
+	maxAccessDays = 3650
+	// maxGrantCourses bounds how many courses one code or bundle unlocks
+	maxGrantCourses = 50
+)
+
+// activationCodeGrant is the validated access policy and course set of new activation codes
+type activationCodeGrant struct {
+	course      *domain.Course // Course of the codes, the first one unlocked
+	accessType  domain.AccessType
+	accessDays  *int
+	accessUntil *time.Time
+	bundleID    *uuid.UUID
+	courseIDs   []uuid.UUID // Courses unlocked besides course
+}
+
+// resolveGrant validates what new codes unlock and for how long. Without a course ID the
+// first course of the bundle is used.
+func (uc *enrollmentUseCase) resolveGrant(ctx context.Context, courseID uuid.UUID, input *ActivationCodeAccessInput) (*activationCodeGrant, error) {
+	grant := &activationCodeGrant{accessType: input.AccessType}
+	if grant.accessType == "" {
+		grant.accessType = domain.AccessTypeDays
+	}
+
+	switch grant.accessType {
+	case domain.AccessTypeDays:
+		days := domain.DefaultAccessDays
+		if input.AccessDays != nil {
+			days = *input.AccessDays
+		}
+		if days < 1 || days > maxAccessDays {
+			return nil, domain.ErrInvalidAccessPolicy
+		}
+		grant.accessDays = &days
+	case domain.AccessTypeUntil:
+		if input.AccessUntil == nil {
+			return nil, domain.ErrInvalidAccessPolicy
+		}
+		t, err := time.Parse(time.RFC3339, *input.AccessUntil)
+		if err != nil || !t.After(time.Now()) {
+			return nil, domain.ErrInvalidAccessPolicy
+		}
+		grant.accessUntil = &t
+	case domain.AccessTypeLifetime:
+	default:
+		return nil, domain.ErrInvalidAccessPolicy
+	}
+
+	if input.BundleID != nil {
+		bundle, err := uc.bundleRepo.GetByID(ctx, *input.BundleID)
+		if err != nil {
+			return nil, err
+		}
+		if len(bundle.CourseIDs) == 0 {
+			return nil, domain.ErrInvalidCourseBundle
+		}
+		if courseID == uuid.Nil {
+			courseID = bundle.CourseIDs[0]
+		}
+		grant.bundleID = &bundle.ID
+	}
+	if courseID == uuid.Nil {
+		return nil, domain.ErrInvalidAccessPolicy
+	}
+
+	course, err := uc.courseRepo.GetByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	grant.course = course
+
+	grant.courseIDs, err = uc.checkCourseIDs(ctx, input.CourseIDs, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+// checkCourseIDs removes duplicates and the excluded course, and checks the other courses exist
+func (uc *enrollmentUseCase) checkCourseIDs(ctx context.Context, courseIDs []uuid.UUID, exclude uuid.UUID) ([]uuid.UUID, error) {
+	if len(courseIDs) > maxGrantCourses {
+		return nil, domain.ErrInvalidAccessPolicy
+	}
+
+	seen := map[uuid.UUID]bool{exclude: true}
+	unique := []uuid.UUID{}
+	for _, id := range courseIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if _, err := uc.courseRepo.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		unique = append(unique, id)
+	}
+
+	return unique, nil
+}
+
+// CreateCourseBundle creates a package of courses that activation codes can unlock (admin only)
+func (uc *enrollmentUseCase) CreateCourseBundle(ctx context.Context, adminID uuid.UUID, input *CourseBundleInput) (*domain.CourseBundle, error) {
+	bundle := &domain.CourseBundle{
+		ID:        uuid.New(),
+		CreatedBy: adminID,
+	}
+	if err := uc.applyBundleInput(ctx, bundle, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.bundleRepo.Create(ctx, bundle); err != nil {
+		return nil, err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionCourseBundleCreate, domain.AuditTargetCourseBundle, bundle.ID, nil, bundle)
+
+	return bundle, nil
+}
+
+// ListCourseBundles lists course bundles (admin only)
+func (uc *enrollmentUseCase) ListCourseBundles(ctx context.Context, page, pageSize int) ([]*domain.CourseBundle, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	return uc.bundleRepo.List(ctx, pageSize, offset)
+}
+
+// GetCourseBundle retrieves a course bundle with its courses (admin only)
+func (uc *enrollmentUseCase) GetCourseBundle(ctx context.Context, id uuid.UUID) (*domain.CourseBundle, error) {
+	bundle, err := uc.bundleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle.Courses = make([]*domain.Course, 0, len(bundle.CourseIDs))
+	for _, courseID := range bundle.CourseIDs {
+		course, err := uc.courseRepo.GetByID(ctx, courseID)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Courses = append(bundle.Courses, course)
+	}
+
+	return bundle, nil
+}
+
+// UpdateCourseBundle updates a course bundle, codes already sold unlock its new courses (admin only)
+func (uc *enrollmentUseCase) UpdateCourseBundle(ctx context.Context, id uuid.UUID, input *CourseBundleInput) (*domain.CourseBundle, error) {
+	before, err := uc.bundleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := *before
+	if err := uc.applyBundleInput(ctx, &bundle, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.bundleRepo.Update(ctx, &bundle); err != nil {
+		return nil, err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionCourseBundleUpdate, domain.AuditTargetCourseBundle, bundle.ID, before, &bundle)
+
+	return &bundle, nil
+}
+
+// DeleteCourseBundle deletes a course bundle no activation code uses (admin only)
+func (uc *enrollmentUseCase) DeleteCourseBundle(ctx context.Context, id uuid.UUID) error {
+	before, err := uc.bundleRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := uc.bundleRepo.IsInUse(ctx, id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return domain.ErrCourseBundleInUse
+	}
+
+	if err := uc.bundleRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionCourseBundleDelete, domain.AuditTargetCourseBundle, id, before, nil)
+
+	return nil
+}
+
+// applyBundleInput validates the input and sets it on the bundle
+func (uc *enrollmentUseCase) applyBundleInput(ctx context.Context, bundle *domain.CourseBundle, input *CourseBundleInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return domain.ErrInvalidCourseBundle
+	}
+
+	courseIDs, err := uc.checkCourseIDs(ctx, input.CourseIDs, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	if len(courseIDs) == 0 {
+		return domain.ErrInvalidCourseBundle
+	}
+
+	bundle.Name = name
+	bundle.Description = input.Description
+	bundle.CourseIDs = courseIDs
+	return nil
+}
@@ -2,10 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mathvn/backend/config"
 	"github.com/mathvn/backend/internal/domain"
 	"github.com/mathvn/backend/internal/repository"
 )
@@ -14,22 +16,49 @@ import (
 type enrollmentUseCase struct {
 	enrollmentRepo     repository.EnrollmentRepository
 	activationCodeRepo repository.ActivationCodeRepository
+	batchRepo          repository.ActivationCodeBatchRepository
+	bundleRepo         repository.CourseBundleRepository
+	redemptionRepo     repository.CodeRedemptionRepository
 	courseRepo         repository.CourseRepository
 	userRepo           repository.UserRepository
+	uow                repository.UnitOfWork
+	loginAttemptRepo   repository.LoginAttemptRepository // Also counts unknown activation codes
+	securityEventRepo  repository.SecurityEventRepository
+	requireVerified    bool // Only verified accounts may activate courses
+	limitConfig        config.ActivationLimitConfig
+	audit              auditRecorder
 }
 
 // NewEnrollmentUseCase creates a new enrollment use case
 func NewEnrollmentUseCase(
 	enrollmentRepo repository.EnrollmentRepository,
 	activationCodeRepo repository.ActivationCodeRepository,
+	batchRepo repository.ActivationCodeBatchRepository,
+	bundleRepo repository.CourseBundleRepository,
+	redemptionRepo repository.CodeRedemptionRepository,
 	courseRepo repository.CourseRepository,
 	userRepo repository.UserRepository,
+	uow repository.UnitOfWork,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	securityEventRepo repository.SecurityEventRepository,
+	requireVerified bool,
+	limitConfig config.ActivationLimitConfig,
+	auditLogRepo repository.AuditLogRepository,
 ) EnrollmentUseCase {
 	return &enrollmentUseCase{
 		enrollmentRepo:     enrollmentRepo,
 		activationCodeRepo: activationCodeRepo,
+		batchRepo:          batchRepo,
+		bundleRepo:         bundleRepo,
+		redemptionRepo:     redemptionRepo,
 		courseRepo:         courseRepo,
 		userRepo:           userRepo,
+		uow:                uow,
+		loginAttemptRepo:   loginAttemptRepo,
+		securityEventRepo:  securityEventRepo,
+		requireVerified:    requireVerified,
+		limitConfig:        limitConfig,
+		audit:              auditRecorder{auditLogRepo: auditLogRepo},
 	}
 }
 
@@ -41,57 +70,131 @@ func (uc *enrollmentUseCase) ActivateCourse(ctx context.Context, userID uuid.UUI
 		return nil, domain.ErrActivationCodeInvalid
 	}
 
-	// Get the activation code
-	activationCode, err := uc.activationCodeRepo.GetByCode(ctx, code)
-	if err != nil {
+	// Refuse users and client IPs that entered too many unknown codes. The attempt is counted
+	// before the lookup, so concurrent guesses cannot all pass before a lockout is written.
+	if err := uc.takeActivationAttempt(ctx, userID, &input.ClientInfo); err != nil {
 		return nil, err
 	}
+	unknownCode := false
+	defer func() {
+		if !unknownCode {
+			uc.releaseActivationAttempt(ctx, userID, &input.ClientInfo)
+		}
+	}()
 
-	// Validate the activation code
-	if err := activationCode.IsValid(); err != nil {
-		return nil, err
+	// Check that the account email is verified when required
+	if uc.requireVerified {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !user.IsVerified {
+			return nil, domain.ErrEmailNotVerified
+		}
 	}
 
-	// Check if user is already enrolled
-	isEnrolled, err := uc.enrollmentRepo.IsEnrolled(ctx, userID, activationCode.CourseID)
+	// A wrong check character is a typo or a guess, rejected without looking the code up
+	if !domain.HasValidChecksum(code) {
+		unknownCode = true
+		return nil, domain.ErrActivationCodeInvalid
+	}
+
+	// Get the activation code
+	activationCode, err := uc.activationCodeRepo.GetByCode(ctx, code)
+	unknownCode = errors.Is(err, domain.ErrActivationCodeNotFound)
 	if err != nil {
 		return nil, err
 	}
-	if isEnrolled {
-		return nil, domain.ErrAlreadyEnrolled
+
+	// Validate the activation code, the transaction below checks again
+	if err := activationCode.IsValid(); err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
-	expiresAt := now.AddDate(1, 0, 0) // Valid for 1 year from activation
+	expiresAt := activationCode.EnrollmentExpiresAt(now)
 
-	// Create enrollment
-	enrollment := &domain.Enrollment{
-		ID:               uuid.New(),
-		UserID:           userID,
-		CourseID:         activationCode.CourseID,
-		ActivationCodeID: &activationCode.ID,
-		EnrolledAt:       now,
-		ExpiresAt:        &expiresAt,
-		Status:           domain.EnrollmentStatusActive,
-	}
+	// Taking a use and enrolling happen in one transaction. The conditional increment comes
+	// first: it locks the code row, so concurrent redemptions of the last use wait and then fail,
+	// and a failed enrollment gives the use back by rolling back.
+	var enrollments []*domain.Enrollment
+	err = uc.uow.Do(ctx, func(repos repository.TxRepositories) error {
+		enrollments = nil
 
-	// If activation code has expiry, set enrollment expiry too (optional logic)
-	// For now, we give lifetime access once activated
+		if err := repos.ActivationCodes().IncrementUses(ctx, activationCode.ID); err != nil {
+			return err
+		}
 
-	if err := uc.enrollmentRepo.Create(ctx, enrollment); err != nil {
+		// Every course the code unlocks, the code's own course first
+		courseIDs, err := repos.ActivationCodes().ListGrantedCourseIDs(ctx, activationCode.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, courseID := range courseIDs {
+			enrollment, err := repos.Enrollments().GetByUserAndCourse(ctx, userID, courseID)
+			switch {
+			case errors.Is(err, domain.ErrEnrollmentNotFound):
+				enrollment = &domain.Enrollment{
+					ID:               uuid.New(),
+					UserID:           userID,
+					CourseID:         courseID,
+					ActivationCodeID: &activationCode.ID,
+					EnrolledAt:       now,
+					ExpiresAt:        expiresAt,
+					Status:           domain.EnrollmentStatusActive,
+				}
+				if err := repos.Enrollments().Create(ctx, enrollment); err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			case enrollment.IsActive():
+				// Courses the user can already study are left as they are
+				continue
+			default:
+				// Expired or cancelled enrollments are renewed with the code's access
+				enrollment.Status = domain.EnrollmentStatusActive
+				enrollment.ExpiresAt = expiresAt
+				if err := repos.Enrollments().Update(ctx, enrollment); err != nil {
+					return err
+				}
+			}
+			enrollments = append(enrollments, enrollment)
+		}
+
+		if len(enrollments) == 0 {
+			return domain.ErrAlreadyEnrolled
+		}
+
+		// Kept for disputes about who used a code
+		return repos.CodeRedemptions().Create(ctx, &domain.CodeRedemption{
+			ActivationCodeID: activationCode.ID,
+			UserID:           &userID,
+			CoursesUnlocked:  len(enrollments),
+			IPAddress:        optionalString(input.IPAddress),
+			UserAgent:        optionalString(input.UserAgent),
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Increment activation code uses
-	if err := uc.activationCodeRepo.IncrementUses(ctx, activationCode.ID); err != nil {
-		// Log error but don't fail the activation
-		// In production, this should be handled in a transaction
+	uc.resetActivationFailures(ctx, userID)
+
+	// Attach course info for the result
+	for _, enrollment := range enrollments {
+		if enrollment.CourseID == activationCode.CourseID {
+			enrollment.Course = activationCode.Course
+		} else if enrollment.Course, err = uc.courseRepo.GetByID(ctx, enrollment.CourseID); err != nil {
+			return nil, err
+		}
 	}
 
-	// Return result with course info
 	return &ActivateCourseResult{
-		Enrollment: enrollment,
-		Course:     activationCode.Course,
+		Enrollment:  enrollments[0],
+		Course:      enrollments[0].Course,
+		Enrollments: enrollments,
 	}, nil
 }
 
@@ -112,8 +215,8 @@ func (uc *enrollmentUseCase) GetEnrollment(ctx context.Context, userID, courseID
 
 // CreateActivationCode creates a new activation code (admin only)
 func (uc *enrollmentUseCase) CreateActivationCode(ctx context.Context, adminID uuid.UUID, input *CreateActivationCodeInput) (*CreateActivationCodeResult, error) {
-	// Verify course exists
-	_, err := uc.courseRepo.GetByID(ctx, input.CourseID)
+	// Verify the courses exist and the access policy is valid
+	grant, err := uc.resolveGrant(ctx, input.CourseID, &input.ActivationCodeAccessInput)
 	if err != nil {
 		return nil, err
 	}
@@ -138,19 +241,26 @@ func (uc *enrollmentUseCase) CreateActivationCode(ctx context.Context, adminID u
 	activationCode := &domain.ActivationCode{
 		ID:          uuid.New(),
 		Code:        codeStr,
-		CourseID:    input.CourseID,
+		CourseID:    grant.course.ID,
 		MaxUses:     input.MaxUses,
 		CurrentUses: 0,
 		ExpiresAt:   expiresAt,
 		IsActive:    true,
 		CreatedBy:   adminID,
 		Note:        input.Note,
+		AccessType:  grant.accessType,
+		AccessDays:  grant.accessDays,
+		AccessUntil: grant.accessUntil,
+		BundleID:    grant.bundleID,
+		CourseIDs:   grant.courseIDs,
 	}
 
 	if err := uc.activationCodeRepo.Create(ctx, activationCode); err != nil {
 		return nil, err
 	}
 
+	uc.audit.record(ctx, domain.AuditActionActivationCodeCreate, domain.AuditTargetActivationCode, activationCode.ID, nil, activationCode)
+
 	return &CreateActivationCodeResult{
 		ActivationCode: activationCode,
 	}, nil
@@ -179,7 +289,17 @@ func (uc *enrollmentUseCase) ListActivationCodes(ctx context.Context, page, page
 
 // DeleteActivationCode deletes an activation code (admin only)
 func (uc *enrollmentUseCase) DeleteActivationCode(ctx context.Context, id uuid.UUID) error {
-	return uc.activationCodeRepo.Delete(ctx, id)
+	code, err := uc.activationCodeRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.activationCodeRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.audit.record(ctx, domain.AuditActionActivationCodeDelete, domain.AuditTargetActivationCode, id, code, nil)
+	return nil
 }
 
 // UpdateActivationCode updates an activation code status (admin only)
@@ -189,6 +309,7 @@ func (uc *enrollmentUseCase) UpdateActivationCode(ctx context.Context, id uuid.U
 		return nil, err
 	}
 
+	before := *code
 	code.IsActive = isActive
 	code.UpdatedAt = time.Now()
 
@@ -196,5 +317,7 @@ func (uc *enrollmentUseCase) UpdateActivationCode(ctx context.Context, id uuid.U
 		return nil, err
 	}
 
+	uc.audit.record(ctx, domain.AuditActionActivationCodeUpdate, domain.AuditTargetActivationCode, id, &before, code)
+
 	return code, nil
 }
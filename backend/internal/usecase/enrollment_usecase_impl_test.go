@@ -0,0 +1,394 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/config"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+	"github.com/mathvn/backend/internal/repository/memory"
+)
+
+// redemptionStore holds the rows touched by a redemption. Its mutex stands in for the
+// database: a unit of work holds it for the whole transaction, like the row lock taken
+// by IncrementUses, and restores a snapshot when the transaction fails.
+type redemptionStore struct {
+	mu          sync.Mutex
+	codes       map[uuid.UUID]*domain.ActivationCode
+	enrollments []*domain.Enrollment
+	redemptions []*domain.CodeRedemption
+}
+
+func newRedemptionStore() *redemptionStore {
+	return &redemptionStore{codes: make(map[uuid.UUID]*domain.ActivationCode)}
+}
+
+// lock locks the store outside a transaction, a transaction already holds the lock
+func (s *redemptionStore) lock(inTx bool) func() {
+	if inTx {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// Do implements repository.UnitOfWork
+func (s *redemptionStore) Do(ctx context.Context, fn func(repos repository.TxRepositories) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	codes := make(map[uuid.UUID]domain.ActivationCode, len(s.codes))
+	for id, code := range s.codes {
+		codes[id] = *code
+	}
+	enrollments, redemptions := len(s.enrollments), len(s.redemptions)
+
+	if err := fn(fakeTxRepositories{store: s}); err != nil {
+		for id, code := range codes {
+			*s.codes[id] = code
+		}
+		s.enrollments, s.redemptions = s.enrollments[:enrollments], s.redemptions[:redemptions]
+		return err
+	}
+
+	return nil
+}
+
+// fakeTxRepositories implements the transaction repositories used by redemptions
+type fakeTxRepositories struct {
+	repository.TxRepositories
+	store *redemptionStore
+}
+
+func (r fakeTxRepositories) ActivationCodes() repository.ActivationCodeRepository {
+	return &fakeActivationCodeRepository{store: r.store, inTx: true}
+}
+
+func (r fakeTxRepositories) Enrollments() repository.EnrollmentRepository {
+	return &fakeEnrollmentRepository{store: r.store, inTx: true}
+}
+
+func (r fakeTxRepositories) CodeRedemptions() repository.CodeRedemptionRepository {
+	return &fakeCodeRedemptionRepository{store: r.store}
+}
+
+// fakeActivationCodeRepository implements the activation code methods used by redemptions
+type fakeActivationCodeRepository struct {
+	repository.ActivationCodeRepository
+	store   *redemptionStore
+	inTx    bool
+	readers *sync.WaitGroup // When set, lookups wait until every reader has read the code
+}
+
+func (r *fakeActivationCodeRepository) GetByCode(ctx context.Context, code string) (*domain.ActivationCode, error) {
+	found, err := r.getByCode(code)
+	if r.readers != nil {
+		r.readers.Done()
+		r.readers.Wait()
+	}
+	return found, err
+}
+
+func (r *fakeActivationCodeRepository) getByCode(code string) (*domain.ActivationCode, error) {
+	defer r.store.lock(r.inTx)()
+
+	for _, c := range r.store.codes {
+		if c.Code == code {
+			copied := *c
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrActivationCodeNotFound
+}
+
+func (r *fakeActivationCodeRepository) IncrementUses(ctx context.Context, id uuid.UUID) error {
+	defer r.store.lock(r.inTx)()
+
+	code, ok := r.store.codes[id]
+	if !ok {
+		return domain.ErrActivationCodeNotFound
+	}
+	if err := code.IsValid(); err != nil {
+		return err
+	}
+	code.CurrentUses++
+	return nil
+}
+
+func (r *fakeActivationCodeRepository) ListGrantedCourseIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	defer r.store.lock(r.inTx)()
+
+	code, ok := r.store.codes[id]
+	if !ok {
+		return nil, domain.ErrActivationCodeNotFound
+	}
+	return append([]uuid.UUID{code.CourseID}, code.CourseIDs...), nil
+}
+
+// fakeEnrollmentRepository implements the enrollment methods used by redemptions
+type fakeEnrollmentRepository struct {
+	repository.EnrollmentRepository
+	store *redemptionStore
+	inTx  bool
+}
+
+func (r *fakeEnrollmentRepository) GetByUserAndCourse(ctx context.Context, userID, courseID uuid.UUID) (*domain.Enrollment, error) {
+	defer r.store.lock(r.inTx)()
+
+	for _, e := range r.store.enrollments {
+		if e.UserID == userID && e.CourseID == courseID {
+			copied := *e
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrEnrollmentNotFound
+}
+
+func (r *fakeEnrollmentRepository) Create(ctx context.Context, enrollment *domain.Enrollment) error {
+	defer r.store.lock(r.inTx)()
+
+	copied := *enrollment
+	r.store.enrollments = append(r.store.enrollments, &copied)
+	return nil
+}
+
+// fakeCodeRedemptionRepository records redemptions, it is only used in transactions
+type fakeCodeRedemptionRepository struct {
+	repository.CodeRedemptionRepository
+	store *redemptionStore
+}
+
+func (r *fakeCodeRedemptionRepository) Create(ctx context.Context, redemption *domain.CodeRedemption) error {
+	copied := *redemption
+	r.store.redemptions = append(r.store.redemptions, &copied)
+	return nil
+}
+
+func TestActivateCourseConcurrentRedemptionsOfSingleUseCode(t *testing.T) {
+	const redeemers = 20
+
+	store := newRedemptionStore()
+	codeStr, err := domain.GenerateSimpleActivationCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	maxUses := 1
+	code := &domain.ActivationCode{
+		ID:         uuid.New(),
+		Code:       codeStr,
+		CourseID:   uuid.New(),
+		MaxUses:    &maxUses,
+		IsActive:   true,
+		AccessType: domain.AccessTypeLifetime,
+	}
+	store.codes[code.ID] = code
+
+	// Every redeemer reads the code as unused before any of them takes the use
+	var readers sync.WaitGroup
+	readers.Add(redeemers)
+
+	uc := NewEnrollmentUseCase(
+		&fakeEnrollmentRepository{store: store},
+		&fakeActivationCodeRepository{store: store, readers: &readers},
+		nil,
+		nil,
+		&fakeCodeRedemptionRepository{store: store},
+		nil,
+		nil,
+		store,
+		memory.NewLoginAttemptRepository(),
+		nil,
+		false,
+		config.ActivationLimitConfig{AttemptWindow: time.Hour},
+		nil,
+	)
+
+	start := make(chan struct{})
+	errs := make([]error, redeemers)
+	var wg sync.WaitGroup
+	for i := 0; i < redeemers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = uc.ActivateCourse(context.Background(), uuid.New(), &ActivateCourseInput{Code: codeStr})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded, usedUp := 0, 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, domain.ErrActivationCodeUsedUp):
+			usedUp++
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if succeeded != 1 {
+		t.Errorf("got %d successful redemptions, want 1", succeeded)
+	}
+	if usedUp != redeemers-1 {
+		t.Errorf("got %d used up errors, want %d", usedUp, redeemers-1)
+	}
+	if len(store.enrollments) != 1 {
+		t.Errorf("got %d enrollments, want 1", len(store.enrollments))
+	}
+	if len(store.redemptions) != 1 {
+		t.Errorf("got %d redemptions, want 1", len(store.redemptions))
+	}
+	if code.CurrentUses != 1 {
+		t.Errorf("got %d uses, want 1", code.CurrentUses)
+	}
+}
+
+// fakeSecurityEventRepository drops security events
+type fakeSecurityEventRepository struct {
+	repository.SecurityEventRepository
+}
+
+func (r *fakeSecurityEventRepository) Create(ctx context.Context, event *domain.SecurityEvent) error {
+	return nil
+}
+
+func TestActivateCourseConcurrentUnknownCodesStopAtLimit(t *testing.T) {
+	const (
+		guessers    = 20
+		maxAttempts = 3
+	)
+
+	store := newRedemptionStore()
+	attempts := memory.NewLoginAttemptRepository()
+	uc := NewEnrollmentUseCase(
+		&fakeEnrollmentRepository{store: store},
+		&fakeActivationCodeRepository{store: store},
+		nil,
+		nil,
+		&fakeCodeRedemptionRepository{store: store},
+		nil,
+		nil,
+		store,
+		attempts,
+		&fakeSecurityEventRepository{},
+		false,
+		config.ActivationLimitConfig{
+			MaxUserAttempts:     maxAttempts,
+			AttemptWindow:       time.Hour,
+			BaseLockoutDuration: time.Minute,
+			MaxLockoutDuration:  time.Hour,
+		},
+		nil,
+	)
+
+	userID := uuid.New()
+	start := make(chan struct{})
+	errs := make([]error, guessers)
+	var wg sync.WaitGroup
+	for i := 0; i < guessers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Unknown codes with a valid check character reach the lookup
+			code, err := domain.GenerateSimpleActivationCode()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			<-start
+			_, errs[i] = uc.ActivateCourse(context.Background(), userID, &ActivateCourseInput{Code: code})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	notFound, locked := 0, 0
+	for _, err := range errs {
+		switch {
+		case errors.Is(err, domain.ErrActivationCodeNotFound):
+			notFound++
+		case errors.Is(err, domain.ErrActivationLocked):
+			locked++
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if notFound != maxAttempts {
+		t.Errorf("got %d codes looked up, want %d", notFound, maxAttempts)
+	}
+	if locked != guessers-maxAttempts {
+		t.Errorf("got %d locked errors, want %d", locked, guessers-maxAttempts)
+	}
+
+	attempt, err := attempts.Get(context.Background(), activationUserKey(userID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !attempt.IsLocked() {
+		t.Error("user is not locked out")
+	}
+}
+
+func TestActivateCourseRejectsMistypedCodesBeforeLookup(t *testing.T) {
+	store := newRedemptionStore()
+	uc := NewEnrollmentUseCase(
+		&fakeEnrollmentRepository{store: store},
+		&fakeActivationCodeRepository{store: store},
+		nil,
+		nil,
+		&fakeCodeRedemptionRepository{store: store},
+		nil,
+		nil,
+		store,
+		memory.NewLoginAttemptRepository(),
+		&fakeSecurityEventRepository{},
+		false,
+		config.ActivationLimitConfig{
+			MaxUserAttempts:     100,
+			AttemptWindow:       time.Hour,
+			BaseLockoutDuration: time.Minute,
+			MaxLockoutDuration:  time.Hour,
+		},
+		nil,
+	)
+
+	generators := map[string]func() (string, error){
+		"simple": domain.GenerateSimpleActivationCode,
+		"hex":    domain.GenerateActivationCode,
+	}
+	for name, generate := range generators {
+		t.Run(name, func(t *testing.T) {
+			code, err := generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// A well-formed unknown code reaches the lookup
+			_, err = uc.ActivateCourse(context.Background(), uuid.New(), &ActivateCourseInput{Code: code})
+			if !errors.Is(err, domain.ErrActivationCodeNotFound) {
+				t.Fatalf("code %s: got %v, want %v", code, err, domain.ErrActivationCodeNotFound)
+			}
+
+			// Swapping the first character for another of the same alphabet breaks the check character
+			typo := []byte(code)
+			if typo[0] == 'A' {
+				typo[0] = 'B'
+			} else {
+				typo[0] = 'A'
+			}
+			_, err = uc.ActivateCourse(context.Background(), uuid.New(), &ActivateCourseInput{Code: string(typo)})
+			if !errors.Is(err, domain.ErrActivationCodeInvalid) {
+				t.Errorf("code %s: got %v, want %v", typo, err, domain.ErrActivationCodeInvalid)
+			}
+		})
+	}
+}
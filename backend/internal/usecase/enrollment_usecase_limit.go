@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// activationKeyPrefix is shared by every failed activation key, so they are kept apart from login keys
+const activationKeyPrefix = "activation:"
+
+// activationUserKey returns the failed activation key of a user
+func activationUserKey(userID uuid.UUID) string {
+	return activationKeyPrefix + "user:" + userID.String()
+}
+
+// activationIPKey returns the failed activation key of a client IP
+func activationIPKey(ip string) string {
+	return activationKeyPrefix + "ip:" + ip
+}
+
+// activationLimit is a failed activation key and the number of unknown codes it may enter
+type activationLimit struct {
+	key         string
+	maxAttempts int
+}
+
+// activationLimits returns the failed activation keys of a redemption, the client IP is skipped when unknown
+func (uc *enrollmentUseCase) activationLimits(userID uuid.UUID, client *ClientInfo) []activationLimit {
+	limits := []activationLimit{{activationUserKey(userID), uc.limitConfig.MaxUserAttempts}}
+	if client.IPAddress != "" {
+		limits = append(limits, activationLimit{activationIPKey(client.IPAddress), uc.limitConfig.MaxIPAttempts})
+	}
+	return limits
+}
+
+// takeActivationAttempt counts a redemption against the user and the client IP before the code is
+// looked up, and returns ErrActivationLocked if either one is locked or has no attempts left.
+// Counting first makes the check atomic: concurrent guesses each get their own count, so no more
+// than the limit reach the lookup before the lockout is written. Redemptions that turn out not to
+// be guesses give their attempt back with releaseActivationAttempt.
+func (uc *enrollmentUseCase) takeActivationAttempt(ctx context.Context, userID uuid.UUID, client *ClientInfo) error {
+	locked := false
+	var taken []string
+	for _, limit := range uc.activationLimits(userID, client) {
+		attempt, err := uc.loginAttemptRepo.RegisterFailure(ctx, limit.key, uc.limitConfig.AttemptWindow)
+		if err != nil {
+			uc.removeActivationFailures(ctx, taken...)
+			return err
+		}
+
+		switch {
+		case attempt.IsLocked():
+			// Requests refused during a lockout do not lengthen the next one
+			uc.removeActivationFailures(ctx, limit.key)
+			locked = true
+		case limit.maxAttempts > 0 && attempt.FailedCount > limit.maxAttempts:
+			// The attempt stays counted, so each lockout after this one is longer
+			uc.lockActivations(ctx, attempt, limit.maxAttempts, userID, client)
+			locked = true
+		default:
+			taken = append(taken, limit.key)
+		}
+	}
+
+	if locked {
+		uc.removeActivationFailures(ctx, taken...)
+		return domain.ErrActivationLocked
+	}
+
+	return nil
+}
+
+// releaseActivationAttempt gives back the attempt of a redemption whose code was found,
+// only unknown codes count toward a lockout
+func (uc *enrollmentUseCase) releaseActivationAttempt(ctx context.Context, userID uuid.UUID, client *ClientInfo) {
+	for _, limit := range uc.activationLimits(userID, client) {
+		uc.removeActivationFailures(ctx, limit.key)
+	}
+}
+
+// removeActivationFailures takes back one counted attempt of each key.
+// Errors are only logged, the key then keeps one attempt too many until its window ends.
+func (uc *enrollmentUseCase) removeActivationFailures(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := uc.loginAttemptRepo.RemoveFailure(ctx, key); err != nil {
+			log.Printf("failed to release activation attempt for %s: %v", key, err)
+		}
+	}
+}
+
+// lockActivations locks a key out once it has used up its attempts, longer for each attempt
+// past the limit. Errors are only logged because the redemption is rejected anyway.
+func (uc *enrollmentUseCase) lockActivations(ctx context.Context, attempt *domain.LoginAttempt, maxAttempts int, userID uuid.UUID, client *ClientInfo) {
+	key := attempt.Key
+	excess := attempt.FailedCount - maxAttempts - 1
+	lockedUntil := time.Now().Add(progressiveLockout(uc.limitConfig.BaseLockoutDuration, uc.limitConfig.MaxLockoutDuration, excess))
+	if err := uc.loginAttemptRepo.Lock(ctx, key, lockedUntil); err != nil {
+		log.Printf("failed to lock activations for %s: %v", key, err)
+		return
+	}
+
+	// Failures can keep coming from other users behind a locked IP, only the first lock is logged
+	if excess == 0 {
+		log.Printf("possible activation code guessing: %s locked after %d unknown codes, user %s, ip %q",
+			key, maxAttempts, userID, client.IPAddress)
+	}
+
+	event := &domain.SecurityEvent{
+		UserID:    &userID,
+		EventType: domain.SecurityEventActivationLocked,
+		IPAddress: optionalString(client.IPAddress),
+		UserAgent: optionalString(client.UserAgent),
+		Metadata: map[string]interface{}{
+			"key":          key,
+			"failed_count": attempt.FailedCount,
+			"locked_until": lockedUntil,
+		},
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record security event for %s: %v", key, err)
+	}
+}
+
+// resetActivationFailures clears the failed activations of a user after a successful redemption.
+// The client IP keeps its count so one valid code cannot hide guessing from other accounts.
+func (uc *enrollmentUseCase) resetActivationFailures(ctx context.Context, userID uuid.UUID) {
+	if err := uc.loginAttemptRepo.Reset(ctx, activationUserKey(userID)); err != nil {
+		log.Printf("failed to reset failed activations: %v", err)
+	}
+}
+
+// ListActivationLockouts lists the users and client IPs currently blocked from redeeming codes
+func (uc *enrollmentUseCase) ListActivationLockouts(ctx context.Context, page, pageSize int) ([]*domain.ActivationLockout, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	attempts, total, err := uc.loginAttemptRepo.ListLocked(ctx, activationKeyPrefix, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lockouts := make([]*domain.ActivationLockout, 0, len(attempts))
+	for _, attempt := range attempts {
+		lockout := &domain.ActivationLockout{
+			FailedCount:  attempt.FailedCount,
+			LastFailedAt: attempt.LastFailedAt,
+			LockedUntil:  *attempt.LockedUntil,
+		}
+
+		key := strings.TrimPrefix(attempt.Key, activationKeyPrefix)
+		if ip, ok := strings.CutPrefix(key, "ip:"); ok {
+			lockout.Scope = domain.ActivationLockoutScopeIP
+			lockout.IPAddress = &ip
+		} else {
+			userID, err := uuid.Parse(strings.TrimPrefix(key, "user:"))
+			if err != nil {
+				continue
+			}
+			lockout.Scope = domain.ActivationLockoutScopeUser
+			lockout.UserID = &userID
+
+			user, err := uc.userRepo.GetByID(ctx, userID)
+			switch {
+			case err == nil:
+				lockout.User = linkedUser(user)
+			case !errors.Is(err, domain.ErrUserNotFound):
+				return nil, 0, err
+			}
+		}
+		lockouts = append(lockouts, lockout)
+	}
+
+	return lockouts, total, nil
+}
+
+// ClearUserActivationLockout lets a user redeem codes again before the lockout ends
+func (uc *enrollmentUseCase) ClearUserActivationLockout(ctx context.Context, adminID, userID uuid.UUID) error {
+	return uc.clearActivationLockout(ctx, adminID, activationUserKey(userID), &userID, "")
+}
+
+// ClearIPActivationLockout lets a client IP redeem codes again before the lockout ends
+func (uc *enrollmentUseCase) ClearIPActivationLockout(ctx context.Context, adminID uuid.UUID, ip string) error {
+	return uc.clearActivationLockout(ctx, adminID, activationIPKey(ip), nil, ip)
+}
+
+// clearActivationLockout resets a locked key and records who unlocked it
+func (uc *enrollmentUseCase) clearActivationLockout(ctx context.Context, adminID uuid.UUID, key string, userID *uuid.UUID, ip string) error {
+	attempt, err := uc.loginAttemptRepo.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !attempt.IsLocked() {
+		return domain.ErrActivationLockNotFound
+	}
+
+	if err := uc.loginAttemptRepo.Reset(ctx, key); err != nil {
+		return err
+	}
+
+	event := &domain.SecurityEvent{
+		UserID:    userID,
+		EventType: domain.SecurityEventActivationUnlocked,
+		IPAddress: optionalString(ip),
+		Metadata: map[string]interface{}{
+			"key":          key,
+			"failed_count": attempt.FailedCount,
+			"unlocked_by":  adminID.String(),
+		},
+	}
+	if err := uc.securityEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record security event for %s: %v", key, err)
+	}
+
+	before := map[string]*domain.LoginAttempt{key: attempt}
+	after := map[string]*domain.LoginAttempt{}
+	if userID != nil {
+		uc.audit.record(ctx, domain.AuditActionUserActivationUnlock, domain.AuditTargetUser, *userID, before, after)
+	} else {
+		uc.audit.recordTarget(ctx, domain.AuditActionIPAddressActivationUnlock, domain.AuditTargetIPAddress, ip, before, after)
+	}
+
+	return nil
+}
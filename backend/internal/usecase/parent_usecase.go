@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+)
+
+// InviteChildInput represents the input for a parent inviting a student account
+type InviteChildInput struct {
+	EmailOrPhone string `json:"email_or_phone" binding:"required"`
+}
+
+// ParentUseCase defines the interface for parent accounts and their links to students
+type ParentUseCase interface {
+	// InviteChild sends a link request to a student, the parent sees the student once it is accepted.
+	// It succeeds without sending anything when no student has the email or phone number.
+	InviteChild(ctx context.Context, parentID uuid.UUID, input *InviteChildInput) error
+
+	// ListChildren lists the students who accepted the parent's invite
+	ListChildren(ctx context.Context, parentID uuid.UUID) ([]*domain.ParentLink, error)
+
+	// RemoveChild cancels an invite or unlinks a student
+	RemoveChild(ctx context.Context, parentID, studentID uuid.UUID) error
+
+	// GetChildEnrollments returns the active enrollments of a linked student
+	GetChildEnrollments(ctx context.Context, parentID, studentID uuid.UUID) ([]*domain.Enrollment, error)
+
+	// GetChildCourseProgress returns a linked student's progress in a course they are actively enrolled in
+	GetChildCourseProgress(ctx context.Context, parentID, studentID, courseID uuid.UUID) (*domain.CourseProgress, error)
+
+	// GetDashboard aggregates the progress of every linked student
+	GetDashboard(ctx context.Context, parentID uuid.UUID) (*domain.ParentDashboard, error)
+
+	// ListParentLinks lists the parents who invited or are linked to a student
+	ListParentLinks(ctx context.Context, studentID uuid.UUID) ([]*domain.ParentLink, error)
+
+	// AcceptParentLink lets a parent follow the student
+	AcceptParentLink(ctx context.Context, studentID, linkID uuid.UUID) error
+
+	// RemoveParentLink declines an invite or unlinks a parent
+	RemoveParentLink(ctx context.Context, studentID, linkID uuid.UUID) error
+}
@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+	"github.com/mathvn/backend/pkg/mailer"
+)
+
+type parentUseCase struct {
+	parentLinkRepo  repository.ParentLinkRepository
+	userRepo        repository.UserRepository
+	enrollmentRepo  repository.EnrollmentRepository
+	progressRepo    repository.ProgressRepository
+	progressUseCase ProgressUseCase
+	mailer          mailer.Mailer
+}
+
+// NewParentUseCase creates a new parent use case
+func NewParentUseCase(
+	parentLinkRepo repository.ParentLinkRepository,
+	userRepo repository.UserRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	progressRepo repository.ProgressRepository,
+	progressUseCase ProgressUseCase,
+	mailSender mailer.Mailer,
+) ParentUseCase {
+	return &parentUseCase{
+		parentLinkRepo:  parentLinkRepo,
+		userRepo:        userRepo,
+		enrollmentRepo:  enrollmentRepo,
+		progressRepo:    progressRepo,
+		progressUseCase: progressUseCase,
+		mailer:          mailSender,
+	}
+}
+
+// InviteChild sends a link request to a student. The outcome is the same whether or not
+// the email or phone number belongs to a student, so invites cannot be used to look up accounts.
+func (uc *parentUseCase) InviteChild(ctx context.Context, parentID uuid.UUID, input *InviteChildInput) error {
+	var student *domain.User
+	var err error
+	switch {
+	case isValidEmail(input.EmailOrPhone):
+		student, err = uc.userRepo.GetByEmail(ctx, input.EmailOrPhone)
+	case isValidPhoneNumber(input.EmailOrPhone):
+		student, err = uc.userRepo.GetByPhoneNumber(ctx, input.EmailOrPhone)
+	default:
+		return domain.ErrInvalidEmailOrPhone
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if student.Role != domain.RoleStudent || !student.IsActive || student.ID == parentID {
+		return nil
+	}
+
+	parent, err := uc.userRepo.GetByID(ctx, parentID)
+	if err != nil {
+		return err
+	}
+
+	link := &domain.ParentLink{
+		ParentID:  parentID,
+		StudentID: student.ID,
+	}
+	if err := uc.parentLinkRepo.Create(ctx, link); err != nil {
+		if errors.Is(err, domain.ErrParentLinkExists) {
+			return nil
+		}
+		return err
+	}
+
+	// The invite also shows up in the student's account, a lost email does not block it
+	if err := uc.sendInviteEmail(ctx, parent, student); err != nil {
+		log.Printf("failed to send parent invite to %s: %v", student.Email, err)
+	}
+
+	return nil
+}
+
+// ListChildren lists the students who accepted the parent's invite
+func (uc *parentUseCase) ListChildren(ctx context.Context, parentID uuid.UUID) ([]*domain.ParentLink, error) {
+	return uc.parentLinkRepo.ListAcceptedByParent(ctx, parentID)
+}
+
+// RemoveChild cancels an invite or unlinks a student
+func (uc *parentUseCase) RemoveChild(ctx context.Context, parentID, studentID uuid.UUID) error {
+	link, err := uc.parentLinkRepo.GetByParentAndStudent(ctx, parentID, studentID)
+	if err != nil {
+		return err
+	}
+
+	return uc.parentLinkRepo.Delete(ctx, link.ID)
+}
+
+// GetChildEnrollments returns the active enrollments of a linked student
+func (uc *parentUseCase) GetChildEnrollments(ctx context.Context, parentID, studentID uuid.UUID) ([]*domain.Enrollment, error) {
+	if err := uc.requireAcceptedLink(ctx, parentID, studentID); err != nil {
+		return nil, err
+	}
+
+	enrollments, err := uc.enrollmentRepo.GetByUserIDWithCourse(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := []*domain.Enrollment{}
+	for _, enrollment := range enrollments {
+		if enrollment.IsActive() {
+			active = append(active, enrollment)
+		}
+	}
+
+	return active, nil
+}
+
+// GetChildCourseProgress returns a linked student's progress in a course they are actively enrolled in
+func (uc *parentUseCase) GetChildCourseProgress(ctx context.Context, parentID, studentID, courseID uuid.UUID) (*domain.CourseProgress, error) {
+	if err := uc.requireAcceptedLink(ctx, parentID, studentID); err != nil {
+		return nil, err
+	}
+
+	// Expired or cancelled enrollments are hidden from parents, like in GetChildEnrollments
+	enrollment, err := uc.enrollmentRepo.GetByUserAndCourse(ctx, studentID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if !enrollment.IsActive() {
+		return nil, domain.ErrEnrollmentNotFound
+	}
+
+	return uc.progressUseCase.GetCourseProgress(ctx, studentID, courseID)
+}
+
+// GetDashboard aggregates the progress of every linked student
+func (uc *parentUseCase) GetDashboard(ctx context.Context, parentID uuid.UUID) (*domain.ParentDashboard, error) {
+	links, err := uc.parentLinkRepo.ListAcceptedByParent(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &domain.ParentDashboard{
+		Children: []*domain.ChildOverview{},
+	}
+	progressSum := 0
+	for _, link := range links {
+		child, err := uc.childOverview(ctx, link.Student)
+		if err != nil {
+			return nil, err
+		}
+		dashboard.Children = append(dashboard.Children, child)
+
+		dashboard.ActiveCourses += child.ActiveCourses
+		dashboard.CompletedLessons += child.CompletedLessons
+		progressSum += child.AverageProgress * child.ActiveCourses
+	}
+
+	if dashboard.ActiveCourses > 0 {
+		dashboard.AverageProgress = progressSum / dashboard.ActiveCourses
+	}
+
+	return dashboard, nil
+}
+
+// childOverview summarizes the active courses of one student
+func (uc *parentUseCase) childOverview(ctx context.Context, student *domain.LinkedUser) (*domain.ChildOverview, error) {
+	enrollments, err := uc.enrollmentRepo.GetByUserIDWithCourse(ctx, student.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	child := &domain.ChildOverview{
+		Student: student,
+		Courses: []*domain.ChildCourseProgress{},
+	}
+	progressSum := 0
+	for _, enrollment := range enrollments {
+		if !enrollment.IsActive() {
+			continue
+		}
+
+		progress, err := uc.progressRepo.GetCourseProgress(ctx, student.ID, enrollment.CourseID)
+		if err != nil {
+			return nil, err
+		}
+
+		course := &domain.ChildCourseProgress{
+			CourseID:         enrollment.CourseID,
+			EnrollmentStatus: enrollment.Status,
+			ExpiresAt:        enrollment.ExpiresAt,
+			TotalLessons:     progress.TotalLessons,
+			CompletedLessons: progress.CompletedLessons,
+			ProgressPercent:  progress.ProgressPercent,
+		}
+		if enrollment.Course != nil {
+			course.CourseTitle = enrollment.Course.Title
+			course.ImageURL = enrollment.Course.ImageURL
+		}
+		child.Courses = append(child.Courses, course)
+
+		child.ActiveCourses++
+		child.CompletedLessons += progress.CompletedLessons
+		progressSum += progress.ProgressPercent
+	}
+
+	if child.ActiveCourses > 0 {
+		child.AverageProgress = progressSum / child.ActiveCourses
+	}
+
+	return child, nil
+}
+
+// ListParentLinks lists the parents who invited or are linked to a student
+func (uc *parentUseCase) ListParentLinks(ctx context.Context, studentID uuid.UUID) ([]*domain.ParentLink, error) {
+	return uc.parentLinkRepo.ListByStudent(ctx, studentID)
+}
+
+// AcceptParentLink lets a parent follow the student
+func (uc *parentUseCase) AcceptParentLink(ctx context.Context, studentID, linkID uuid.UUID) error {
+	link, err := uc.studentLink(ctx, studentID, linkID)
+	if err != nil {
+		return err
+	}
+
+	if link.IsAccepted() {
+		return nil
+	}
+
+	return uc.parentLinkRepo.Accept(ctx, link.ID)
+}
+
+// RemoveParentLink declines an invite or unlinks a parent
+func (uc *parentUseCase) RemoveParentLink(ctx context.Context, studentID, linkID uuid.UUID) error {
+	link, err := uc.studentLink(ctx, studentID, linkID)
+	if err != nil {
+		return err
+	}
+
+	return uc.parentLinkRepo.Delete(ctx, link.ID)
+}
+
+// requireAcceptedLink checks that the student accepted the parent's invite
+func (uc *parentUseCase) requireAcceptedLink(ctx context.Context, parentID, studentID uuid.UUID) error {
+	link, err := uc.parentLinkRepo.GetByParentAndStudent(ctx, parentID, studentID)
+	if err != nil {
+		return err
+	}
+
+	if !link.IsAccepted() {
+		return domain.ErrParentLinkNotAccepted
+	}
+
+	return nil
+}
+
+// studentLink loads a link addressed to the student, hiding links of other students
+func (uc *parentUseCase) studentLink(ctx context.Context, studentID, linkID uuid.UUID) (*domain.ParentLink, error) {
+	link, err := uc.parentLinkRepo.GetByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if link.StudentID != studentID {
+		return nil, domain.ErrParentLinkNotFound
+	}
+
+	return link, nil
+}
+
+// sendInviteEmail tells the student a parent wants to follow their progress
+func (uc *parentUseCase) sendInviteEmail(ctx context.Context, parent, student *domain.User) error {
+	return uc.mailer.Send(ctx, &mailer.Message{
+		To:      student.Email,
+		Subject: "Lời mời liên kết tài khoản phụ huynh MathVN",
+		Body: fmt.Sprintf(
+			"Xin chào %s,\n\n%s (%s) muốn liên kết tài khoản phụ huynh với tài khoản của bạn để theo dõi các khóa học và tiến độ học tập.\n\nĐăng nhập MathVN để chấp nhận hoặc từ chối lời mời.",
+			student.FullName,
+			parent.FullName,
+			parent.Email,
+		),
+	})
+}
+
+// linkedUser returns the profile of a user shown on a parent link
+func linkedUser(user *domain.User) *domain.LinkedUser {
+	return &domain.LinkedUser{
+		ID:       user.ID,
+		FullName: user.FullName,
+		Email:    user.Email,
+		Avatar:   user.Avatar,
+	}
+}
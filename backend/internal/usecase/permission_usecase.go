@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mathvn/backend/internal/domain"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// PermissionUseCase defines the interface for permission checks
+type PermissionUseCase interface {
+	// HasPermission reports whether a role grants the permission
+	HasPermission(ctx context.Context, role domain.UserRole, permission domain.Permission) (bool, error)
+}
+
+// rolePermissionEntry is the cached permission set of a role and when it must be looked up again
+type rolePermissionEntry struct {
+	permissions map[domain.Permission]bool
+	expiresAt   time.Time
+}
+
+// permissionUseCase implements PermissionUseCase. Permission sets are cached per role
+// so authorized requests do not each need a database round-trip, a change to
+// role_permissions applies once the cached entry expires.
+type permissionUseCase struct {
+	rolePermissionRepo repository.RolePermissionRepository
+	ttl                time.Duration
+
+	mu      sync.RWMutex
+	entries map[domain.UserRole]rolePermissionEntry
+}
+
+// NewPermissionUseCase creates a new permission use case, a zero cacheTTL disables caching
+func NewPermissionUseCase(rolePermissionRepo repository.RolePermissionRepository, cacheTTL time.Duration) PermissionUseCase {
+	return &permissionUseCase{
+		rolePermissionRepo: rolePermissionRepo,
+		ttl:                cacheTTL,
+		entries:            make(map[domain.UserRole]rolePermissionEntry),
+	}
+}
+
+// HasPermission reports whether a role grants the permission
+func (uc *permissionUseCase) HasPermission(ctx context.Context, role domain.UserRole, permission domain.Permission) (bool, error) {
+	permissions, err := uc.rolePermissions(ctx, role)
+	if err != nil {
+		return false, err
+	}
+
+	return permissions[permission], nil
+}
+
+// rolePermissions returns the permission set of a role, from the cache when still fresh
+func (uc *permissionUseCase) rolePermissions(ctx context.Context, role domain.UserRole) (map[domain.Permission]bool, error) {
+	now := time.Now()
+
+	uc.mu.RLock()
+	entry, ok := uc.entries[role]
+	uc.mu.RUnlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.permissions, nil
+	}
+
+	list, err := uc.rolePermissionRepo.ListByRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(map[domain.Permission]bool, len(list))
+	for _, permission := range list {
+		permissions[permission] = true
+	}
+
+	if uc.ttl > 0 {
+		uc.mu.Lock()
+		uc.entries[role] = rolePermissionEntry{permissions: permissions, expiresAt: now.Add(uc.ttl)}
+		uc.mu.Unlock()
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mathvn/backend/internal/repository"
+)
+
+// tokenVersionEntry is a cached token version and when it must be looked up again
+type tokenVersionEntry struct {
+	version   int
+	expiresAt time.Time
+}
+
+// TokenVersionCache keeps token versions for a short time so authenticated requests
+// do not each need a database round-trip. Usecases that bump a version on this
+// instance invalidate the entry, a bump made by another instance is picked up once
+// the cached entry expires.
+type TokenVersionCache struct {
+	userRepo repository.UserRepository
+	ttl      time.Duration
+
+	mu        sync.RWMutex
+	entries   map[uuid.UUID]tokenVersionEntry
+	nextSweep time.Time
+}
+
+// NewTokenVersionCache creates a token version cache, a zero ttl disables caching
+func NewTokenVersionCache(userRepo repository.UserRepository, ttl time.Duration) *TokenVersionCache {
+	return &TokenVersionCache{
+		userRepo:  userRepo,
+		ttl:       ttl,
+		entries:   make(map[uuid.UUID]tokenVersionEntry),
+		nextSweep: time.Now().Add(ttl),
+	}
+}
+
+// get returns the token version of a user, from the cache when still fresh
+func (c *TokenVersionCache) get(ctx context.Context, userID uuid.UUID) (int, error) {
+	now := time.Now()
+
+	c.mu.RLock()
+	entry, ok := c.entries[userID]
+	c.mu.RUnlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.version, nil
+	}
+
+	version, err := c.userRepo.GetTokenVersion(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.ttl > 0 {
+		c.mu.Lock()
+		c.entries[userID] = tokenVersionEntry{version: version, expiresAt: now.Add(c.ttl)}
+		c.sweepLocked(now)
+		c.mu.Unlock()
+	}
+
+	return version, nil
+}
+
+// sweepLocked drops expired entries at most once per ttl, so users who stopped
+// sending requests do not stay in the map. The caller holds the write lock.
+func (c *TokenVersionCache) sweepLocked(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+
+	for userID, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, userID)
+		}
+	}
+	c.nextSweep = now.Add(c.ttl)
+}
+
+// Invalidate drops the cached version of a user after it was bumped
+func (c *TokenVersionCache) Invalidate(userID uuid.UUID) {
+	c.mu.Lock()
+	delete(c.entries, userID)
+	c.mu.Unlock()
+}
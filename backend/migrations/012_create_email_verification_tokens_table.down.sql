@@ -0,0 +1,4 @@
+-- Migration: 012_create_email_verification_tokens_table (rollback)
+-- Description: Drop email_verification_tokens table
+
+DROP TABLE IF EXISTS email_verification_tokens;
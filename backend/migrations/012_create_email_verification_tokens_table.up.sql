@@ -0,0 +1,20 @@
+-- Migration: 012_create_email_verification_tokens_table
+-- Description: Create email_verification_tokens table for the email verification flow
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE DEFAULT NULL, -- NULL means not used yet
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_email_verification_tokens_user_id ON email_verification_tokens(user_id);
+CREATE INDEX idx_email_verification_tokens_expires_at ON email_verification_tokens(expires_at);
+
+-- Add comment
+COMMENT ON TABLE email_verification_tokens IS 'Single-use tokens sent by email to verify user email addresses';
+COMMENT ON COLUMN email_verification_tokens.email IS 'Email address the token was sent to, the token is void if the user changes email';
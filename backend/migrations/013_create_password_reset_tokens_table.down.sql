@@ -0,0 +1,4 @@
+-- Migration: 013_create_password_reset_tokens_table (rollback)
+-- Description: Drop password_reset_tokens table
+
+DROP TABLE IF EXISTS password_reset_tokens;
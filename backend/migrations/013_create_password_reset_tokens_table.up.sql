@@ -0,0 +1,25 @@
+-- Migration: 013_create_password_reset_tokens_table
+-- Description: Create password_reset_tokens table for the forgot-password flow
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL, -- email (reset link) or sms (numeric OTP)
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE DEFAULT NULL, -- NULL means not used yet
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT password_reset_tokens_channel_valid CHECK (channel IN ('email', 'sms')),
+    CONSTRAINT password_reset_tokens_attempts_valid CHECK (attempts >= 0)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens(user_id, created_at DESC);
+CREATE INDEX idx_password_reset_tokens_expires_at ON password_reset_tokens(expires_at);
+
+-- Add comment
+COMMENT ON TABLE password_reset_tokens IS 'Single-use password reset links and OTP codes';
+COMMENT ON COLUMN password_reset_tokens.attempts IS 'Number of wrong codes entered for this token';
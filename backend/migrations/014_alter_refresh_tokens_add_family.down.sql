@@ -0,0 +1,6 @@
+-- Migration: 014_alter_refresh_tokens_add_family (rollback)
+-- Description: Remove refresh token family tracking
+
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS replaced_by;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,16 @@
+-- Migration: 014_alter_refresh_tokens_add_family
+-- Description: Track refresh token rotation chains to detect reuse of rotated tokens
+
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS family_id UUID;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS replaced_by UUID REFERENCES refresh_tokens(id) ON DELETE SET NULL;
+
+-- Existing tokens each start their own family
+UPDATE refresh_tokens SET family_id = id WHERE family_id IS NULL;
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+
+-- Create index for family revocation
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+-- Add comments
+COMMENT ON COLUMN refresh_tokens.family_id IS 'All tokens issued by rotating the same login share a family';
+COMMENT ON COLUMN refresh_tokens.replaced_by IS 'Token issued when this one was rotated. NULL if not rotated.';
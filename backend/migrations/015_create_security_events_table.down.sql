@@ -0,0 +1,4 @@
+-- Migration: 015_create_security_events_table (rollback)
+-- Description: Drop security_events table
+
+DROP TABLE IF EXISTS security_events;
@@ -0,0 +1,20 @@
+-- Migration: 015_create_security_events_table
+-- Description: Create security_events table to record suspicious account activity
+
+CREATE TABLE IF NOT EXISTS security_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- NULL when the account is unknown
+    event_type VARCHAR(100) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_security_events_user_id ON security_events(user_id, created_at DESC);
+CREATE INDEX idx_security_events_event_type ON security_events(event_type);
+CREATE INDEX idx_security_events_created_at ON security_events(created_at DESC);
+
+-- Add comment
+COMMENT ON TABLE security_events IS 'Security relevant events such as refresh token reuse';
@@ -0,0 +1,19 @@
+-- Migration: 016_alter_refresh_tokens_add_device
+-- Description: Record which device each refresh token was issued to so users can manage their sessions
+
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS device_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45) NOT NULL DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Existing tokens were last used when they were issued
+UPDATE refresh_tokens SET last_used_at = created_at;
+
+-- Create index for listing active sessions
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_active ON refresh_tokens(user_id, is_revoked, expires_at);
+
+-- Add comments
+COMMENT ON COLUMN refresh_tokens.device_name IS 'Friendly device name sent by the client or derived from the user agent';
+COMMENT ON COLUMN refresh_tokens.user_agent IS 'User agent of the request that issued the token';
+COMMENT ON COLUMN refresh_tokens.ip_address IS 'Client IP address of the request that issued the token';
+COMMENT ON COLUMN refresh_tokens.last_used_at IS 'Last time the session was used to log in or refresh';
@@ -0,0 +1,4 @@
+-- Migration: 017_alter_users_add_max_sessions (rollback)
+-- Description: Remove the per-user concurrent device limit
+
+ALTER TABLE users DROP COLUMN IF EXISTS max_sessions;
@@ -0,0 +1,7 @@
+-- Migration: 017_alter_users_add_max_sessions
+-- Description: Allow admins to override the concurrent device limit of a user
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_sessions INTEGER CHECK (max_sessions >= 0);
+
+-- Add comments
+COMMENT ON COLUMN users.max_sessions IS 'Active devices allowed for this user, 0 for unlimited. NULL uses the default for the role.';
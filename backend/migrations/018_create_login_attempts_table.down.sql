@@ -0,0 +1,4 @@
+-- Migration: 018_create_login_attempts_table (rollback)
+-- Description: Drop login_attempts table
+
+DROP TABLE IF EXISTS login_attempts;
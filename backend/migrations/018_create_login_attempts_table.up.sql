@@ -0,0 +1,15 @@
+-- Migration: 018_create_login_attempts_table
+-- Description: Create login_attempts table to track failed logins per account and per client IP
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    attempt_key VARCHAR(320) PRIMARY KEY, -- "account:<email or phone>" or "ip:<address>"
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE
+);
+
+-- Create index for cleaning up stale entries
+CREATE INDEX idx_login_attempts_last_failed_at ON login_attempts(last_failed_at);
+
+-- Add comment
+COMMENT ON TABLE login_attempts IS 'Failed login counters used for backoff and temporary lockout';
@@ -0,0 +1,4 @@
+-- Migration: 019_alter_users_add_token_version (rollback)
+-- Description: Remove the per-user token version
+
+ALTER TABLE users DROP COLUMN IF EXISTS token_version;
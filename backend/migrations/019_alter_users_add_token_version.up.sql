@@ -0,0 +1,7 @@
+-- Migration: 019_alter_users_add_token_version
+-- Description: Add a per-user token version to invalidate issued access tokens
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS token_version INTEGER NOT NULL DEFAULT 0;
+
+-- Add comments
+COMMENT ON COLUMN users.token_version IS 'Embedded in access tokens, bumped on role change, deactivation and logout from all devices';
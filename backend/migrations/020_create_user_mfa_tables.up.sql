@@ -0,0 +1,32 @@
+-- Migration: 020_create_user_mfa_tables
+-- Description: Store TOTP secrets and recovery codes for two-factor authentication
+
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    totp_secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMP WITH TIME ZONE, -- NULL while enrollment is not confirmed
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_mfa_recovery_codes_user_id ON mfa_recovery_codes(user_id);
+CREATE UNIQUE INDEX idx_mfa_recovery_codes_user_hash ON mfa_recovery_codes(user_id, code_hash);
+
+-- Sessions remember whether they were opened with a second factor
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS mfa_verified BOOLEAN NOT NULL DEFAULT false;
+
+-- Add comments
+COMMENT ON TABLE user_mfa IS 'TOTP two-factor authentication enrollment per user';
+COMMENT ON COLUMN user_mfa.last_used_step IS 'Last accepted TOTP time step, older or equal steps are rejected to prevent replay';
+COMMENT ON TABLE mfa_recovery_codes IS 'One-time recovery codes for users who lost their authenticator';
+COMMENT ON COLUMN refresh_tokens.mfa_verified IS 'Whether the session was opened with a second factor';
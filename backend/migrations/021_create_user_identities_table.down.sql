@@ -0,0 +1,6 @@
+-- Migration: 021_create_user_identities_table (rollback)
+-- Description: Drop social login identities
+
+DROP INDEX IF EXISTS idx_users_phone_number_unique;
+ALTER TABLE users ADD CONSTRAINT users_phone_number_key UNIQUE (phone_number);
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,25 @@
+-- Migration: 021_create_user_identities_table
+-- Description: Link Google/Facebook accounts to users for social login
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    provider_subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better query performance
+CREATE UNIQUE INDEX idx_user_identities_provider_subject ON user_identities(provider, provider_subject);
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+-- Users created by social login have no phone number yet, only real numbers must be unique
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_phone_number_key;
+CREATE UNIQUE INDEX idx_users_phone_number_unique ON users(phone_number) WHERE phone_number <> '';
+
+-- Add comments
+COMMENT ON TABLE user_identities IS 'Social login accounts linked to users';
+COMMENT ON COLUMN user_identities.provider_subject IS 'Stable account ID at the provider, not the email which can change';
+COMMENT ON COLUMN user_identities.email IS 'Email reported by the provider when the identity was linked';
@@ -0,0 +1,4 @@
+-- Migration: 022_create_login_otps_table (rollback)
+-- Description: Drop login_otps table
+
+DROP TABLE IF EXISTS login_otps;
@@ -0,0 +1,25 @@
+-- Migration: 022_create_login_otps_table
+-- Description: Create login_otps table for passwordless login with an SMS code
+
+CREATE TABLE IF NOT EXISTS login_otps (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    phone_number VARCHAR(20) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- NULL when the number is not registered
+    code_hash VARCHAR(255) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE DEFAULT NULL, -- NULL means not used yet
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT login_otps_attempts_valid CHECK (attempts >= 0)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_login_otps_phone_number ON login_otps(phone_number, created_at DESC);
+CREATE INDEX idx_login_otps_created_at ON login_otps(created_at);
+
+-- Add comment
+COMMENT ON TABLE login_otps IS 'Single-use SMS codes for passwordless login, kept for an hour for rate limiting';
+COMMENT ON COLUMN login_otps.code_hash IS 'SHA-256 of the code salted with the row ID';
+COMMENT ON COLUMN login_otps.attempts IS 'Number of wrong codes entered for this code';
@@ -0,0 +1,4 @@
+-- Migration: 023_create_audit_logs_table (rollback)
+-- Description: Drop audit_logs table
+
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,27 @@
+-- Migration: 023_create_audit_logs_table
+-- Description: Create audit_logs table to record changes made by admins
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Kept after the admin account is deleted
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50) NOT NULL,
+    target_id VARCHAR(100) NOT NULL,
+    before_data JSONB,
+    after_data JSONB,
+    changes JSONB NOT NULL DEFAULT '{}'::jsonb,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id, created_at DESC);
+CREATE INDEX idx_audit_logs_target ON audit_logs(target_type, target_id, created_at DESC);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at DESC);
+
+-- Add comments
+COMMENT ON TABLE audit_logs IS 'Changes made by admins, one row per mutation';
+COMMENT ON COLUMN audit_logs.before_data IS 'Target as JSON before the change, NULL for creations';
+COMMENT ON COLUMN audit_logs.after_data IS 'Target as JSON after the change, NULL for deletions';
+COMMENT ON COLUMN audit_logs.changes IS 'Changed fields as {"field": {"old": ..., "new": ...}}';
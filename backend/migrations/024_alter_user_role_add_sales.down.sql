@@ -0,0 +1,4 @@
+-- Migration: 024_alter_user_role_add_sales (rollback)
+-- Description: PostgreSQL cannot drop an enum value, sales accounts fall back to student
+
+UPDATE users SET role = 'student' WHERE role = 'sales';
@@ -0,0 +1,4 @@
+-- Migration: 024_alter_user_role_add_sales
+-- Description: Add a sales role for staff who handle consultation requests
+
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'sales';
@@ -0,0 +1,4 @@
+-- Migration: 025_create_role_permissions_table (rollback)
+-- Description: Drop role_permissions table
+
+DROP TABLE IF EXISTS role_permissions;
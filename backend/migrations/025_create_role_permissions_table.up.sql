@@ -0,0 +1,30 @@
+-- Migration: 025_create_role_permissions_table
+-- Description: Create role_permissions table mapping roles to the permissions they grant
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role user_role NOT NULL,
+    permission VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (role, permission)
+);
+
+-- Default permissions: admins hold every permission, sales staff handle consultations
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'stats.view'),
+    ('admin', 'users.view'),
+    ('admin', 'users.manage'),
+    ('admin', 'users.impersonate'),
+    ('admin', 'courses.write'),
+    ('admin', 'codes.view'),
+    ('admin', 'codes.create'),
+    ('admin', 'codes.manage'),
+    ('admin', 'consultations.view'),
+    ('admin', 'consultations.manage'),
+    ('admin', 'audit_logs.view'),
+    ('sales', 'consultations.view'),
+    ('sales', 'consultations.manage')
+ON CONFLICT DO NOTHING;
+
+-- Add comments
+COMMENT ON TABLE role_permissions IS 'Permissions granted to each role, checked by the API on protected routes';
+COMMENT ON COLUMN role_permissions.permission IS 'Permission name like courses.write, see domain.Permission';
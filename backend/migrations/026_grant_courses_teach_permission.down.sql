@@ -0,0 +1,4 @@
+-- Migration: 026_grant_courses_teach_permission (rollback)
+-- Description: Revoke the teacher portal permission
+
+DELETE FROM role_permissions WHERE permission = 'courses.teach';
@@ -0,0 +1,7 @@
+-- Migration: 026_grant_courses_teach_permission
+-- Description: Let teachers manage the courses they own in the teacher portal
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('teacher', 'courses.teach'),
+    ('admin', 'courses.teach')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+-- Migration: 027_create_course_staff_table (rollback)
+-- Description: Drop course_staff table
+
+DROP TABLE IF EXISTS course_staff;
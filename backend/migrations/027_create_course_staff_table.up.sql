@@ -0,0 +1,24 @@
+-- Migration: 027_create_course_staff_table
+-- Description: Create course_staff table for co-instructors and teaching assistants
+
+CREATE TABLE IF NOT EXISTS course_staff (
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'co_instructor', 'ta')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (course_id, user_id)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_course_staff_user_id ON course_staff(user_id);
+CREATE UNIQUE INDEX idx_course_staff_owner ON course_staff(course_id) WHERE role = 'owner';
+
+-- Every existing instructor owns their course
+INSERT INTO course_staff (course_id, user_id, role)
+SELECT id, instructor_id, 'owner' FROM courses
+ON CONFLICT DO NOTHING;
+
+-- Add comments
+COMMENT ON TABLE course_staff IS 'Teachers of a course, the owner is also courses.instructor_id';
+COMMENT ON COLUMN course_staff.role IS 'owner manages the course and its staff, co_instructor edits content, ta only views';
@@ -0,0 +1,6 @@
+-- Migration: 028_alter_users_add_deletion (rollback)
+-- Description: Remove account deletion tracking
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
@@ -0,0 +1,13 @@
+-- Migration: 028_alter_users_add_deletion
+-- Description: Track account deletions requested by users and accounts already anonymized
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Create indexes for better query performance
+CREATE INDEX idx_users_deletion_scheduled_at ON users(deletion_scheduled_at)
+    WHERE deletion_scheduled_at IS NOT NULL AND deleted_at IS NULL;
+
+-- Add comments
+COMMENT ON COLUMN users.deletion_scheduled_at IS 'When the account is anonymized, cleared if the user signs in during the grace period';
+COMMENT ON COLUMN users.deleted_at IS 'When personal data was anonymized, the row stays so enrollments and stats remain intact';
@@ -0,0 +1,4 @@
+-- Migration: 029_alter_user_role_add_parent (rollback)
+-- Description: PostgreSQL cannot drop an enum value, parent accounts fall back to student
+
+UPDATE users SET role = 'student' WHERE role = 'parent';
@@ -0,0 +1,4 @@
+-- Migration: 029_alter_user_role_add_parent
+-- Description: Add a parent role for accounts that follow the progress of their children
+
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'parent';
@@ -0,0 +1,5 @@
+-- Migration: 030_create_parent_links_table (rollback)
+-- Description: Drop parent_links table and the parent permission
+
+DELETE FROM role_permissions WHERE permission = 'children.view';
+DROP TABLE IF EXISTS parent_links;
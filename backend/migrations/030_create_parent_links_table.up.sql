@@ -0,0 +1,26 @@
+-- Migration: 030_create_parent_links_table
+-- Description: Create parent_links table linking parent accounts to student accounts
+
+CREATE TABLE IF NOT EXISTS parent_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    parent_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted')),
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (parent_id, student_id),
+    CHECK (parent_id <> student_id)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_parent_links_student_id ON parent_links(student_id);
+
+-- Parents see their linked children
+INSERT INTO role_permissions (role, permission) VALUES
+    ('parent', 'children.view')
+ON CONFLICT DO NOTHING;
+
+-- Add comments
+COMMENT ON TABLE parent_links IS 'Parent to student links, created by the parent and accepted by the student';
+COMMENT ON COLUMN parent_links.status IS 'pending until the student accepts, a declined invite is deleted';
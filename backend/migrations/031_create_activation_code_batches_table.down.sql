@@ -0,0 +1,6 @@
+-- Migration: 031_create_activation_code_batches_table (rollback)
+-- Description: Drop activation_code_batches table, the generated codes stay as single codes
+
+DROP INDEX IF EXISTS idx_activation_codes_batch_id;
+ALTER TABLE activation_codes DROP COLUMN IF EXISTS batch_id;
+DROP TABLE IF EXISTS activation_code_batches;
@@ -0,0 +1,30 @@
+-- Migration: 031_create_activation_code_batches_table
+-- Description: Create activation_code_batches table for codes generated in bulk for printing
+
+CREATE TABLE IF NOT EXISTS activation_code_batches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    label VARCHAR(255) NOT NULL,
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    prefix VARCHAR(10),
+    quantity INTEGER NOT NULL,
+    max_uses INTEGER DEFAULT NULL, -- NULL means unlimited, applies to every code
+    expires_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT activation_code_batches_quantity_positive CHECK (quantity > 0),
+    CONSTRAINT activation_code_batches_max_uses_positive CHECK (max_uses IS NULL OR max_uses > 0)
+);
+
+ALTER TABLE activation_codes ADD COLUMN IF NOT EXISTS batch_id UUID REFERENCES activation_code_batches(id) ON DELETE SET NULL;
+
+-- Create indexes for better query performance
+CREATE INDEX idx_activation_code_batches_course_id ON activation_code_batches(course_id);
+CREATE INDEX idx_activation_code_batches_created_at ON activation_code_batches(created_at DESC);
+CREATE INDEX idx_activation_codes_batch_id ON activation_codes(batch_id) WHERE batch_id IS NOT NULL;
+
+-- Add comments
+COMMENT ON TABLE activation_code_batches IS 'Activation codes generated together, e.g. for a bookstore or a school';
+COMMENT ON COLUMN activation_code_batches.prefix IS 'Prepended to every code of the batch, e.g. BOOK-XXXXXXXX';
+COMMENT ON COLUMN activation_codes.batch_id IS 'Batch the code was generated in, NULL for codes created one by one';
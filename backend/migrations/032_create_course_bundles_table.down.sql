@@ -0,0 +1,6 @@
+-- Migration: 032_create_course_bundles_table (rollback)
+-- Description: Drop course_bundles and course_bundle_courses tables
+
+DROP TRIGGER IF EXISTS update_course_bundles_updated_at ON course_bundles;
+DROP TABLE IF EXISTS course_bundle_courses;
+DROP TABLE IF EXISTS course_bundles;
@@ -0,0 +1,32 @@
+-- Migration: 032_create_course_bundles_table
+-- Description: Create course_bundles table for packages of courses sold together, e.g. "Grade 9 full year"
+
+CREATE TABLE IF NOT EXISTS course_bundles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS course_bundle_courses (
+    bundle_id UUID NOT NULL REFERENCES course_bundles(id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (bundle_id, course_id)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_course_bundles_created_at ON course_bundles(created_at DESC);
+CREATE INDEX idx_course_bundle_courses_course_id ON course_bundle_courses(course_id);
+
+-- Create trigger for auto-updating updated_at
+CREATE TRIGGER update_course_bundles_updated_at
+    BEFORE UPDATE ON course_bundles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments
+COMMENT ON TABLE course_bundles IS 'Packages of courses unlocked together by one activation code';
+COMMENT ON COLUMN course_bundle_courses.position IS 'Display order of the course in the bundle';
@@ -0,0 +1,17 @@
+-- Migration: 033_alter_activation_codes_add_access_policy (rollback)
+-- Description: Drop activation code access policy, codes go back to one course for one year
+
+DROP TABLE IF EXISTS activation_code_courses;
+DROP INDEX IF EXISTS idx_activation_codes_bundle_id;
+ALTER TABLE activation_code_batches
+    DROP COLUMN IF EXISTS bundle_id,
+    DROP COLUMN IF EXISTS access_until,
+    DROP COLUMN IF EXISTS access_days,
+    DROP COLUMN IF EXISTS access_type;
+ALTER TABLE activation_codes
+    DROP CONSTRAINT IF EXISTS activation_codes_access_policy_check,
+    DROP CONSTRAINT IF EXISTS activation_codes_access_type_check,
+    DROP COLUMN IF EXISTS bundle_id,
+    DROP COLUMN IF EXISTS access_until,
+    DROP COLUMN IF EXISTS access_days,
+    DROP COLUMN IF EXISTS access_type;
@@ -0,0 +1,39 @@
+-- Migration: 033_alter_activation_codes_add_access_policy
+-- Description: Let activation codes choose how long access lasts and unlock several courses or a bundle
+
+ALTER TABLE activation_codes
+    ADD COLUMN IF NOT EXISTS access_type VARCHAR(20) NOT NULL DEFAULT 'days',
+    ADD COLUMN IF NOT EXISTS access_days INTEGER DEFAULT 365,
+    ADD COLUMN IF NOT EXISTS access_until TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS bundle_id UUID REFERENCES course_bundles(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT activation_codes_access_type_check CHECK (access_type IN ('lifetime', 'days', 'until')),
+    ADD CONSTRAINT activation_codes_access_policy_check CHECK (
+        (access_type = 'days' AND access_days > 0) OR
+        (access_type = 'until' AND access_until IS NOT NULL) OR
+        access_type = 'lifetime'
+    );
+
+-- Batches keep the policy their codes were generated with
+ALTER TABLE activation_code_batches
+    ADD COLUMN IF NOT EXISTS access_type VARCHAR(20) NOT NULL DEFAULT 'days',
+    ADD COLUMN IF NOT EXISTS access_days INTEGER DEFAULT 365,
+    ADD COLUMN IF NOT EXISTS access_until TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS bundle_id UUID REFERENCES course_bundles(id) ON DELETE RESTRICT;
+
+-- Courses unlocked by a code besides activation_codes.course_id
+CREATE TABLE IF NOT EXISTS activation_code_courses (
+    activation_code_id UUID NOT NULL REFERENCES activation_codes(id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    PRIMARY KEY (activation_code_id, course_id)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_activation_codes_bundle_id ON activation_codes(bundle_id) WHERE bundle_id IS NOT NULL;
+CREATE INDEX idx_activation_code_courses_course_id ON activation_code_courses(course_id);
+
+-- Add comments
+COMMENT ON COLUMN activation_codes.access_type IS 'lifetime, days (access_days from activation) or until (fixed access_until date)';
+COMMENT ON COLUMN activation_codes.access_days IS 'Days of access from activation when access_type is days, existing codes keep one year';
+COMMENT ON COLUMN activation_codes.access_until IS 'End of access when access_type is until';
+COMMENT ON COLUMN activation_codes.bundle_id IS 'Bundle whose courses are unlocked too, resolved at activation';
+COMMENT ON TABLE activation_code_courses IS 'Extra courses unlocked by an activation code';
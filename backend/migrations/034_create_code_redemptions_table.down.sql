@@ -0,0 +1,4 @@
+-- Migration: 034_create_code_redemptions_table (rollback)
+-- Description: Drop code_redemptions table
+
+DROP TABLE IF EXISTS code_redemptions;
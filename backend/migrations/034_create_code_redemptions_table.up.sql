@@ -0,0 +1,21 @@
+-- Migration: 034_create_code_redemptions_table
+-- Description: Create code_redemptions table recording who redeemed an activation code, when and from where
+
+CREATE TABLE IF NOT EXISTS code_redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    activation_code_id UUID NOT NULL REFERENCES activation_codes(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    courses_unlocked INTEGER NOT NULL DEFAULT 1,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    redeemed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_code_redemptions_activation_code_id ON code_redemptions(activation_code_id, redeemed_at DESC);
+CREATE INDEX idx_code_redemptions_user_id ON code_redemptions(user_id);
+
+-- Add comments
+COMMENT ON TABLE code_redemptions IS 'One row per successful activation code redemption, codes redeemed before this table have none';
+COMMENT ON COLUMN code_redemptions.courses_unlocked IS 'Enrollments created or renewed by the redemption';
+COMMENT ON COLUMN code_redemptions.ip_address IS 'Client IP of the redemption, cleared when the account is deleted';
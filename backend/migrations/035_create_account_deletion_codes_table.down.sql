@@ -0,0 +1,4 @@
+-- Migration: 035_create_account_deletion_codes_table (rollback)
+-- Description: Drop account_deletion_codes table
+
+DROP TABLE IF EXISTS account_deletion_codes;
@@ -0,0 +1,22 @@
+-- Migration: 035_create_account_deletion_codes_table
+-- Description: Create account_deletion_codes table for confirming account deletion by email
+
+CREATE TABLE IF NOT EXISTS account_deletion_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE DEFAULT NULL, -- NULL means not used yet
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT account_deletion_codes_attempts_valid CHECK (attempts >= 0)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_account_deletion_codes_user_id ON account_deletion_codes(user_id, created_at DESC);
+
+-- Add comment
+COMMENT ON TABLE account_deletion_codes IS 'Single-use codes sent by email to confirm deleting an account';
+COMMENT ON COLUMN account_deletion_codes.attempts IS 'Number of wrong codes entered for this code';
@@ -0,0 +1,178 @@
+// Package jwtkeys manages the keys used to sign and verify access tokens.
+// Asymmetric keys are identified by kid so old keys keep verifying tokens after rotation.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mathvn/backend/config"
+)
+
+// ErrUnknownKey is returned when a token references a key that is not in the set
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Key is a signing or verification key identified by its kid
+type Key struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.Signer    // nil for keys that only verify tokens issued before a rotation
+	PublicKey  crypto.PublicKey // nil for the shared secret
+	secret     []byte
+}
+
+// KeySet signs tokens with the active key and verifies them with any key of the set
+type KeySet struct {
+	active *Key
+	keys   map[string]*Key
+}
+
+// New creates a key set from a list of keys, signing with the key identified by activeID
+func New(keys []*Key, activeID string) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*Key, len(keys))}
+	for _, key := range keys {
+		if _, exists := ks.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate key id: %s", key.ID)
+		}
+		ks.keys[key.ID] = key
+	}
+
+	// Without an explicit choice a single private key is used for signing
+	if activeID == "" {
+		var signers []string
+		for id, key := range ks.keys {
+			if key.PrivateKey != nil {
+				signers = append(signers, id)
+			}
+		}
+		if len(signers) != 1 {
+			return nil, errors.New("JWT_ACTIVE_KEY_ID must be set when there is not exactly one private key")
+		}
+		activeID = signers[0]
+	}
+
+	active, ok := ks.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q not found", activeID)
+	}
+	if active.PrivateKey == nil {
+		return nil, fmt.Errorf("active key %q has no private key", activeID)
+	}
+	ks.active = active
+
+	return ks, nil
+}
+
+// NewHMAC creates a key set that signs and verifies with a shared secret (HS256).
+// It is kept for deployments that have not configured asymmetric keys yet.
+func NewHMAC(secret string) *KeySet {
+	key := &Key{Method: jwt.SigningMethodHS256, secret: []byte(secret)}
+	return &KeySet{active: key, keys: map[string]*Key{"": key}}
+}
+
+// Load builds the key set from the JWT configuration. PEM keys are read from
+// cfg.KeysDir and cfg.Keys, without any the shared access token secret is used.
+func Load(cfg *config.JWTConfig) (*KeySet, error) {
+	var keys []*Key
+
+	if cfg.KeysDir != "" {
+		dirKeys, err := loadDir(cfg.KeysDir)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, dirKeys...)
+	}
+
+	if cfg.Keys != "" {
+		envKeys, err := loadEnv(cfg.Keys)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, envKeys...)
+	}
+
+	if len(keys) == 0 {
+		return NewHMAC(cfg.AccessTokenSecret), nil
+	}
+
+	return New(keys, cfg.ActiveKeyID)
+}
+
+// IsSymmetric reports whether tokens are signed with the shared secret
+func (ks *KeySet) IsSymmetric() bool {
+	return ks.active.secret != nil
+}
+
+// ActiveKeyID returns the kid of the key used for signing
+func (ks *KeySet) ActiveKeyID() string {
+	return ks.active.ID
+}
+
+// Sign signs the claims with the active key and sets the kid header
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.active.Method, claims)
+
+	if ks.active.secret != nil {
+		return token.SignedString(ks.active.secret)
+	}
+
+	token.Header["kid"] = ks.active.ID
+	return token.SignedString(ks.active.PrivateKey)
+}
+
+// Keyfunc selects the verification key of a token by its kid, for use with jwt.Parse
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if ks.IsSymmetric() {
+		if token.Method != ks.active.Method {
+			return nil, ErrUnknownKey
+		}
+		return ks.active.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok || key.secret != nil || key.Method.Alg() != token.Method.Alg() {
+		return nil, ErrUnknownKey
+	}
+
+	return key.PublicKey, nil
+}
+
+// Algorithms returns the signing algorithms accepted when verifying tokens
+func (ks *KeySet) Algorithms() []string {
+	seen := make(map[string]bool)
+	var algs []string
+	for _, key := range ks.keys {
+		alg := key.Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	sort.Strings(algs)
+	return algs
+}
+
+// newKey creates a key for a parsed private or public key, choosing the algorithm from the key type
+func newKey(id string, parsed interface{}) (*Key, error) {
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("key %s: RSA keys must be at least 2048 bits", id)
+		}
+		return &Key{ID: id, Method: jwt.SigningMethodRS256, PrivateKey: k, PublicKey: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &Key{ID: id, Method: jwt.SigningMethodRS256, PublicKey: k}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: id, Method: jwt.SigningMethodEdDSA, PrivateKey: k, PublicKey: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: id, Method: jwt.SigningMethodEdDSA, PublicKey: k}, nil
+	default:
+		return nil, fmt.Errorf("key %s: unsupported key type %T, use RSA or Ed25519", id, parsed)
+	}
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// logMailer prints emails to the log and optionally writes them to files, for local development
+type logMailer struct {
+	from string
+	dir  string
+}
+
+// NewLogMailer creates a mailer that logs messages instead of sending them.
+// If dir is not empty, every message is also written to an .eml file in that directory.
+func NewLogMailer(from, dir string) Mailer {
+	return &logMailer{
+		from: from,
+		dir:  dir,
+	}
+}
+
+// Send logs an email message
+func (m *logMailer) Send(ctx context.Context, msg *Message) error {
+	log.Printf("📧 Mail to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+
+	if m.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102T150405.000000000"), msg.To)
+	return os.WriteFile(filepath.Join(m.dir, name), buildMessage(m.from, msg), 0o644)
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mathvn/backend/config"
+)
+
+// Message represents an email message
+type Message struct {
+	To      string
+	Subject string
+	Body    string // Plain text body
+}
+
+// Mailer sends email messages
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// New creates a mailer for the configured driver
+func New(cfg *config.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg), nil
+	case "log", "":
+		return NewLogMailer(cfg.From, cfg.LogDir), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,75 @@
+package oauth
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/mathvn/backend/config"
+)
+
+const (
+	facebookAuthURL  = "https://www.facebook.com/v19.0/dialog/oauth"
+	facebookTokenURL = "https://graph.facebook.com/v19.0/oauth/access_token"
+	facebookMeURL    = "https://graph.facebook.com/v19.0/me?fields=id,name,email,picture.type(large)"
+)
+
+// facebookProvider signs users in with Facebook Login
+type facebookProvider struct {
+	cfg *config.OAuthProviderConfig
+}
+
+// NewFacebookProvider creates the Facebook provider
+func NewFacebookProvider(cfg *config.OAuthProviderConfig) Provider {
+	return &facebookProvider{cfg: cfg}
+}
+
+// Name returns the provider name
+func (p *facebookProvider) Name() string {
+	return "facebook"
+}
+
+// AuthCodeURL returns the Facebook sign-in URL
+func (p *facebookProvider) AuthCodeURL(state string) string {
+	return buildURL(facebookAuthURL, url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.cfg.ClientID},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"scope":         {"email public_profile"},
+		"state":         {state},
+	})
+}
+
+// Exchange trades the code for the user's Facebook identity
+func (p *facebookProvider) Exchange(ctx context.Context, code string) (*Identity, error) {
+	accessToken, err := exchangeCode(ctx, facebookTokenURL, p.cfg, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var me struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Email   string `json:"email"`
+		Picture struct {
+			Data struct {
+				URL string `json:"url"`
+			} `json:"data"`
+		} `json:"picture"`
+	}
+	if err := getJSON(ctx, facebookMeURL, accessToken, &me); err != nil {
+		return nil, err
+	}
+	if me.ID == "" {
+		return nil, ErrExchangeFailed
+	}
+
+	// Facebook does not say whether the address was confirmed, so it is never trusted
+	// to sign in to or create an account. The user links Facebook while signed in instead.
+	return &Identity{
+		Subject:       me.ID,
+		Email:         me.Email,
+		EmailVerified: false,
+		Name:          me.Name,
+		AvatarURL:     me.Picture.Data.URL,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mathvn/backend/config"
+)
+
+// ErrExchangeFailed is returned when the provider rejects the authorization code
+var ErrExchangeFailed = errors.New("oauth code exchange failed")
+
+// Identity represents the account a user signed in with at a provider
+type Identity struct {
+	Subject       string // Stable account ID at the provider
+	Email         string
+	EmailVerified bool
+	Name          string
+	AvatarURL     string
+}
+
+// Provider is an OAuth2 or OpenID Connect identity provider
+type Provider interface {
+	// Name returns the provider name used in URLs, e.g. "google"
+	Name() string
+
+	// AuthCodeURL returns the URL the browser is sent to for signing in
+	AuthCodeURL(state string) string
+
+	// Exchange trades the authorization code from the callback for the user's identity
+	Exchange(ctx context.Context, code string) (*Identity, error)
+}
+
+// Registry holds the enabled providers by name
+type Registry map[string]Provider
+
+// New creates a registry with the providers that have credentials configured
+func New(cfg *config.OAuthConfig) Registry {
+	registry := Registry{}
+	if cfg.Google.ClientID != "" {
+		registry.Register(NewGoogleProvider(&cfg.Google))
+	}
+	if cfg.Facebook.ClientID != "" {
+		registry.Register(NewFacebookProvider(&cfg.Facebook))
+	}
+	if cfg.MockEnabled {
+		registry.Register(NewMockProvider(cfg.MockRedirectURL))
+	}
+	return registry
+}
+
+// Register adds a provider, replacing one with the same name
+func (r Registry) Register(provider Provider) {
+	r[provider.Name()] = provider
+}
+
+// Get returns the provider with the given name
+func (r Registry) Get(name string) (Provider, bool) {
+	provider, ok := r[name]
+	return provider, ok
+}
+
+// Names returns the names of the enabled providers in alphabetical order
+func (r Registry) Names() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// httpClient is shared by the providers that talk to real endpoints
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// buildURL appends query parameters to an endpoint
+func buildURL(endpoint string, params url.Values) string {
+	return endpoint + "?" + params.Encode()
+}
+
+// exchangeCode posts the authorization code to a token endpoint and returns the access token
+func exchangeCode(ctx context.Context, tokenURL string, cfg *config.OAuthProviderConfig, code string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"client_id":     {cfg.ClientID},
+		"client_secret": {cfg.ClientSecret},
+		"redirect_uri":  {cfg.RedirectURL},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := doJSON(req, &token); err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", ErrExchangeFailed
+	}
+
+	return token.AccessToken, nil
+}
+
+// getJSON fetches a user info endpoint with a bearer access token
+func getJSON(ctx context.Context, endpoint, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	return doJSON(req, out)
+}
+
+// doJSON sends a request and decodes a JSON response, 4xx responses mean the code was rejected
+func doJSON(req *http.Request, out interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return ErrExchangeFailed
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("oauth provider returned status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,58 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// httpSender sends messages through an SMS gateway accepting JSON requests
+type httpSender struct {
+	gatewayURL string
+	apiKey     string
+	client     *http.Client
+}
+
+// NewHTTPSender creates a sender that posts {"to", "message"} to the gateway URL
+func NewHTTPSender(gatewayURL, apiKey string) Sender {
+	return &httpSender{
+		gatewayURL: gatewayURL,
+		apiKey:     apiKey,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send sends a text message
+func (s *httpSender) Send(ctx context.Context, phoneNumber, message string) error {
+	body, err := json.Marshal(map[string]string{
+		"to":      phoneNumber,
+		"message": message,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.gatewayURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sms gateway returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
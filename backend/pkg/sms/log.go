@@ -0,0 +1,43 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// logSender prints messages to the log and optionally appends them to a file, for local development
+type logSender struct {
+	dir string
+}
+
+// NewLogSender creates a sender that logs messages instead of sending them.
+// If dir is not empty, every message is also appended to sms.log in that directory.
+func NewLogSender(dir string) Sender {
+	return &logSender{dir: dir}
+}
+
+// Send logs a text message
+func (s *logSender) Send(ctx context.Context, phoneNumber, message string) error {
+	log.Printf("📱 SMS to %s: %s", phoneNumber, message)
+
+	if s.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, "sms.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s\t%s\t%s\n", time.Now().Format(time.RFC3339), phoneNumber, message)
+	return err
+}
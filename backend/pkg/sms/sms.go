@@ -0,0 +1,25 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mathvn/backend/config"
+)
+
+// Sender sends text messages to phone numbers
+type Sender interface {
+	Send(ctx context.Context, phoneNumber, message string) error
+}
+
+// New creates an SMS sender for the configured driver
+func New(cfg *config.SMSConfig) (Sender, error) {
+	switch cfg.Driver {
+	case "http":
+		return NewHTTPSender(cfg.GatewayURL, cfg.APIKey), nil
+	case "log", "":
+		return NewLogSender(cfg.LogDir), nil
+	default:
+		return nil, fmt.Errorf("unknown sms driver: %s", cfg.Driver)
+	}
+}
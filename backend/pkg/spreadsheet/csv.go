@@ -0,0 +1,18 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+// utf8BOM is written by Excel at the start of CSV files saved as UTF-8
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ReadCSV returns the rows of a comma separated file
+func ReadCSV(data []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+	reader.FieldsPerRecord = -1 // Rows may have fewer cells than the header
+	reader.TrimLeadingSpace = true
+
+	return reader.ReadAll()
+}
@@ -0,0 +1,51 @@
+// Package spreadsheet reads the rows of CSV and XLSX files uploaded by admins.
+package spreadsheet
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither CSV nor XLSX
+var ErrUnsupportedFormat = errors.New("unsupported spreadsheet format")
+
+// Read returns the rows of a CSV or XLSX file, picking the format from the file name.
+// Cells are trimmed and trailing empty rows are dropped.
+func Read(filename string, data []byte) ([][]string, error) {
+	var rows [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		rows, err = ReadCSV(data)
+	case ".xlsx":
+		rows, err = ReadXLSX(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+	}
+	for len(rows) > 0 && isEmpty(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+
+	return rows, nil
+}
+
+// isEmpty reports whether every cell of a row is blank
+func isEmpty(row []string) bool {
+	for _, cell := range row {
+		if cell != "" {
+			return false
+		}
+	}
+	return true
+}